@@ -6,13 +6,26 @@ import (
 
 // API handle the API specification
 type API struct {
-	KibanaSpaces           *KibanaSpacesAPI
-	KibanaRoleManagement   *KibanaRoleManagementAPI
-	KibanaDashboard        *KibanaDashboardAPI
-	KibanaSavedObject      *KibanaSavedObjectAPI
-	KibanaStatus           *KibanaStatusAPI
-	KibanaLogstashPipeline *KibanaLogstashPipelineAPI
-	KibanaShortenURL       *KibanaShortenURLAPI
+	KibanaSpaces             *KibanaSpacesAPI
+	KibanaRoleManagement     *KibanaRoleManagementAPI
+	KibanaDashboard          *KibanaDashboardAPI
+	KibanaSavedObject        *KibanaSavedObjectAPI
+	KibanaStatus             *KibanaStatusAPI
+	KibanaLogstashPipeline   *KibanaLogstashPipelineAPI
+	KibanaShortenURL         *KibanaShortenURLAPI
+	KibanaSecurity           *KibanaSecurityAPI
+	KibanaDataViews          *KibanaDataViewsAPI
+	KibanaAlertingRules      *KibanaAlertingRulesAPI
+	KibanaConnectors         *KibanaConnectorsAPI
+	KibanaMaintenanceWindows *KibanaMaintenanceWindowsAPI
+	KibanaCases              *KibanaCasesAPI
+	KibanaFleet              *KibanaFleetAPI
+	KibanaDetectionRules     *KibanaDetectionRulesAPI
+	KibanaExceptionLists     *KibanaExceptionListsAPI
+	KibanaValueLists         *KibanaValueListsAPI
+	KibanaTimelines          *KibanaTimelinesAPI
+	KibanaSavedQueries       *KibanaSavedQueriesAPI
+	KibanaSettings           *KibanaSettingsAPI
 }
 
 // KibanaSpacesAPI handle the spaces API
@@ -27,16 +40,21 @@ type KibanaSpacesAPI struct {
 
 // KibanaRoleManagementAPI handle the role management API
 type KibanaRoleManagementAPI struct {
-	Get            KibanaRoleManagementGet
-	List           KibanaRoleManagementList
-	CreateOrUpdate KibanaRoleManagementCreateOrUpdate
-	Delete         KibanaRoleManagementDelete
+	Get                KibanaRoleManagementGet
+	List               KibanaRoleManagementList
+	CreateOrUpdate     KibanaRoleManagementCreateOrUpdate
+	Create             KibanaRoleManagementCreate
+	Delete             KibanaRoleManagementDelete
+	BulkCreateOrUpdate KibanaRoleManagementBulkCreateOrUpdate
+	Query              KibanaRoleManagementQuery
 }
 
 // KibanaDashboardAPI handle the dashboard API
 type KibanaDashboardAPI struct {
-	Export KibanaDashboardExport
-	Import KibanaDashboardImport
+	Export       KibanaDashboardExport
+	Import       KibanaDashboardImport
+	ExportNDJSON KibanaDashboardExportNDJSON
+	ImportNDJSON KibanaDashboardImportNDJSON
 }
 
 // KibanaSavedObjectAPI handle the saved object API
@@ -52,7 +70,10 @@ type KibanaSavedObjectAPI struct {
 
 // KibanaStatusAPI handle the status API
 type KibanaStatusAPI struct {
-	Get KibanaStatusGet
+	Get            KibanaStatusGet
+	GetDetails     KibanaStatusGetDetails
+	WaitUntilReady KibanaStatusWaitUntilReady
+	GetVersion     KibanaStatusGetVersion
 }
 
 // KibanaLogstashPipelineAPI handle the logstash configuration management API
@@ -65,11 +86,279 @@ type KibanaLogstashPipelineAPI struct {
 
 // KibanaShortenURLAPI handle the shorten URL API
 type KibanaShortenURLAPI struct {
-	Create KibanaShortenURLCreate
+	Create  KibanaShortenURLCreate
+	Get     KibanaShortenURLGet
+	Resolve KibanaShortenURLResolve
+	Delete  KibanaShortenURLDelete
+}
+
+// KibanaSecurityAPI handle the security API
+type KibanaSecurityAPI struct {
+	Privileges         KibanaSecurityPrivileges
+	InvalidateSessions KibanaSecurityInvalidateSessions
+}
+
+// KibanaDataViewsAPI handle the data views API
+type KibanaDataViewsAPI struct {
+	List               KibanaDataViewList
+	Get                KibanaDataViewGet
+	Create             KibanaDataViewCreate
+	Update             KibanaDataViewUpdate
+	Delete             KibanaDataViewDelete
+	GetDefault         KibanaDataViewGetDefault
+	SetDefault         KibanaDataViewSetDefault
+	UpdateFields       KibanaDataViewUpdateFields
+	GetRuntimeField    KibanaDataViewGetRuntimeField
+	CreateRuntimeField KibanaDataViewCreateRuntimeField
+	UpdateRuntimeField KibanaDataViewUpdateRuntimeField
+	DeleteRuntimeField KibanaDataViewDeleteRuntimeField
+}
+
+// KibanaAlertingRulesAPI handle the alerting rules API
+type KibanaAlertingRulesAPI struct {
+	Create       KibanaAlertingRuleCreate
+	Get          KibanaAlertingRuleGet
+	Update       KibanaAlertingRuleUpdate
+	Delete       KibanaAlertingRuleDelete
+	Find         KibanaAlertingRuleFind
+	Enable       KibanaAlertingRuleEnable
+	Disable      KibanaAlertingRuleDisable
+	MuteAll      KibanaAlertingRuleMuteAll
+	UnmuteAll    KibanaAlertingRuleUnmuteAll
+	MuteAlert    KibanaAlertingRuleMuteAlert
+	UnmuteAlert  KibanaAlertingRuleUnmuteAlert
+	UpdateAPIKey KibanaAlertingRuleUpdateAPIKey
+	Health       KibanaAlertingRuleHealth
+}
+
+// KibanaConnectorsAPI handle the connectors API
+type KibanaConnectorsAPI struct {
+	Create    KibanaConnectorCreate
+	Get       KibanaConnectorGet
+	List      KibanaConnectorList
+	Update    KibanaConnectorUpdate
+	Delete    KibanaConnectorDelete
+	ListTypes KibanaConnectorListTypes
+	Execute   KibanaConnectorExecute
+}
+
+// KibanaMaintenanceWindowsAPI handle the maintenance windows API
+type KibanaMaintenanceWindowsAPI struct {
+	Create    KibanaMaintenanceWindowCreate
+	Get       KibanaMaintenanceWindowGet
+	Update    KibanaMaintenanceWindowUpdate
+	Delete    KibanaMaintenanceWindowDelete
+	Find      KibanaMaintenanceWindowFind
+	Archive   KibanaMaintenanceWindowArchive
+	Unarchive KibanaMaintenanceWindowUnarchive
+	Finish    KibanaMaintenanceWindowFinish
+}
+
+// KibanaCasesAPI handle the cases API
+type KibanaCasesAPI struct {
+	Create              KibanaCaseCreate
+	Get                 KibanaCaseGet
+	Update              KibanaCaseUpdate
+	Delete              KibanaCaseDelete
+	Find                KibanaCaseFind
+	AddComment          KibanaCaseAddComment
+	GetComment          KibanaCaseGetComment
+	UpdateComment       KibanaCaseUpdateComment
+	DeleteComment       KibanaCaseDeleteComment
+	DeleteComments      KibanaCaseDeleteComments
+	FindComments        KibanaCaseFindComments
+	GetConfiguration    KibanaCaseGetConfiguration
+	CreateConfiguration KibanaCaseCreateConfiguration
+	UpdateConfiguration KibanaCaseUpdateConfiguration
+	ListTags            KibanaCaseListTags
+	ListReporters       KibanaCaseListReporters
+	Push                KibanaCasePush
+}
+
+// KibanaFleetAPI handle the fleet API
+type KibanaFleetAPI struct {
+	AgentPolicies     *KibanaFleetAgentPoliciesAPI
+	PackagePolicies   *KibanaFleetPackagePoliciesAPI
+	EPM               *KibanaFleetEPMAPI
+	EnrollmentAPIKeys *KibanaFleetEnrollmentAPIKeysAPI
+	Agents            *KibanaFleetAgentsAPI
+	Outputs           *KibanaFleetOutputsAPI
+	FleetServerHosts  *KibanaFleetServerHostsAPI
+	Proxies           *KibanaFleetProxiesAPI
+}
+
+// KibanaFleetAgentPoliciesAPI handle the fleet agent policies API
+type KibanaFleetAgentPoliciesAPI struct {
+	List     KibanaFleetAgentPolicyList
+	Get      KibanaFleetAgentPolicyGet
+	Create   KibanaFleetAgentPolicyCreate
+	Update   KibanaFleetAgentPolicyUpdate
+	Delete   KibanaFleetAgentPolicyDelete
+	Copy     KibanaFleetAgentPolicyCopy
+	GetFull  KibanaFleetAgentPolicyGetFull
+	Download KibanaFleetAgentPolicyDownload
+}
+
+// KibanaFleetPackagePoliciesAPI handle the fleet package policies API
+type KibanaFleetPackagePoliciesAPI struct {
+	List          KibanaFleetPackagePolicyList
+	Get           KibanaFleetPackagePolicyGet
+	Create        KibanaFleetPackagePolicyCreate
+	Update        KibanaFleetPackagePolicyUpdate
+	Delete        KibanaFleetPackagePolicyDelete
+	Upgrade       KibanaFleetPackagePolicyUpgrade
+	UpgradeDryRun KibanaFleetPackagePolicyUpgradeDryRun
+}
+
+// KibanaFleetEPMAPI handle the fleet integrations packages API
+type KibanaFleetEPMAPI struct {
+	List        KibanaFleetEPMList
+	Search      KibanaFleetEPMSearch
+	Get         KibanaFleetEPMGet
+	Install     KibanaFleetEPMInstall
+	Upload      KibanaFleetEPMUpload
+	BulkInstall KibanaFleetEPMBulkInstall
+	Uninstall   KibanaFleetEPMUninstall
+	Reinstall   KibanaFleetEPMReinstall
+}
+
+// KibanaFleetEnrollmentAPIKeysAPI handle the fleet enrollment API keys API
+type KibanaFleetEnrollmentAPIKeysAPI struct {
+	List   KibanaFleetEnrollmentAPIKeyList
+	Get    KibanaFleetEnrollmentAPIKeyGet
+	Create KibanaFleetEnrollmentAPIKeyCreate
+	Revoke KibanaFleetEnrollmentAPIKeyRevoke
+}
+
+// KibanaFleetAgentsAPI handle the fleet agents API
+type KibanaFleetAgentsAPI struct {
+	List         KibanaFleetAgentList
+	Get          KibanaFleetAgentGet
+	Reassign     KibanaFleetAgentReassign
+	Unenroll     KibanaFleetAgentUnenroll
+	Upgrade      KibanaFleetAgentUpgrade
+	BulkReassign KibanaFleetAgentBulkReassign
+	BulkUnenroll KibanaFleetAgentBulkUnenroll
+	BulkUpgrade  KibanaFleetAgentBulkUpgrade
+}
+
+// KibanaFleetOutputsAPI handle the fleet outputs API
+type KibanaFleetOutputsAPI struct {
+	List   KibanaFleetOutputList
+	Get    KibanaFleetOutputGet
+	Create KibanaFleetOutputCreate
+	Update KibanaFleetOutputUpdate
+	Delete KibanaFleetOutputDelete
+}
+
+// KibanaFleetServerHostsAPI handle the fleet server hosts API
+type KibanaFleetServerHostsAPI struct {
+	List   KibanaFleetServerHostList
+	Get    KibanaFleetServerHostGet
+	Create KibanaFleetServerHostCreate
+	Update KibanaFleetServerHostUpdate
+	Delete KibanaFleetServerHostDelete
+}
+
+// KibanaFleetProxiesAPI handle the fleet proxies API
+type KibanaFleetProxiesAPI struct {
+	List   KibanaFleetProxyList
+	Get    KibanaFleetProxyGet
+	Create KibanaFleetProxyCreate
+	Update KibanaFleetProxyUpdate
+	Delete KibanaFleetProxyDelete
+}
+
+// KibanaDetectionRulesAPI handle the security detection rules API
+type KibanaDetectionRulesAPI struct {
+	Create          KibanaDetectionRuleCreate
+	Get             KibanaDetectionRuleGet
+	GetByRuleID     KibanaDetectionRuleGetByRuleID
+	Update          KibanaDetectionRuleUpdate
+	Delete          KibanaDetectionRuleDelete
+	DeleteByRuleID  KibanaDetectionRuleDeleteByRuleID
+	Find            KibanaDetectionRuleFind
+	BulkAction      KibanaDetectionRuleBulkAction
+	Import          KibanaDetectionRuleImport
+	Export          KibanaDetectionRuleExport
+	InstallPrebuilt KibanaDetectionRuleInstallPrebuilt
+	PrebuiltStatus  KibanaDetectionRulePrebuiltStatus
+}
+
+// KibanaExceptionListsAPI handle the security exception lists API
+type KibanaExceptionListsAPI struct {
+	Create             KibanaExceptionListCreate
+	Get                KibanaExceptionListGet
+	Update             KibanaExceptionListUpdate
+	Delete             KibanaExceptionListDelete
+	Find               KibanaExceptionListFind
+	Export             KibanaExceptionListExport
+	Import             KibanaExceptionListImport
+	CreateItem         KibanaExceptionListCreateItem
+	GetItem            KibanaExceptionListGetItem
+	UpdateItem         KibanaExceptionListUpdateItem
+	DeleteItem         KibanaExceptionListDeleteItem
+	FindItems          KibanaExceptionListFindItems
+	CreateEndpointList KibanaExceptionListCreateEndpointList
+	CreateEndpointItem KibanaExceptionListCreateEndpointItem
+	GetEndpointItem    KibanaExceptionListGetEndpointItem
+	UpdateEndpointItem KibanaExceptionListUpdateEndpointItem
+	DeleteEndpointItem KibanaExceptionListDeleteEndpointItem
+	FindEndpointItems  KibanaExceptionListFindEndpointItems
+}
+
+// KibanaValueListsAPI handle the security value lists API
+type KibanaValueListsAPI struct {
+	CreateIndex KibanaValueListCreateIndex
+	Create      KibanaValueListCreate
+	Get         KibanaValueListGet
+	Update      KibanaValueListUpdate
+	Delete      KibanaValueListDelete
+	Find        KibanaValueListFind
+	ImportItems KibanaValueListImportItems
+	ExportItems KibanaValueListExportItems
+}
+
+// KibanaTimelinesAPI handle the security timelines API
+type KibanaTimelinesAPI struct {
+	Get                      KibanaTimelineGet
+	GetTemplate              KibanaTimelineGetTemplate
+	List                     KibanaTimelineList
+	Create                   KibanaTimelineCreate
+	Patch                    KibanaTimelinePatch
+	Delete                   KibanaTimelineDelete
+	Export                   KibanaTimelineExport
+	Import                   KibanaTimelineImport
+	InstallPrebuiltTemplates KibanaTimelineInstallPrebuiltTemplates
+	Favorite                 KibanaTimelineFavorite
+	PinEvent                 KibanaTimelinePinEvent
+	UnpinEvent               KibanaTimelineUnpinEvent
+}
+
+// KibanaSavedQueriesAPI handle the saved queries API
+type KibanaSavedQueriesAPI struct {
+	Create KibanaSavedQueryCreate
+	Get    KibanaSavedQueryGet
+	Update KibanaSavedQueryUpdate
+	Delete KibanaSavedQueryDelete
+	Find   KibanaSavedQueryFind
+}
+
+// KibanaSettingsAPI handle the advanced settings API
+type KibanaSettingsAPI struct {
+	Get          KibanaSettingsGet
+	Set          KibanaSettingsSet
+	Delete       KibanaSettingsDelete
+	GetGlobal    KibanaSettingsGetGlobal
+	SetGlobal    KibanaSettingsSetGlobal
+	DeleteGlobal KibanaSettingsDeleteGlobal
 }
 
 // New initialise the API implementation
 func New(c *resty.Client) *API {
+	// Kibana version is read only one time and shared by version sensitive API
+	versionCache := newKibanaVersionCache(c)
+
 	return &API{
 		KibanaSpaces: &KibanaSpacesAPI{
 			Get:              newKibanaSpaceGetFunc(c),
@@ -80,14 +369,19 @@ func New(c *resty.Client) *API {
 			CopySavedObjects: newKibanaSpaceCopySavedObjectsFunc(c),
 		},
 		KibanaRoleManagement: &KibanaRoleManagementAPI{
-			Get:            newKibanaRoleManagementGetFunc(c),
-			List:           newKibanaRoleManagementListFunc(c),
-			CreateOrUpdate: newKibanaRoleManagementCreateOrUpdateFunc(c),
-			Delete:         newKibanaRoleManagementDeleteFunc(c),
+			Get:                newKibanaRoleManagementGetFunc(c),
+			List:               newKibanaRoleManagementListFunc(c),
+			CreateOrUpdate:     newKibanaRoleManagementCreateOrUpdateFunc(c),
+			Create:             newKibanaRoleManagementCreateFunc(c),
+			Delete:             newKibanaRoleManagementDeleteFunc(c),
+			BulkCreateOrUpdate: newKibanaRoleManagementBulkCreateOrUpdateFunc(c),
+			Query:              newKibanaRoleManagementQueryFunc(c),
 		},
 		KibanaDashboard: &KibanaDashboardAPI{
-			Export: newKibanaDashboardExportFunc(c),
-			Import: newKibanaDashboardImportFunc(c),
+			Export:       newKibanaDashboardExportFunc(c, versionCache),
+			Import:       newKibanaDashboardImportFunc(c, versionCache),
+			ExportNDJSON: newKibanaDashboardExportNDJSONFunc(c),
+			ImportNDJSON: newKibanaDashboardImportNDJSONFunc(c),
 		},
 		KibanaSavedObject: &KibanaSavedObjectAPI{
 			Get:    newKibanaSavedObjectGetFunc(c),
@@ -99,7 +393,10 @@ func New(c *resty.Client) *API {
 			Export: newKibanaSavedObjectExportFunc(c),
 		},
 		KibanaStatus: &KibanaStatusAPI{
-			Get: newKibanaStatusGetFunc(c),
+			Get:            newKibanaStatusGetFunc(c),
+			GetDetails:     newKibanaStatusGetDetailsFunc(c),
+			WaitUntilReady: newKibanaStatusWaitUntilReadyFunc(c),
+			GetVersion:     newKibanaStatusGetVersionFunc(versionCache),
 		},
 		KibanaLogstashPipeline: &KibanaLogstashPipelineAPI{
 			Get:            newKibanaLogstashPipelineGetFunc(c),
@@ -108,7 +405,222 @@ func New(c *resty.Client) *API {
 			Delete:         newKibanaLogstashPipelineDeleteFunc(c),
 		},
 		KibanaShortenURL: &KibanaShortenURLAPI{
-			Create: newKibanaShortenURLCreateFunc(c),
+			Create:  newKibanaShortenURLCreateFunc(c, versionCache),
+			Get:     newKibanaShortenURLGetFunc(c, versionCache),
+			Resolve: newKibanaShortenURLResolveFunc(c, versionCache),
+			Delete:  newKibanaShortenURLDeleteFunc(c, versionCache),
+		},
+		KibanaSecurity: &KibanaSecurityAPI{
+			Privileges:         newKibanaSecurityPrivilegesFunc(c),
+			InvalidateSessions: newKibanaSecurityInvalidateSessionsFunc(c),
+		},
+		KibanaDataViews: &KibanaDataViewsAPI{
+			List:               newKibanaDataViewListFunc(c),
+			Get:                newKibanaDataViewGetFunc(c),
+			Create:             newKibanaDataViewCreateFunc(c),
+			Update:             newKibanaDataViewUpdateFunc(c),
+			Delete:             newKibanaDataViewDeleteFunc(c),
+			GetDefault:         newKibanaDataViewGetDefaultFunc(c),
+			SetDefault:         newKibanaDataViewSetDefaultFunc(c),
+			UpdateFields:       newKibanaDataViewUpdateFieldsFunc(c),
+			GetRuntimeField:    newKibanaDataViewGetRuntimeFieldFunc(c),
+			CreateRuntimeField: newKibanaDataViewCreateRuntimeFieldFunc(c),
+			UpdateRuntimeField: newKibanaDataViewUpdateRuntimeFieldFunc(c),
+			DeleteRuntimeField: newKibanaDataViewDeleteRuntimeFieldFunc(c),
+		},
+		KibanaAlertingRules: &KibanaAlertingRulesAPI{
+			Create:       newKibanaAlertingRuleCreateFunc(c),
+			Get:          newKibanaAlertingRuleGetFunc(c),
+			Update:       newKibanaAlertingRuleUpdateFunc(c),
+			Delete:       newKibanaAlertingRuleDeleteFunc(c),
+			Find:         newKibanaAlertingRuleFindFunc(c),
+			Enable:       newKibanaAlertingRuleEnableFunc(c),
+			Disable:      newKibanaAlertingRuleDisableFunc(c),
+			MuteAll:      newKibanaAlertingRuleMuteAllFunc(c),
+			UnmuteAll:    newKibanaAlertingRuleUnmuteAllFunc(c),
+			MuteAlert:    newKibanaAlertingRuleMuteAlertFunc(c),
+			UnmuteAlert:  newKibanaAlertingRuleUnmuteAlertFunc(c),
+			UpdateAPIKey: newKibanaAlertingRuleUpdateAPIKeyFunc(c),
+			Health:       newKibanaAlertingRuleHealthFunc(c),
+		},
+		KibanaConnectors: &KibanaConnectorsAPI{
+			Create:    newKibanaConnectorCreateFunc(c),
+			Get:       newKibanaConnectorGetFunc(c),
+			List:      newKibanaConnectorListFunc(c),
+			Update:    newKibanaConnectorUpdateFunc(c),
+			Delete:    newKibanaConnectorDeleteFunc(c),
+			ListTypes: newKibanaConnectorListTypesFunc(c),
+			Execute:   newKibanaConnectorExecuteFunc(c),
+		},
+		KibanaMaintenanceWindows: &KibanaMaintenanceWindowsAPI{
+			Create:    newKibanaMaintenanceWindowCreateFunc(c, versionCache),
+			Get:       newKibanaMaintenanceWindowGetFunc(c, versionCache),
+			Update:    newKibanaMaintenanceWindowUpdateFunc(c, versionCache),
+			Delete:    newKibanaMaintenanceWindowDeleteFunc(c, versionCache),
+			Find:      newKibanaMaintenanceWindowFindFunc(c, versionCache),
+			Archive:   newKibanaMaintenanceWindowArchiveFunc(c, versionCache),
+			Unarchive: newKibanaMaintenanceWindowUnarchiveFunc(c, versionCache),
+			Finish:    newKibanaMaintenanceWindowFinishFunc(c, versionCache),
+		},
+		KibanaCases: &KibanaCasesAPI{
+			Create:              newKibanaCaseCreateFunc(c),
+			Get:                 newKibanaCaseGetFunc(c),
+			Update:              newKibanaCaseUpdateFunc(c),
+			Delete:              newKibanaCaseDeleteFunc(c),
+			Find:                newKibanaCaseFindFunc(c),
+			AddComment:          newKibanaCaseAddCommentFunc(c),
+			GetComment:          newKibanaCaseGetCommentFunc(c),
+			UpdateComment:       newKibanaCaseUpdateCommentFunc(c),
+			DeleteComment:       newKibanaCaseDeleteCommentFunc(c),
+			DeleteComments:      newKibanaCaseDeleteCommentsFunc(c),
+			FindComments:        newKibanaCaseFindCommentsFunc(c),
+			GetConfiguration:    newKibanaCaseGetConfigurationFunc(c),
+			CreateConfiguration: newKibanaCaseCreateConfigurationFunc(c),
+			UpdateConfiguration: newKibanaCaseUpdateConfigurationFunc(c),
+			ListTags:            newKibanaCaseListTagsFunc(c),
+			ListReporters:       newKibanaCaseListReportersFunc(c),
+			Push:                newKibanaCasePushFunc(c),
+		},
+		KibanaFleet: &KibanaFleetAPI{
+			AgentPolicies: &KibanaFleetAgentPoliciesAPI{
+				List:     newKibanaFleetAgentPolicyListFunc(c),
+				Get:      newKibanaFleetAgentPolicyGetFunc(c),
+				Create:   newKibanaFleetAgentPolicyCreateFunc(c),
+				Update:   newKibanaFleetAgentPolicyUpdateFunc(c),
+				Delete:   newKibanaFleetAgentPolicyDeleteFunc(c),
+				Copy:     newKibanaFleetAgentPolicyCopyFunc(c),
+				GetFull:  newKibanaFleetAgentPolicyGetFullFunc(c),
+				Download: newKibanaFleetAgentPolicyDownloadFunc(c),
+			},
+			PackagePolicies: &KibanaFleetPackagePoliciesAPI{
+				List:          newKibanaFleetPackagePolicyListFunc(c),
+				Get:           newKibanaFleetPackagePolicyGetFunc(c),
+				Create:        newKibanaFleetPackagePolicyCreateFunc(c),
+				Update:        newKibanaFleetPackagePolicyUpdateFunc(c),
+				Delete:        newKibanaFleetPackagePolicyDeleteFunc(c),
+				Upgrade:       newKibanaFleetPackagePolicyUpgradeFunc(c),
+				UpgradeDryRun: newKibanaFleetPackagePolicyUpgradeDryRunFunc(c),
+			},
+			EPM: &KibanaFleetEPMAPI{
+				List:        newKibanaFleetEPMListFunc(c),
+				Search:      newKibanaFleetEPMSearchFunc(c),
+				Get:         newKibanaFleetEPMGetFunc(c),
+				Install:     newKibanaFleetEPMInstallFunc(c),
+				Upload:      newKibanaFleetEPMUploadFunc(c),
+				BulkInstall: newKibanaFleetEPMBulkInstallFunc(c),
+				Uninstall:   newKibanaFleetEPMUninstallFunc(c),
+				Reinstall:   newKibanaFleetEPMReinstallFunc(c),
+			},
+			EnrollmentAPIKeys: &KibanaFleetEnrollmentAPIKeysAPI{
+				List:   newKibanaFleetEnrollmentAPIKeyListFunc(c),
+				Get:    newKibanaFleetEnrollmentAPIKeyGetFunc(c),
+				Create: newKibanaFleetEnrollmentAPIKeyCreateFunc(c),
+				Revoke: newKibanaFleetEnrollmentAPIKeyRevokeFunc(c),
+			},
+			Agents: &KibanaFleetAgentsAPI{
+				List:         newKibanaFleetAgentListFunc(c),
+				Get:          newKibanaFleetAgentGetFunc(c),
+				Reassign:     newKibanaFleetAgentReassignFunc(c),
+				Unenroll:     newKibanaFleetAgentUnenrollFunc(c),
+				Upgrade:      newKibanaFleetAgentUpgradeFunc(c),
+				BulkReassign: newKibanaFleetAgentBulkReassignFunc(c),
+				BulkUnenroll: newKibanaFleetAgentBulkUnenrollFunc(c),
+				BulkUpgrade:  newKibanaFleetAgentBulkUpgradeFunc(c),
+			},
+			Outputs: &KibanaFleetOutputsAPI{
+				List:   newKibanaFleetOutputListFunc(c),
+				Get:    newKibanaFleetOutputGetFunc(c),
+				Create: newKibanaFleetOutputCreateFunc(c),
+				Update: newKibanaFleetOutputUpdateFunc(c),
+				Delete: newKibanaFleetOutputDeleteFunc(c),
+			},
+			FleetServerHosts: &KibanaFleetServerHostsAPI{
+				List:   newKibanaFleetServerHostListFunc(c),
+				Get:    newKibanaFleetServerHostGetFunc(c),
+				Create: newKibanaFleetServerHostCreateFunc(c),
+				Update: newKibanaFleetServerHostUpdateFunc(c),
+				Delete: newKibanaFleetServerHostDeleteFunc(c),
+			},
+			Proxies: &KibanaFleetProxiesAPI{
+				List:   newKibanaFleetProxyListFunc(c),
+				Get:    newKibanaFleetProxyGetFunc(c),
+				Create: newKibanaFleetProxyCreateFunc(c),
+				Update: newKibanaFleetProxyUpdateFunc(c),
+				Delete: newKibanaFleetProxyDeleteFunc(c),
+			},
+		},
+		KibanaDetectionRules: &KibanaDetectionRulesAPI{
+			Create:          newKibanaDetectionRuleCreateFunc(c),
+			Get:             newKibanaDetectionRuleGetFunc(c),
+			GetByRuleID:     newKibanaDetectionRuleGetByRuleIDFunc(c),
+			Update:          newKibanaDetectionRuleUpdateFunc(c),
+			Delete:          newKibanaDetectionRuleDeleteFunc(c),
+			DeleteByRuleID:  newKibanaDetectionRuleDeleteByRuleIDFunc(c),
+			Find:            newKibanaDetectionRuleFindFunc(c),
+			BulkAction:      newKibanaDetectionRuleBulkActionFunc(c),
+			Import:          newKibanaDetectionRuleImportFunc(c),
+			Export:          newKibanaDetectionRuleExportFunc(c),
+			InstallPrebuilt: newKibanaDetectionRuleInstallPrebuiltFunc(c),
+			PrebuiltStatus:  newKibanaDetectionRulePrebuiltStatusFunc(c),
+		},
+		KibanaExceptionLists: &KibanaExceptionListsAPI{
+			Create:             newKibanaExceptionListCreateFunc(c),
+			Get:                newKibanaExceptionListGetFunc(c),
+			Update:             newKibanaExceptionListUpdateFunc(c),
+			Delete:             newKibanaExceptionListDeleteFunc(c),
+			Find:               newKibanaExceptionListFindFunc(c),
+			Export:             newKibanaExceptionListExportFunc(c),
+			Import:             newKibanaExceptionListImportFunc(c),
+			CreateItem:         newKibanaExceptionListCreateItemFunc(c),
+			GetItem:            newKibanaExceptionListGetItemFunc(c),
+			UpdateItem:         newKibanaExceptionListUpdateItemFunc(c),
+			DeleteItem:         newKibanaExceptionListDeleteItemFunc(c),
+			FindItems:          newKibanaExceptionListFindItemsFunc(c),
+			CreateEndpointList: newKibanaExceptionListCreateEndpointListFunc(c),
+			CreateEndpointItem: newKibanaExceptionListCreateEndpointItemFunc(c),
+			GetEndpointItem:    newKibanaExceptionListGetEndpointItemFunc(c),
+			UpdateEndpointItem: newKibanaExceptionListUpdateEndpointItemFunc(c),
+			DeleteEndpointItem: newKibanaExceptionListDeleteEndpointItemFunc(c),
+			FindEndpointItems:  newKibanaExceptionListFindEndpointItemsFunc(c),
+		},
+		KibanaValueLists: &KibanaValueListsAPI{
+			CreateIndex: newKibanaValueListCreateIndexFunc(c),
+			Create:      newKibanaValueListCreateFunc(c),
+			Get:         newKibanaValueListGetFunc(c),
+			Update:      newKibanaValueListUpdateFunc(c),
+			Delete:      newKibanaValueListDeleteFunc(c),
+			Find:        newKibanaValueListFindFunc(c),
+			ImportItems: newKibanaValueListImportItemsFunc(c),
+			ExportItems: newKibanaValueListExportItemsFunc(c),
+		},
+		KibanaTimelines: &KibanaTimelinesAPI{
+			Get:                      newKibanaTimelineGetFunc(c),
+			GetTemplate:              newKibanaTimelineGetTemplateFunc(c),
+			List:                     newKibanaTimelineListFunc(c),
+			Create:                   newKibanaTimelineCreateFunc(c),
+			Patch:                    newKibanaTimelinePatchFunc(c),
+			Delete:                   newKibanaTimelineDeleteFunc(c),
+			Export:                   newKibanaTimelineExportFunc(c),
+			Import:                   newKibanaTimelineImportFunc(c),
+			InstallPrebuiltTemplates: newKibanaTimelineInstallPrebuiltTemplatesFunc(c),
+			Favorite:                 newKibanaTimelineFavoriteFunc(c),
+			PinEvent:                 newKibanaTimelinePinEventFunc(c),
+			UnpinEvent:               newKibanaTimelineUnpinEventFunc(c),
+		},
+		KibanaSavedQueries: &KibanaSavedQueriesAPI{
+			Create: newKibanaSavedQueryCreateFunc(c),
+			Get:    newKibanaSavedQueryGetFunc(c),
+			Update: newKibanaSavedQueryUpdateFunc(c),
+			Delete: newKibanaSavedQueryDeleteFunc(c),
+			Find:   newKibanaSavedQueryFindFunc(c),
+		},
+		KibanaSettings: &KibanaSettingsAPI{
+			Get:          newKibanaSettingsGetFunc(c),
+			Set:          newKibanaSettingsSetFunc(c),
+			Delete:       newKibanaSettingsDeleteFunc(c),
+			GetGlobal:    newKibanaSettingsGetGlobalFunc(c),
+			SetGlobal:    newKibanaSettingsSetGlobalFunc(c),
+			DeleteGlobal: newKibanaSettingsDeleteGlobalFunc(c),
 		},
 	}
 }
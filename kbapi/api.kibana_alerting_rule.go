@@ -0,0 +1,554 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaAlertingRule = "/api/alerting" // Base URL to access on Kibana alerting API
+)
+
+// Rule is the alerting rule object.
+// Params depend on the rule type, use DecodeParams and SetParams to handle them with rule type specific struct.
+type Rule struct {
+	ID              string                 `json:"id,omitempty"`
+	Name            string                 `json:"name"`
+	RuleTypeID      string                 `json:"rule_type_id"`
+	Consumer        string                 `json:"consumer"`
+	Schedule        RuleSchedule           `json:"schedule"`
+	Params          json.RawMessage        `json:"params,omitempty"`
+	Actions         []RuleAction           `json:"actions,omitempty"`
+	Tags            []string               `json:"tags,omitempty"`
+	Enabled         *bool                  `json:"enabled,omitempty"`
+	Throttle        *string                `json:"throttle,omitempty"`
+	NotifyWhen      string                 `json:"notify_when,omitempty"`
+	AlertDelay      *RuleAlertDelay        `json:"alert_delay,omitempty"`
+	MuteAll         bool                   `json:"mute_all,omitempty"`
+	MutedAlertIDs   []string               `json:"muted_alert_ids,omitempty"`
+	ScheduledTaskID string                 `json:"scheduled_task_id,omitempty"`
+	CreatedBy       string                 `json:"created_by,omitempty"`
+	UpdatedBy       string                 `json:"updated_by,omitempty"`
+	CreatedAt       string                 `json:"created_at,omitempty"`
+	UpdatedAt       string                 `json:"updated_at,omitempty"`
+	APIKeyOwner     string                 `json:"api_key_owner,omitempty"`
+	Revision        int                    `json:"revision,omitempty"`
+	ExecutionStatus *RuleExecutionStatus   `json:"execution_status,omitempty"`
+	LastRun         map[string]interface{} `json:"last_run,omitempty"`
+	NextRun         string                 `json:"next_run,omitempty"`
+	Monitoring      map[string]interface{} `json:"monitoring,omitempty"`
+}
+
+// RuleSchedule is the schedule of alerting rule
+type RuleSchedule struct {
+	Interval string `json:"interval"`
+}
+
+// RuleAlertDelay is the number of consecutive runs that must meet the rule conditions before alert
+type RuleAlertDelay struct {
+	Active int `json:"active"`
+}
+
+// RuleAction is the action run by alerting rule
+type RuleAction struct {
+	Group           string                 `json:"group,omitempty"`
+	ID              string                 `json:"id"`
+	ConnectorTypeID string                 `json:"connector_type_id,omitempty"`
+	UUID            string                 `json:"uuid,omitempty"`
+	Params          map[string]interface{} `json:"params"`
+	Frequency       *RuleActionFrequency   `json:"frequency,omitempty"`
+	AlertsFilter    map[string]interface{} `json:"alerts_filter,omitempty"`
+}
+
+// RuleActionFrequency is the frequency of alerting rule action
+type RuleActionFrequency struct {
+	Summary    bool    `json:"summary"`
+	NotifyWhen string  `json:"notify_when"`
+	Throttle   *string `json:"throttle,omitempty"`
+}
+
+// RuleExecutionStatus is the last execution status of alerting rule
+type RuleExecutionStatus struct {
+	Status            string                 `json:"status"`
+	LastExecutionDate string                 `json:"last_execution_date,omitempty"`
+	LastDuration      int                    `json:"last_duration,omitempty"`
+	Error             map[string]interface{} `json:"error,omitempty"`
+}
+
+// RuleFindParameters contain optional parameters to find alerting rules
+type RuleFindParameters struct {
+	PerPage               int
+	Page                  int
+	Search                string
+	DefaultSearchOperator string
+	SearchFields          []string
+	SortField             string
+	SortOrder             string
+	Fields                []string
+	Filter                string
+}
+
+// RuleFindResponse is the result when find alerting rules
+type RuleFindResponse struct {
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+	Total   int   `json:"total"`
+	Data    Rules `json:"data"`
+}
+
+// Rules is list of Rule object
+type Rules []Rule
+
+// RuleHealth is the health of alerting framework
+type RuleHealth struct {
+	IsSufficientlySecure      bool                        `json:"is_sufficiently_secure"`
+	HasPermanentEncryptionKey bool                        `json:"has_permanent_encryption_key"`
+	AlertingFrameworkHealth   map[string]RuleHealthStatus `json:"alerting_framework_health"`
+}
+
+// RuleHealthStatus is the health status of one alerting framework component
+type RuleHealthStatus struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+}
+
+// ruleRequest is the alerting rule attributes that can be written
+type ruleRequest struct {
+	Name       string              `json:"name"`
+	RuleTypeID string              `json:"rule_type_id,omitempty"`
+	Consumer   string              `json:"consumer,omitempty"`
+	Schedule   RuleSchedule        `json:"schedule"`
+	Params     json.RawMessage     `json:"params"`
+	Actions    []ruleActionRequest `json:"actions"`
+	Tags       []string            `json:"tags,omitempty"`
+	Enabled    *bool               `json:"enabled,omitempty"`
+	Throttle   *string             `json:"throttle,omitempty"`
+	NotifyWhen string              `json:"notify_when,omitempty"`
+	AlertDelay *RuleAlertDelay     `json:"alert_delay,omitempty"`
+}
+
+// ruleActionRequest is the alerting rule action attributes that can be written
+type ruleActionRequest struct {
+	Group        string                 `json:"group,omitempty"`
+	ID           string                 `json:"id"`
+	UUID         string                 `json:"uuid,omitempty"`
+	Params       map[string]interface{} `json:"params"`
+	Frequency    *RuleActionFrequency   `json:"frequency,omitempty"`
+	AlertsFilter map[string]interface{} `json:"alerts_filter,omitempty"`
+}
+
+// KibanaAlertingRuleCreate permit to create alerting rule. The ID is generated by Kibana if empty
+type KibanaAlertingRuleCreate func(rule *Rule, kibanaSpace string) (*Rule, error)
+
+// KibanaAlertingRuleGet permit to get alerting rule
+type KibanaAlertingRuleGet func(id string, kibanaSpace string) (*Rule, error)
+
+// KibanaAlertingRuleUpdate permit to update alerting rule
+type KibanaAlertingRuleUpdate func(rule *Rule, kibanaSpace string) (*Rule, error)
+
+// KibanaAlertingRuleDelete permit to delete alerting rule
+type KibanaAlertingRuleDelete func(id string, kibanaSpace string) error
+
+// KibanaAlertingRuleFind permit to find alerting rules
+type KibanaAlertingRuleFind func(parameters *RuleFindParameters, kibanaSpace string) (*RuleFindResponse, error)
+
+// KibanaAlertingRuleEnable permit to enable alerting rule
+type KibanaAlertingRuleEnable func(id string, kibanaSpace string) error
+
+// KibanaAlertingRuleDisable permit to disable alerting rule
+type KibanaAlertingRuleDisable func(id string, kibanaSpace string) error
+
+// KibanaAlertingRuleMuteAll permit to mute all alerts of alerting rule
+type KibanaAlertingRuleMuteAll func(id string, kibanaSpace string) error
+
+// KibanaAlertingRuleUnmuteAll permit to unmute all alerts of alerting rule
+type KibanaAlertingRuleUnmuteAll func(id string, kibanaSpace string) error
+
+// KibanaAlertingRuleMuteAlert permit to mute one alert of alerting rule
+type KibanaAlertingRuleMuteAlert func(id string, alertID string, kibanaSpace string) error
+
+// KibanaAlertingRuleUnmuteAlert permit to unmute one alert of alerting rule
+type KibanaAlertingRuleUnmuteAlert func(id string, alertID string, kibanaSpace string) error
+
+// KibanaAlertingRuleUpdateAPIKey permit to regenerate the API key of alerting rule with the current user credentials
+type KibanaAlertingRuleUpdateAPIKey func(id string, kibanaSpace string) error
+
+// KibanaAlertingRuleHealth permit to get the health of alerting framework
+type KibanaAlertingRuleHealth func(kibanaSpace string) (*RuleHealth, error)
+
+// String permit to return Rule object as JSON string
+func (o *Rule) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// String permit to return RuleFindParameters object as JSON string
+func (o *RuleFindParameters) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// DecodeParams permit to read the rule params on rule type specific struct
+func (o *Rule) DecodeParams(params interface{}) error {
+	if len(o.Params) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(o.Params, params)
+}
+
+// SetParams permit to set the rule params from rule type specific struct or map
+func (o *Rule) SetParams(params interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	o.Params = data
+
+	return nil
+}
+
+// toRequest return the attributes of rule that can be written
+func (o *Rule) toRequest() *ruleRequest {
+	request := &ruleRequest{
+		Name:       o.Name,
+		RuleTypeID: o.RuleTypeID,
+		Consumer:   o.Consumer,
+		Schedule:   o.Schedule,
+		Params:     o.Params,
+		Actions:    make([]ruleActionRequest, 0, len(o.Actions)),
+		Tags:       o.Tags,
+		Enabled:    o.Enabled,
+		Throttle:   o.Throttle,
+		NotifyWhen: o.NotifyWhen,
+		AlertDelay: o.AlertDelay,
+	}
+	if len(request.Params) == 0 {
+		request.Params = json.RawMessage("{}")
+	}
+	for _, action := range o.Actions {
+		params := action.Params
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+		request.Actions = append(request.Actions, ruleActionRequest{
+			Group:        action.Group,
+			ID:           action.ID,
+			UUID:         action.UUID,
+			Params:       params,
+			Frequency:    action.Frequency,
+			AlertsFilter: action.AlertsFilter,
+		})
+	}
+
+	return request
+}
+
+// newKibanaAlertingRuleCreateFunc permit to create alerting rule
+func newKibanaAlertingRuleCreateFunc(c *resty.Client) KibanaAlertingRuleCreate {
+	return func(rule *Rule, kibanaSpace string) (*Rule, error) {
+
+		if rule == nil {
+			return nil, NewAPIError(600, "You must provide alerting rule object")
+		}
+		log.Debug("Rule: ", rule)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(rule.toRequest())
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/rule", basePathKibanaAlertingRule))
+		if rule.ID != "" {
+			path = fmt.Sprintf("%s/%s", path, rule.ID)
+		}
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		rule = &Rule{}
+		err = json.Unmarshal(resp.Body(), rule)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Rule: ", rule)
+
+		return rule, nil
+	}
+}
+
+// newKibanaAlertingRuleGetFunc permit to get alerting rule with it ID
+func newKibanaAlertingRuleGetFunc(c *resty.Client) KibanaAlertingRuleGet {
+	return func(id string, kibanaSpace string) (*Rule, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide alerting rule ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/rule/%s", basePathKibanaAlertingRule, id))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		rule := &Rule{}
+		err = json.Unmarshal(resp.Body(), rule)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Rule: ", rule)
+
+		return rule, nil
+	}
+}
+
+// newKibanaAlertingRuleUpdateFunc permit to update alerting rule
+func newKibanaAlertingRuleUpdateFunc(c *resty.Client) KibanaAlertingRuleUpdate {
+	return func(rule *Rule, kibanaSpace string) (*Rule, error) {
+
+		if rule == nil {
+			return nil, NewAPIError(600, "You must provide alerting rule object")
+		}
+		if rule.ID == "" {
+			return nil, NewAPIError(600, "You must provide alerting rule ID")
+		}
+		log.Debug("Rule: ", rule)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// Rule type, consumer and enabled state can't be updated
+		request := rule.toRequest()
+		request.RuleTypeID = ""
+		request.Consumer = ""
+		request.Enabled = nil
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/rule/%s", basePathKibanaAlertingRule, rule.ID))
+		resp, err := c.R().SetBody(jsonData).Put(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		rule = &Rule{}
+		err = json.Unmarshal(resp.Body(), rule)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Rule: ", rule)
+
+		return rule, nil
+	}
+}
+
+// newKibanaAlertingRuleDeleteFunc permit to delete alerting rule with it ID
+func newKibanaAlertingRuleDeleteFunc(c *resty.Client) KibanaAlertingRuleDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide alerting rule ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/rule/%s", basePathKibanaAlertingRule, id))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaAlertingRuleFindFunc permit to find alerting rules
+func newKibanaAlertingRuleFindFunc(c *resty.Client) KibanaAlertingRuleFind {
+	return func(parameters *RuleFindParameters, kibanaSpace string) (*RuleFindResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := map[string]string{}
+		if parameters != nil {
+			if parameters.PerPage != 0 {
+				queryParams["per_page"] = strconv.Itoa(parameters.PerPage)
+			}
+			if parameters.Page != 0 {
+				queryParams["page"] = strconv.Itoa(parameters.Page)
+			}
+			if parameters.Search != "" {
+				queryParams["search"] = parameters.Search
+			}
+			if parameters.DefaultSearchOperator != "" {
+				queryParams["default_search_operator"] = parameters.DefaultSearchOperator
+			}
+			if parameters.SearchFields != nil {
+				queryParams["search_fields"] = strings.Join(parameters.SearchFields, ",")
+			}
+			if parameters.SortField != "" {
+				queryParams["sort_field"] = parameters.SortField
+			}
+			if parameters.SortOrder != "" {
+				queryParams["sort_order"] = parameters.SortOrder
+			}
+			if parameters.Fields != nil {
+				queryParams["fields"] = strings.Join(parameters.Fields, ",")
+			}
+			if parameters.Filter != "" {
+				queryParams["filter"] = parameters.Filter
+			}
+		}
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/rules/_find", basePathKibanaAlertingRule))
+		resp, err := c.R().SetQueryParams(queryParams).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		ruleFindResponse := &RuleFindResponse{}
+		err = json.Unmarshal(resp.Body(), ruleFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("RuleFindResponse: ", ruleFindResponse)
+
+		return ruleFindResponse, nil
+	}
+}
+
+// newKibanaAlertingRuleEnableFunc permit to enable alerting rule
+func newKibanaAlertingRuleEnableFunc(c *resty.Client) KibanaAlertingRuleEnable {
+	return func(id string, kibanaSpace string) error {
+		return postRuleAction(c, id, "", "_enable", kibanaSpace)
+	}
+}
+
+// newKibanaAlertingRuleDisableFunc permit to disable alerting rule
+func newKibanaAlertingRuleDisableFunc(c *resty.Client) KibanaAlertingRuleDisable {
+	return func(id string, kibanaSpace string) error {
+		return postRuleAction(c, id, "", "_disable", kibanaSpace)
+	}
+}
+
+// newKibanaAlertingRuleMuteAllFunc permit to mute all alerts of alerting rule
+func newKibanaAlertingRuleMuteAllFunc(c *resty.Client) KibanaAlertingRuleMuteAll {
+	return func(id string, kibanaSpace string) error {
+		return postRuleAction(c, id, "", "_mute_all", kibanaSpace)
+	}
+}
+
+// newKibanaAlertingRuleUnmuteAllFunc permit to unmute all alerts of alerting rule
+func newKibanaAlertingRuleUnmuteAllFunc(c *resty.Client) KibanaAlertingRuleUnmuteAll {
+	return func(id string, kibanaSpace string) error {
+		return postRuleAction(c, id, "", "_unmute_all", kibanaSpace)
+	}
+}
+
+// newKibanaAlertingRuleMuteAlertFunc permit to mute one alert of alerting rule
+func newKibanaAlertingRuleMuteAlertFunc(c *resty.Client) KibanaAlertingRuleMuteAlert {
+	return func(id string, alertID string, kibanaSpace string) error {
+		if alertID == "" {
+			return NewAPIError(600, "You must provide alert ID")
+		}
+		return postRuleAction(c, id, alertID, "_mute", kibanaSpace)
+	}
+}
+
+// newKibanaAlertingRuleUnmuteAlertFunc permit to unmute one alert of alerting rule
+func newKibanaAlertingRuleUnmuteAlertFunc(c *resty.Client) KibanaAlertingRuleUnmuteAlert {
+	return func(id string, alertID string, kibanaSpace string) error {
+		if alertID == "" {
+			return NewAPIError(600, "You must provide alert ID")
+		}
+		return postRuleAction(c, id, alertID, "_unmute", kibanaSpace)
+	}
+}
+
+// newKibanaAlertingRuleUpdateAPIKeyFunc permit to regenerate the API key of alerting rule
+func newKibanaAlertingRuleUpdateAPIKeyFunc(c *resty.Client) KibanaAlertingRuleUpdateAPIKey {
+	return func(id string, kibanaSpace string) error {
+		return postRuleAction(c, id, "", "_update_api_key", kibanaSpace)
+	}
+}
+
+// postRuleAction permit to call action on alerting rule, or on one alert of alerting rule if alertID is provided
+func postRuleAction(c *resty.Client, id string, alertID string, action string, kibanaSpace string) error {
+
+	if id == "" {
+		return NewAPIError(600, "You must provide alerting rule ID")
+	}
+	log.Debug("ID: ", id)
+	log.Debug("AlertID: ", alertID)
+	log.Debug("Action: ", action)
+	log.Debug("KibanaSpace: ", kibanaSpace)
+
+	path := fmt.Sprintf("%s/rule/%s", basePathKibanaAlertingRule, id)
+	if alertID != "" {
+		path = fmt.Sprintf("%s/alert/%s", path, url.PathEscape(alertID))
+	}
+	path = spacePath(kibanaSpace, fmt.Sprintf("%s/%s", path, action))
+	resp, err := c.R().Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// newKibanaAlertingRuleHealthFunc permit to get the health of alerting framework
+func newKibanaAlertingRuleHealthFunc(c *resty.Client) KibanaAlertingRuleHealth {
+	return func(kibanaSpace string) (*RuleHealth, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_health", basePathKibanaAlertingRule))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		ruleHealth := &RuleHealth{}
+		err = json.Unmarshal(resp.Body(), ruleHealth)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("RuleHealth: ", ruleHealth)
+
+		return ruleHealth, nil
+	}
+}
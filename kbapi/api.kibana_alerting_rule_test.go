@@ -0,0 +1,104 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+// indexThresholdParams is the params of index threshold rule type
+type indexThresholdParams struct {
+	Index               []string `json:"index"`
+	TimeField           string   `json:"timeField"`
+	AggType             string   `json:"aggType"`
+	GroupBy             string   `json:"groupBy"`
+	TimeWindowSize      int      `json:"timeWindowSize"`
+	TimeWindowUnit      string   `json:"timeWindowUnit"`
+	ThresholdComparator string   `json:"thresholdComparator"`
+	Threshold           []int    `json:"threshold"`
+}
+
+func (s *KBAPITestSuite) TestKibanaAlertingRules() {
+
+	// Create new rule
+	rule := &Rule{
+		ID:         "test-rule",
+		Name:       "test",
+		RuleTypeID: ".index-threshold",
+		Consumer:   "alerts",
+		Schedule: RuleSchedule{
+			Interval: "1m",
+		},
+		Tags: []string{"test"},
+	}
+	err := rule.SetParams(&indexThresholdParams{
+		Index:               []string{"logs-*"},
+		TimeField:           "@timestamp",
+		AggType:             "count",
+		GroupBy:             "all",
+		TimeWindowSize:      5,
+		TimeWindowUnit:      "m",
+		ThresholdComparator: ">",
+		Threshold:           []int{1000},
+	})
+	assert.NoError(s.T(), err)
+	rule, err = s.API.KibanaAlertingRules.Create(rule, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), rule)
+	assert.Equal(s.T(), "test-rule", rule.ID)
+
+	// Get rule
+	rule, err = s.API.KibanaAlertingRules.Get("test-rule", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), rule)
+	params := &indexThresholdParams{}
+	err = rule.DecodeParams(params)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []int{1000}, params.Threshold)
+
+	// Update rule
+	rule.Name = "test2"
+	rule, err = s.API.KibanaAlertingRules.Update(rule, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test2", rule.Name)
+
+	// Find rules
+	ruleFindResponse, err := s.API.KibanaAlertingRules.Find(&RuleFindParameters{
+		PerPage: 10,
+		Page:    1,
+		Filter:  "alert.attributes.tags:test",
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), ruleFindResponse.Data)
+
+	// Disable and enable rule
+	err = s.API.KibanaAlertingRules.Disable("test-rule", "default")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaAlertingRules.Enable("test-rule", "default")
+	assert.NoError(s.T(), err)
+
+	// Mute and unmute rule
+	err = s.API.KibanaAlertingRules.MuteAll("test-rule", "default")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaAlertingRules.UnmuteAll("test-rule", "default")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaAlertingRules.MuteAlert("test-rule", "all documents", "default")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaAlertingRules.UnmuteAlert("test-rule", "all documents", "default")
+	assert.NoError(s.T(), err)
+
+	// Update API key
+	err = s.API.KibanaAlertingRules.UpdateAPIKey("test-rule", "default")
+	assert.NoError(s.T(), err)
+
+	// Get health
+	ruleHealth, err := s.API.KibanaAlertingRules.Health("default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), ruleHealth)
+
+	// Delete rule
+	err = s.API.KibanaAlertingRules.Delete("test-rule", "default")
+	assert.NoError(s.T(), err)
+	rule, err = s.API.KibanaAlertingRules.Get("test-rule", "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), rule)
+
+}
@@ -0,0 +1,1011 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaCase = "/api/cases" // Base URL to access on Kibana cases API
+)
+
+// Owners of case, it's the application that handle it
+const (
+	CaseOwnerCases            = "cases"
+	CaseOwnerObservability    = "observability"
+	CaseOwnerSecuritySolution = "securitySolution"
+)
+
+// Status of case
+const (
+	CaseStatusOpen       = "open"
+	CaseStatusInProgress = "in-progress"
+	CaseStatusClosed     = "closed"
+)
+
+// Severities of case
+const (
+	CaseSeverityLow      = "low"
+	CaseSeverityMedium   = "medium"
+	CaseSeverityHigh     = "high"
+	CaseSeverityCritical = "critical"
+)
+
+// Types of case comment
+const (
+	CaseCommentTypeUser  = "user"
+	CaseCommentTypeAlert = "alert"
+)
+
+// Closure types of case configuration
+const (
+	CaseClosureTypeByUser    = "close-by-user"
+	CaseClosureTypeByPushing = "close-by-pushing"
+)
+
+// CaseConnectorTypeNone is the connector type used when case is not pushed on external system
+const CaseConnectorTypeNone = ".none"
+
+// Case is the case object
+type Case struct {
+	ID              string                 `json:"id,omitempty"`
+	Version         string                 `json:"version,omitempty"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	Tags            []string               `json:"tags"`
+	Owner           string                 `json:"owner"`
+	Connector       *CaseConnector         `json:"connector"`
+	Settings        *CaseSettings          `json:"settings,omitempty"`
+	Status          string                 `json:"status,omitempty"`
+	Severity        string                 `json:"severity,omitempty"`
+	Assignees       []CaseAssignee         `json:"assignees,omitempty"`
+	Category        string                 `json:"category,omitempty"`
+	Comments        []CaseComment          `json:"comments,omitempty"`
+	TotalComment    int                    `json:"totalComment,omitempty"`
+	TotalAlerts     int                    `json:"totalAlerts,omitempty"`
+	ExternalService map[string]interface{} `json:"external_service,omitempty"`
+	Duration        *int64                 `json:"duration,omitempty"`
+	CreatedAt       string                 `json:"created_at,omitempty"`
+	CreatedBy       *CaseUser              `json:"created_by,omitempty"`
+	UpdatedAt       string                 `json:"updated_at,omitempty"`
+	UpdatedBy       *CaseUser              `json:"updated_by,omitempty"`
+	ClosedAt        string                 `json:"closed_at,omitempty"`
+	ClosedBy        *CaseUser              `json:"closed_by,omitempty"`
+}
+
+// Cases is list of Case object
+type Cases []Case
+
+// CaseConnector is the external connector where case is pushed.
+// Fields depend on the connector type and are null for none connector.
+type CaseConnector struct {
+	ID     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// CaseSettings is the settings of case
+type CaseSettings struct {
+	SyncAlerts bool `json:"syncAlerts"`
+}
+
+// CaseAssignee is the user assigned to case
+type CaseAssignee struct {
+	UID string `json:"uid"`
+}
+
+// CaseUser is the user that act on case
+type CaseUser struct {
+	Username   string `json:"username"`
+	FullName   string `json:"full_name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	ProfileUID string `json:"profile_uid,omitempty"`
+}
+
+// CaseComment is the comment attached to case.
+// User comment use Comment, alert comment use AlertID, Index and Rule.
+type CaseComment struct {
+	ID        string           `json:"id,omitempty"`
+	Version   string           `json:"version,omitempty"`
+	Type      string           `json:"type"`
+	Owner     string           `json:"owner"`
+	Comment   string           `json:"comment,omitempty"`
+	AlertID   CaseStrings      `json:"alertId,omitempty"`
+	Index     CaseStrings      `json:"index,omitempty"`
+	Rule      *CaseCommentRule `json:"rule,omitempty"`
+	CreatedAt string           `json:"created_at,omitempty"`
+	CreatedBy *CaseUser        `json:"created_by,omitempty"`
+	UpdatedAt string           `json:"updated_at,omitempty"`
+	UpdatedBy *CaseUser        `json:"updated_by,omitempty"`
+	PushedAt  string           `json:"pushed_at,omitempty"`
+	PushedBy  *CaseUser        `json:"pushed_by,omitempty"`
+}
+
+// CaseComments is list of CaseComment object
+type CaseComments []CaseComment
+
+// CaseCommentRule is the rule that generate the alert attached to case
+type CaseCommentRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CaseStrings is list of string that Kibana can return as single string
+type CaseStrings []string
+
+// CaseConfiguration is the cases configuration of one owner
+type CaseConfiguration struct {
+	ID          string              `json:"id,omitempty"`
+	Version     string              `json:"version,omitempty"`
+	ClosureType string              `json:"closure_type"`
+	Connector   *CaseConnector      `json:"connector"`
+	Owner       string              `json:"owner"`
+	Mappings    []map[string]string `json:"mappings,omitempty"`
+	Error       *string             `json:"error,omitempty"`
+	CreatedAt   string              `json:"created_at,omitempty"`
+	CreatedBy   *CaseUser           `json:"created_by,omitempty"`
+	UpdatedAt   string              `json:"updated_at,omitempty"`
+	UpdatedBy   *CaseUser           `json:"updated_by,omitempty"`
+}
+
+// CaseFindParameters contain optional parameters to find cases
+type CaseFindParameters struct {
+	Tags         []string
+	Status       string
+	Severity     string
+	Search       string
+	SearchFields []string
+	SortField    string
+	SortOrder    string
+	Page         int
+	PerPage      int
+	Owner        []string
+	Reporters    []string
+	Assignees    []string
+	From         string
+	To           string
+}
+
+// CaseFindResponse is the result when find cases
+type CaseFindResponse struct {
+	Page                 int   `json:"page"`
+	PerPage              int   `json:"per_page"`
+	Total                int   `json:"total"`
+	Cases                Cases `json:"cases"`
+	CountOpenCases       int   `json:"count_open_cases"`
+	CountInProgressCases int   `json:"count_in_progress_cases"`
+	CountClosedCases     int   `json:"count_closed_cases"`
+}
+
+// CaseCommentFindResponse is the result when find case comments
+type CaseCommentFindResponse struct {
+	Page     int          `json:"page"`
+	PerPage  int          `json:"per_page"`
+	Total    int          `json:"total"`
+	Comments CaseComments `json:"comments"`
+}
+
+// caseCreateRequest is the case attributes that can be set on creation
+type caseCreateRequest struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Tags        []string       `json:"tags"`
+	Owner       string         `json:"owner"`
+	Connector   *CaseConnector `json:"connector"`
+	Settings    CaseSettings   `json:"settings"`
+	Severity    string         `json:"severity,omitempty"`
+	Assignees   []CaseAssignee `json:"assignees,omitempty"`
+	Category    string         `json:"category,omitempty"`
+}
+
+// caseUpdateRequest is the case attributes that can be updated.
+// Tags, assignees and settings are sent only when set, and empty list clear them.
+type caseUpdateRequest struct {
+	ID          string          `json:"id"`
+	Version     string          `json:"version"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Tags        *[]string       `json:"tags,omitempty"`
+	Connector   *CaseConnector  `json:"connector,omitempty"`
+	Settings    *CaseSettings   `json:"settings,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Severity    string          `json:"severity,omitempty"`
+	Assignees   *[]CaseAssignee `json:"assignees,omitempty"`
+	Category    string          `json:"category,omitempty"`
+}
+
+// caseCommentRequest is the case comment attributes that can be written
+type caseCommentRequest struct {
+	ID      string           `json:"id,omitempty"`
+	Version string           `json:"version,omitempty"`
+	Type    string           `json:"type"`
+	Owner   string           `json:"owner"`
+	Comment string           `json:"comment,omitempty"`
+	AlertID CaseStrings      `json:"alertId,omitempty"`
+	Index   CaseStrings      `json:"index,omitempty"`
+	Rule    *CaseCommentRule `json:"rule,omitempty"`
+}
+
+// caseConfigurationRequest is the case configuration attributes that can be written
+type caseConfigurationRequest struct {
+	Version     string         `json:"version,omitempty"`
+	ClosureType string         `json:"closure_type"`
+	Connector   *CaseConnector `json:"connector"`
+	Owner       string         `json:"owner,omitempty"`
+}
+
+// KibanaCaseCreate permit to create case
+type KibanaCaseCreate func(caseObj *Case, kibanaSpace string) (*Case, error)
+
+// KibanaCaseGet permit to get case
+type KibanaCaseGet func(id string, kibanaSpace string) (*Case, error)
+
+// KibanaCaseUpdate permit to update case. The case version must be the current version.
+// Only the set attributes are updated: nil tags, assignees and settings are kept, empty tags and assignees are cleared.
+type KibanaCaseUpdate func(caseObj *Case, kibanaSpace string) (*Case, error)
+
+// KibanaCaseDelete permit to delete cases
+type KibanaCaseDelete func(ids []string, kibanaSpace string) error
+
+// KibanaCaseFind permit to find cases
+type KibanaCaseFind func(parameters *CaseFindParameters, kibanaSpace string) (*CaseFindResponse, error)
+
+// KibanaCaseAddComment permit to add comment or alert on case
+type KibanaCaseAddComment func(caseID string, comment *CaseComment, kibanaSpace string) (*Case, error)
+
+// KibanaCaseGetComment permit to get case comment
+type KibanaCaseGetComment func(caseID string, commentID string, kibanaSpace string) (*CaseComment, error)
+
+// KibanaCaseUpdateComment permit to update case comment
+type KibanaCaseUpdateComment func(caseID string, comment *CaseComment, kibanaSpace string) (*Case, error)
+
+// KibanaCaseDeleteComment permit to delete case comment
+type KibanaCaseDeleteComment func(caseID string, commentID string, kibanaSpace string) error
+
+// KibanaCaseDeleteComments permit to delete all comments of case
+type KibanaCaseDeleteComments func(caseID string, kibanaSpace string) error
+
+// KibanaCaseFindComments permit to find the comments of case
+type KibanaCaseFindComments func(caseID string, page int, perPage int, kibanaSpace string) (*CaseCommentFindResponse, error)
+
+// KibanaCaseGetConfiguration permit to get cases configuration of owners
+type KibanaCaseGetConfiguration func(owners []string, kibanaSpace string) ([]CaseConfiguration, error)
+
+// KibanaCaseCreateConfiguration permit to create cases configuration of owner
+type KibanaCaseCreateConfiguration func(configuration *CaseConfiguration, kibanaSpace string) (*CaseConfiguration, error)
+
+// KibanaCaseUpdateConfiguration permit to update cases configuration
+type KibanaCaseUpdateConfiguration func(configuration *CaseConfiguration, kibanaSpace string) (*CaseConfiguration, error)
+
+// KibanaCaseListTags permit to list the tags used on cases of owners
+type KibanaCaseListTags func(owners []string, kibanaSpace string) ([]string, error)
+
+// KibanaCaseListReporters permit to list the users that open cases of owners
+type KibanaCaseListReporters func(owners []string, kibanaSpace string) ([]CaseUser, error)
+
+// KibanaCasePush permit to push case on external system with connector
+type KibanaCasePush func(caseID string, connectorID string, kibanaSpace string) (*Case, error)
+
+// NewCaseConnectorNone return the connector to use when case is not pushed on external system
+func NewCaseConnectorNone() *CaseConnector {
+	return &CaseConnector{
+		ID:   "none",
+		Name: "none",
+		Type: CaseConnectorTypeNone,
+	}
+}
+
+// String permit to return Case object as JSON string
+func (o *Case) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// String permit to return CaseComment object as JSON string
+func (o *CaseComment) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// String permit to return CaseConfiguration object as JSON string
+func (o *CaseConfiguration) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// UnmarshalJSON permit to read value returned as single string or as list of string
+func (o *CaseStrings) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*o = CaseStrings{value}
+		return nil
+	}
+
+	values := []string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*o = values
+
+	return nil
+}
+
+// toRequest return the case comment attributes that can be written, depending on comment type
+func (o *CaseComment) toRequest() (*caseCommentRequest, error) {
+	request := &caseCommentRequest{
+		ID:      o.ID,
+		Version: o.Version,
+		Type:    o.Type,
+		Owner:   o.Owner,
+	}
+
+	switch o.Type {
+	case CaseCommentTypeUser:
+		if o.Comment == "" {
+			return nil, NewAPIError(600, "You must provide comment when comment type is '%s'", CaseCommentTypeUser)
+		}
+		request.Comment = o.Comment
+	case CaseCommentTypeAlert:
+		if len(o.AlertID) == 0 || len(o.Index) == 0 {
+			return nil, NewAPIError(600, "You must provide alert ID and index when comment type is '%s'", CaseCommentTypeAlert)
+		}
+		request.AlertID = o.AlertID
+		request.Index = o.Index
+		request.Rule = o.Rule
+		if request.Rule == nil {
+			request.Rule = &CaseCommentRule{}
+		}
+	default:
+		return nil, NewAPIError(600, "Comment type must be '%s' or '%s', not '%s'", CaseCommentTypeUser, CaseCommentTypeAlert, o.Type)
+	}
+
+	return request, nil
+}
+
+// newKibanaCaseCreateFunc permit to create case
+func newKibanaCaseCreateFunc(c *resty.Client) KibanaCaseCreate {
+	return func(caseObj *Case, kibanaSpace string) (*Case, error) {
+
+		if caseObj == nil {
+			return nil, NewAPIError(600, "You must provide case object")
+		}
+		if caseObj.Title == "" || caseObj.Owner == "" {
+			return nil, NewAPIError(600, "You must provide case title and owner")
+		}
+		log.Debug("Case: ", caseObj)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := &caseCreateRequest{
+			Title:       caseObj.Title,
+			Description: caseObj.Description,
+			Tags:        caseObj.Tags,
+			Owner:       caseObj.Owner,
+			Connector:   caseObj.Connector,
+			Severity:    caseObj.Severity,
+			Assignees:   caseObj.Assignees,
+			Category:    caseObj.Category,
+		}
+		if request.Tags == nil {
+			request.Tags = []string{}
+		}
+		if request.Connector == nil {
+			request.Connector = NewCaseConnectorNone()
+		}
+		if caseObj.Settings != nil {
+			request.Settings = *caseObj.Settings
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, basePathKibanaCase)
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCase(resp.Body())
+	}
+}
+
+// newKibanaCaseGetFunc permit to get case with it ID
+func newKibanaCaseGetFunc(c *resty.Client) KibanaCaseGet {
+	return func(id string, kibanaSpace string) (*Case, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide case ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s", basePathKibanaCase, id))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCase(resp.Body())
+	}
+}
+
+// newKibanaCaseUpdateFunc permit to update case
+func newKibanaCaseUpdateFunc(c *resty.Client) KibanaCaseUpdate {
+	return func(caseObj *Case, kibanaSpace string) (*Case, error) {
+
+		if caseObj == nil {
+			return nil, NewAPIError(600, "You must provide case object")
+		}
+		if caseObj.ID == "" || caseObj.Version == "" {
+			return nil, NewAPIError(600, "You must provide case ID and version")
+		}
+		log.Debug("Case: ", caseObj)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := &caseUpdateRequest{
+			ID:          caseObj.ID,
+			Version:     caseObj.Version,
+			Title:       caseObj.Title,
+			Description: caseObj.Description,
+			Connector:   caseObj.Connector,
+			Settings:    caseObj.Settings,
+			Status:      caseObj.Status,
+			Severity:    caseObj.Severity,
+			Category:    caseObj.Category,
+		}
+		// Nil list keep the current values, empty list clear them
+		if caseObj.Tags != nil {
+			request.Tags = &caseObj.Tags
+		}
+		if caseObj.Assignees != nil {
+			request.Assignees = &caseObj.Assignees
+		}
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"cases": []*caseUpdateRequest{request},
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.R().SetBody(jsonData).Patch(spacePath(kibanaSpace, basePathKibanaCase))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		cases := Cases{}
+		err = json.Unmarshal(resp.Body(), &cases)
+		if err != nil {
+			return nil, err
+		}
+		if len(cases) == 0 {
+			return nil, NewAPIError(600, "Kibana don't return the updated case %s", caseObj.ID)
+		}
+		log.Debug("Case: ", &cases[0])
+
+		return &cases[0], nil
+	}
+}
+
+// newKibanaCaseDeleteFunc permit to delete cases with their IDs
+func newKibanaCaseDeleteFunc(c *resty.Client) KibanaCaseDelete {
+	return func(ids []string, kibanaSpace string) error {
+
+		if len(ids) == 0 {
+			return NewAPIError(600, "You must provide case IDs")
+		}
+		log.Debug("IDs: ", ids)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonIDs, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		path := spacePath(kibanaSpace, basePathKibanaCase)
+		resp, err := c.R().SetQueryParam("ids", string(jsonIDs)).Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaCaseFindFunc permit to find cases
+func newKibanaCaseFindFunc(c *resty.Client) KibanaCaseFind {
+	return func(parameters *CaseFindParameters, kibanaSpace string) (*CaseFindResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := url.Values{}
+		if parameters != nil {
+			for _, tag := range parameters.Tags {
+				queryParams.Add("tags", tag)
+			}
+			if parameters.Status != "" {
+				queryParams.Set("status", parameters.Status)
+			}
+			if parameters.Severity != "" {
+				queryParams.Set("severity", parameters.Severity)
+			}
+			if parameters.Search != "" {
+				queryParams.Set("search", parameters.Search)
+			}
+			for _, searchField := range parameters.SearchFields {
+				queryParams.Add("searchFields", searchField)
+			}
+			if parameters.SortField != "" {
+				queryParams.Set("sortField", parameters.SortField)
+			}
+			if parameters.SortOrder != "" {
+				queryParams.Set("sortOrder", parameters.SortOrder)
+			}
+			if parameters.Page != 0 {
+				queryParams.Set("page", strconv.Itoa(parameters.Page))
+			}
+			if parameters.PerPage != 0 {
+				queryParams.Set("perPage", strconv.Itoa(parameters.PerPage))
+			}
+			for _, owner := range parameters.Owner {
+				queryParams.Add("owner", owner)
+			}
+			for _, reporter := range parameters.Reporters {
+				queryParams.Add("reporters", reporter)
+			}
+			for _, assignee := range parameters.Assignees {
+				queryParams.Add("assignees", assignee)
+			}
+			if parameters.From != "" {
+				queryParams.Set("from", parameters.From)
+			}
+			if parameters.To != "" {
+				queryParams.Set("to", parameters.To)
+			}
+		}
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_find", basePathKibanaCase))
+		resp, err := c.R().SetQueryParamsFromValues(queryParams).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		caseFindResponse := &CaseFindResponse{}
+		err = json.Unmarshal(resp.Body(), caseFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Cases: ", caseFindResponse.Cases)
+
+		return caseFindResponse, nil
+	}
+}
+
+// newKibanaCaseAddCommentFunc permit to add comment or alert on case
+func newKibanaCaseAddCommentFunc(c *resty.Client) KibanaCaseAddComment {
+	return func(caseID string, comment *CaseComment, kibanaSpace string) (*Case, error) {
+
+		if caseID == "" {
+			return nil, NewAPIError(600, "You must provide case ID")
+		}
+		if comment == nil {
+			return nil, NewAPIError(600, "You must provide comment object")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("Comment: ", comment)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request, err := comment.toRequest()
+		if err != nil {
+			return nil, err
+		}
+		request.ID = ""
+		request.Version = ""
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/comments", basePathKibanaCase, caseID))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCase(resp.Body())
+	}
+}
+
+// newKibanaCaseGetCommentFunc permit to get case comment with it ID
+func newKibanaCaseGetCommentFunc(c *resty.Client) KibanaCaseGetComment {
+	return func(caseID string, commentID string, kibanaSpace string) (*CaseComment, error) {
+
+		if caseID == "" || commentID == "" {
+			return nil, NewAPIError(600, "You must provide case ID and comment ID")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("CommentID: ", commentID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/comments/%s", basePathKibanaCase, caseID, commentID))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		comment := &CaseComment{}
+		err = json.Unmarshal(resp.Body(), comment)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Comment: ", comment)
+
+		return comment, nil
+	}
+}
+
+// newKibanaCaseUpdateCommentFunc permit to update case comment
+func newKibanaCaseUpdateCommentFunc(c *resty.Client) KibanaCaseUpdateComment {
+	return func(caseID string, comment *CaseComment, kibanaSpace string) (*Case, error) {
+
+		if caseID == "" {
+			return nil, NewAPIError(600, "You must provide case ID")
+		}
+		if comment == nil {
+			return nil, NewAPIError(600, "You must provide comment object")
+		}
+		if comment.ID == "" || comment.Version == "" {
+			return nil, NewAPIError(600, "You must provide comment ID and version")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("Comment: ", comment)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request, err := comment.toRequest()
+		if err != nil {
+			return nil, err
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/comments", basePathKibanaCase, caseID))
+		resp, err := c.R().SetBody(jsonData).Patch(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCase(resp.Body())
+	}
+}
+
+// newKibanaCaseDeleteCommentFunc permit to delete case comment with it ID
+func newKibanaCaseDeleteCommentFunc(c *resty.Client) KibanaCaseDeleteComment {
+	return func(caseID string, commentID string, kibanaSpace string) error {
+
+		if caseID == "" || commentID == "" {
+			return NewAPIError(600, "You must provide case ID and comment ID")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("CommentID: ", commentID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/comments/%s", basePathKibanaCase, caseID, commentID))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaCaseDeleteCommentsFunc permit to delete all comments of case
+func newKibanaCaseDeleteCommentsFunc(c *resty.Client) KibanaCaseDeleteComments {
+	return func(caseID string, kibanaSpace string) error {
+
+		if caseID == "" {
+			return NewAPIError(600, "You must provide case ID")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/comments", basePathKibanaCase, caseID))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaCaseFindCommentsFunc permit to find the comments of case
+func newKibanaCaseFindCommentsFunc(c *resty.Client) KibanaCaseFindComments {
+	return func(caseID string, page int, perPage int, kibanaSpace string) (*CaseCommentFindResponse, error) {
+
+		if caseID == "" {
+			return nil, NewAPIError(600, "You must provide case ID")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("Page: ", page)
+		log.Debug("PerPage: ", perPage)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := map[string]string{}
+		if page != 0 {
+			queryParams["page"] = strconv.Itoa(page)
+		}
+		if perPage != 0 {
+			queryParams["perPage"] = strconv.Itoa(perPage)
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/comments/_find", basePathKibanaCase, caseID))
+		resp, err := c.R().SetQueryParams(queryParams).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		caseCommentFindResponse := &CaseCommentFindResponse{}
+		err = json.Unmarshal(resp.Body(), caseCommentFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Comments: ", caseCommentFindResponse.Comments)
+
+		return caseCommentFindResponse, nil
+	}
+}
+
+// newKibanaCaseGetConfigurationFunc permit to get cases configuration of owners
+func newKibanaCaseGetConfigurationFunc(c *resty.Client) KibanaCaseGetConfiguration {
+	return func(owners []string, kibanaSpace string) ([]CaseConfiguration, error) {
+
+		log.Debug("Owners: ", owners)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/configure", basePathKibanaCase))
+		resp, err := c.R().SetQueryParamsFromValues(url.Values{"owner": owners}).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		configurations := []CaseConfiguration{}
+		err = json.Unmarshal(resp.Body(), &configurations)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Configurations: ", configurations)
+
+		return configurations, nil
+	}
+}
+
+// newKibanaCaseCreateConfigurationFunc permit to create cases configuration of owner
+func newKibanaCaseCreateConfigurationFunc(c *resty.Client) KibanaCaseCreateConfiguration {
+	return func(configuration *CaseConfiguration, kibanaSpace string) (*CaseConfiguration, error) {
+
+		if configuration == nil {
+			return nil, NewAPIError(600, "You must provide case configuration object")
+		}
+		if configuration.Owner == "" || configuration.ClosureType == "" {
+			return nil, NewAPIError(600, "You must provide case configuration owner and closure type")
+		}
+		log.Debug("Configuration: ", configuration)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := &caseConfigurationRequest{
+			ClosureType: configuration.ClosureType,
+			Connector:   configuration.Connector,
+			Owner:       configuration.Owner,
+		}
+		if request.Connector == nil {
+			request.Connector = NewCaseConnectorNone()
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/configure", basePathKibanaCase))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCaseConfiguration(resp.Body())
+	}
+}
+
+// newKibanaCaseUpdateConfigurationFunc permit to update cases configuration
+func newKibanaCaseUpdateConfigurationFunc(c *resty.Client) KibanaCaseUpdateConfiguration {
+	return func(configuration *CaseConfiguration, kibanaSpace string) (*CaseConfiguration, error) {
+
+		if configuration == nil {
+			return nil, NewAPIError(600, "You must provide case configuration object")
+		}
+		if configuration.ID == "" || configuration.Version == "" {
+			return nil, NewAPIError(600, "You must provide case configuration ID and version")
+		}
+		log.Debug("Configuration: ", configuration)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := &caseConfigurationRequest{
+			Version:     configuration.Version,
+			ClosureType: configuration.ClosureType,
+			Connector:   configuration.Connector,
+		}
+		if request.Connector == nil {
+			request.Connector = NewCaseConnectorNone()
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/configure/%s", basePathKibanaCase, configuration.ID))
+		resp, err := c.R().SetBody(jsonData).Patch(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCaseConfiguration(resp.Body())
+	}
+}
+
+// newKibanaCaseListTagsFunc permit to list the tags used on cases
+func newKibanaCaseListTagsFunc(c *resty.Client) KibanaCaseListTags {
+	return func(owners []string, kibanaSpace string) ([]string, error) {
+
+		log.Debug("Owners: ", owners)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/tags", basePathKibanaCase))
+		resp, err := c.R().SetQueryParamsFromValues(url.Values{"owner": owners}).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		tags := []string{}
+		err = json.Unmarshal(resp.Body(), &tags)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Tags: ", tags)
+
+		return tags, nil
+	}
+}
+
+// newKibanaCaseListReportersFunc permit to list the users that open cases
+func newKibanaCaseListReportersFunc(c *resty.Client) KibanaCaseListReporters {
+	return func(owners []string, kibanaSpace string) ([]CaseUser, error) {
+
+		log.Debug("Owners: ", owners)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/reporters", basePathKibanaCase))
+		resp, err := c.R().SetQueryParamsFromValues(url.Values{"owner": owners}).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		reporters := []CaseUser{}
+		err = json.Unmarshal(resp.Body(), &reporters)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Reporters: ", reporters)
+
+		return reporters, nil
+	}
+}
+
+// newKibanaCasePushFunc permit to push case on external system
+func newKibanaCasePushFunc(c *resty.Client) KibanaCasePush {
+	return func(caseID string, connectorID string, kibanaSpace string) (*Case, error) {
+
+		if caseID == "" || connectorID == "" {
+			return nil, NewAPIError(600, "You must provide case ID and connector ID")
+		}
+		log.Debug("CaseID: ", caseID)
+		log.Debug("ConnectorID: ", connectorID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/connector/%s/_push", basePathKibanaCase, caseID, connectorID))
+		resp, err := c.R().SetBody("{}").Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalCase(resp.Body())
+	}
+}
+
+// unmarshalCase permit to read case from response body
+func unmarshalCase(data []byte) (*Case, error) {
+	caseObj := &Case{}
+	if err := json.Unmarshal(data, caseObj); err != nil {
+		return nil, err
+	}
+	log.Debug("Case: ", caseObj)
+
+	return caseObj, nil
+}
+
+// unmarshalCaseConfiguration permit to read case configuration from response body
+func unmarshalCaseConfiguration(data []byte) (*CaseConfiguration, error) {
+	configuration := &CaseConfiguration{}
+	if err := json.Unmarshal(data, configuration); err != nil {
+		return nil, err
+	}
+	log.Debug("Configuration: ", configuration)
+
+	return configuration, nil
+}
@@ -0,0 +1,167 @@
+package kbapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaCases() {
+
+	// Create new case
+	caseObj, err := s.API.KibanaCases.Create(&Case{
+		Title:       "test",
+		Description: "test case",
+		Tags:        []string{"test"},
+		Owner:       CaseOwnerCases,
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), caseObj)
+	assert.NotEmpty(s.T(), caseObj.ID)
+	assert.Equal(s.T(), CaseStatusOpen, caseObj.Status)
+	assert.Equal(s.T(), CaseConnectorTypeNone, caseObj.Connector.Type)
+	id := caseObj.ID
+
+	// Get case
+	caseObj, err = s.API.KibanaCases.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), caseObj)
+	assert.Equal(s.T(), "test", caseObj.Title)
+
+	// Update case
+	caseObj.Status = CaseStatusInProgress
+	caseObj, err = s.API.KibanaCases.Update(caseObj, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), CaseStatusInProgress, caseObj.Status)
+
+	// Find cases
+	caseFindResponse, err := s.API.KibanaCases.Find(&CaseFindParameters{
+		Tags:  []string{"test"},
+		Owner: []string{CaseOwnerCases},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), caseFindResponse.Cases)
+
+	// Add user comment and alert
+	caseObj, err = s.API.KibanaCases.AddComment(id, &CaseComment{
+		Type:    CaseCommentTypeUser,
+		Owner:   CaseOwnerCases,
+		Comment: "test comment",
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, caseObj.TotalComment)
+	caseObj, err = s.API.KibanaCases.AddComment(id, &CaseComment{
+		Type:    CaseCommentTypeAlert,
+		Owner:   CaseOwnerCases,
+		AlertID: CaseStrings{"test-alert"},
+		Index:   CaseStrings{".alerts-test"},
+		Rule: &CaseCommentRule{
+			ID:   "test-rule",
+			Name: "test rule",
+		},
+	}, "default")
+	assert.NoError(s.T(), err)
+	_, err = s.API.KibanaCases.AddComment(id, &CaseComment{
+		Type:  CaseCommentTypeAlert,
+		Owner: CaseOwnerCases,
+	}, "default")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// Find and update comments
+	caseCommentFindResponse, err := s.API.KibanaCases.FindComments(id, 0, 0, "default")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), caseCommentFindResponse.Comments, 2)
+	var comment *CaseComment
+	for i := range caseCommentFindResponse.Comments {
+		if caseCommentFindResponse.Comments[i].Type == CaseCommentTypeUser {
+			comment = &caseCommentFindResponse.Comments[i]
+		}
+	}
+	assert.NotNil(s.T(), comment)
+	comment.Comment = "test comment 2"
+	_, err = s.API.KibanaCases.UpdateComment(id, comment, "default")
+	assert.NoError(s.T(), err)
+	comment, err = s.API.KibanaCases.GetComment(id, comment.ID, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test comment 2", comment.Comment)
+	err = s.API.KibanaCases.DeleteComment(id, comment.ID, "default")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaCases.DeleteComments(id, "default")
+	assert.NoError(s.T(), err)
+
+	// List tags and reporters
+	tags, err := s.API.KibanaCases.ListTags([]string{CaseOwnerCases}, "default")
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), tags, "test")
+	reporters, err := s.API.KibanaCases.ListReporters([]string{CaseOwnerCases}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), reporters)
+
+	// Handle case configuration
+	configurations, err := s.API.KibanaCases.GetConfiguration([]string{CaseOwnerCases}, "default")
+	assert.NoError(s.T(), err)
+	var configuration *CaseConfiguration
+	if len(configurations) == 0 {
+		configuration, err = s.API.KibanaCases.CreateConfiguration(&CaseConfiguration{
+			ClosureType: CaseClosureTypeByUser,
+			Owner:       CaseOwnerCases,
+		}, "default")
+		assert.NoError(s.T(), err)
+	} else {
+		configuration = &configurations[0]
+	}
+	configuration.ClosureType = CaseClosureTypeByPushing
+	configuration, err = s.API.KibanaCases.UpdateConfiguration(configuration, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), CaseClosureTypeByPushing, configuration.ClosureType)
+
+	// Delete case
+	err = s.API.KibanaCases.Delete([]string{id}, "default")
+	assert.NoError(s.T(), err)
+	caseObj, err = s.API.KibanaCases.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), caseObj)
+}
+
+func TestKibanaCasesUpdateClear(t *testing.T) {
+
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		return http.StatusOK, `[{"id": "case1", "version": "v2", "title": "test"}]`
+	})
+	sentCase := func() map[string]interface{} {
+		body := struct {
+			Cases []map[string]interface{} `json:"cases"`
+		}{}
+		kibana.lastRequest(t).decodeBody(t, &body)
+		assert.Len(t, body.Cases, 1)
+		return body.Cases[0]
+	}
+
+	// Partial update keep the tags, assignees and settings
+	_, err := kibana.API.KibanaCases.Update(&Case{
+		ID:      "case1",
+		Version: "v1",
+		Status:  CaseStatusClosed,
+	}, "default")
+	assert.NoError(t, err)
+	sent := sentCase()
+	assert.NotContains(t, sent, "tags")
+	assert.NotContains(t, sent, "assignees")
+	assert.NotContains(t, sent, "settings")
+	assert.Equal(t, CaseStatusClosed, sent["status"])
+
+	// Tags and assignees are cleared with empty list
+	_, err = kibana.API.KibanaCases.Update(&Case{
+		ID:        "case1",
+		Version:   "v1",
+		Tags:      []string{},
+		Assignees: []CaseAssignee{},
+		Settings:  &CaseSettings{SyncAlerts: false},
+	}, "default")
+	assert.NoError(t, err)
+	sent = sentCase()
+	assert.Equal(t, []interface{}{}, sent["tags"])
+	assert.Equal(t, []interface{}{}, sent["assignees"])
+	assert.Equal(t, map[string]interface{}{"syncAlerts": false}, sent["settings"])
+}
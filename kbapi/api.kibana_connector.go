@@ -0,0 +1,448 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaConnector = "/api/actions" // Base URL to access on Kibana connectors API
+)
+
+// Connector types handled with typed config and secrets
+const (
+	ConnectorTypeEmail     = ".email"
+	ConnectorTypeSlack     = ".slack"
+	ConnectorTypeWebhook   = ".webhook"
+	ConnectorTypeIndex     = ".index"
+	ConnectorTypeServerLog = ".server-log"
+	ConnectorTypePagerDuty = ".pagerduty"
+	ConnectorTypeJira      = ".jira"
+)
+
+// Connector is the connector (action) object.
+// Config and secrets depend on the connector type, use SetConfig, DecodeConfig and SetSecrets to handle them with typed struct.
+// Secrets are never returned by Kibana.
+type Connector struct {
+	ID                string          `json:"id,omitempty"`
+	Name              string          `json:"name"`
+	ConnectorTypeID   string          `json:"connector_type_id"`
+	Config            json.RawMessage `json:"config,omitempty"`
+	Secrets           json.RawMessage `json:"secrets,omitempty"`
+	IsPreconfigured   bool            `json:"is_preconfigured,omitempty"`
+	IsDeprecated      bool            `json:"is_deprecated,omitempty"`
+	IsMissingSecrets  bool            `json:"is_missing_secrets,omitempty"`
+	IsSystemAction    bool            `json:"is_system_action,omitempty"`
+	ReferencedByCount int             `json:"referenced_by_count,omitempty"`
+}
+
+// Connectors is list of Connector object
+type Connectors []Connector
+
+// ConnectorType is the connector type object
+type ConnectorType struct {
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	Enabled                bool     `json:"enabled"`
+	EnabledInConfig        bool     `json:"enabled_in_config"`
+	EnabledInLicense       bool     `json:"enabled_in_license"`
+	MinimumLicenseRequired string   `json:"minimum_license_required"`
+	SupportedFeatureIDs    []string `json:"supported_feature_ids,omitempty"`
+	IsSystemActionType     bool     `json:"is_system_action_type,omitempty"`
+}
+
+// ConnectorTypes is list of ConnectorType object
+type ConnectorTypes []ConnectorType
+
+// ConnectorExecuteResponse is the result when run connector
+type ConnectorExecuteResponse struct {
+	ConnectorID    string      `json:"connector_id"`
+	Status         string      `json:"status"`
+	Message        string      `json:"message,omitempty"`
+	ServiceMessage string      `json:"service_message,omitempty"`
+	Data           interface{} `json:"data,omitempty"`
+}
+
+// ConnectorEmailConfig is the config of email connector
+type ConnectorEmailConfig struct {
+	From    string `json:"from"`
+	Host    string `json:"host,omitempty"`
+	Port    int    `json:"port,omitempty"`
+	Secure  *bool  `json:"secure,omitempty"`
+	Service string `json:"service,omitempty"`
+	HasAuth *bool  `json:"hasAuth,omitempty"`
+}
+
+// ConnectorEmailSecrets is the secrets of email connector
+type ConnectorEmailSecrets struct {
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ConnectorSlackSecrets is the secrets of slack connector
+type ConnectorSlackSecrets struct {
+	WebhookURL string `json:"webhookUrl"`
+}
+
+// ConnectorWebhookConfig is the config of webhook connector
+type ConnectorWebhookConfig struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	HasAuth *bool             `json:"hasAuth,omitempty"`
+}
+
+// ConnectorWebhookSecrets is the secrets of webhook connector
+type ConnectorWebhookSecrets struct {
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ConnectorIndexConfig is the config of index connector
+type ConnectorIndexConfig struct {
+	Index              string `json:"index"`
+	Refresh            bool   `json:"refresh,omitempty"`
+	ExecutionTimeField string `json:"executionTimeField,omitempty"`
+}
+
+// ConnectorServerLogConfig is the config of server log connector, it has no settings
+type ConnectorServerLogConfig struct{}
+
+// ConnectorPagerDutyConfig is the config of PagerDuty connector
+type ConnectorPagerDutyConfig struct {
+	APIURL string `json:"apiUrl,omitempty"`
+}
+
+// ConnectorPagerDutySecrets is the secrets of PagerDuty connector
+type ConnectorPagerDutySecrets struct {
+	RoutingKey string `json:"routingKey"`
+}
+
+// ConnectorJiraConfig is the config of Jira connector
+type ConnectorJiraConfig struct {
+	APIURL     string `json:"apiUrl"`
+	ProjectKey string `json:"projectKey"`
+}
+
+// ConnectorJiraSecrets is the secrets of Jira connector
+type ConnectorJiraSecrets struct {
+	Email    string `json:"email"`
+	APIToken string `json:"apiToken"`
+}
+
+// connectorRequest is the connector attributes that can be written
+type connectorRequest struct {
+	Name            string          `json:"name"`
+	ConnectorTypeID string          `json:"connector_type_id,omitempty"`
+	Config          json.RawMessage `json:"config,omitempty"`
+	Secrets         json.RawMessage `json:"secrets,omitempty"`
+}
+
+// KibanaConnectorCreate permit to create connector. The ID is generated by Kibana if empty
+type KibanaConnectorCreate func(connector *Connector, kibanaSpace string) (*Connector, error)
+
+// KibanaConnectorGet permit to get connector
+type KibanaConnectorGet func(id string, kibanaSpace string) (*Connector, error)
+
+// KibanaConnectorList permit to get all connectors
+type KibanaConnectorList func(kibanaSpace string) (Connectors, error)
+
+// KibanaConnectorUpdate permit to update connector
+type KibanaConnectorUpdate func(connector *Connector, kibanaSpace string) (*Connector, error)
+
+// KibanaConnectorDelete permit to delete connector
+type KibanaConnectorDelete func(id string, kibanaSpace string) error
+
+// KibanaConnectorListTypes permit to get the connector types. FeatureID is optional
+type KibanaConnectorListTypes func(featureID string, kibanaSpace string) (ConnectorTypes, error)
+
+// KibanaConnectorExecute permit to run connector with params
+type KibanaConnectorExecute func(id string, params interface{}, kibanaSpace string) (*ConnectorExecuteResponse, error)
+
+// String permit to return Connector object as JSON string. Secrets are redacted.
+func (o *Connector) String() string {
+	connector := *o
+	if len(connector.Secrets) > 0 {
+		connector.Secrets = json.RawMessage(`"[REDACTED]"`)
+	}
+	json, _ := json.Marshal(connector)
+	return string(json)
+}
+
+// String permit to return ConnectorExecuteResponse object as JSON string
+func (o *ConnectorExecuteResponse) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// SetConfig permit to set the connector config from typed struct or map
+func (o *Connector) SetConfig(config interface{}) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	o.Config = data
+
+	return nil
+}
+
+// DecodeConfig permit to read the connector config on typed struct
+func (o *Connector) DecodeConfig(config interface{}) error {
+	if len(o.Config) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(o.Config, config)
+}
+
+// SetSecrets permit to set the connector secrets from typed struct or map
+func (o *Connector) SetSecrets(secrets interface{}) error {
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	o.Secrets = data
+
+	return nil
+}
+
+// newKibanaConnectorCreateFunc permit to create connector
+func newKibanaConnectorCreateFunc(c *resty.Client) KibanaConnectorCreate {
+	return func(connector *Connector, kibanaSpace string) (*Connector, error) {
+
+		if connector == nil {
+			return nil, NewAPIError(600, "You must provide connector object")
+		}
+		log.Debug("Connector: ", connector)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// The payload is not logged because it contain secrets
+		jsonData, err := json.Marshal(&connectorRequest{
+			Name:            connector.Name,
+			ConnectorTypeID: connector.ConnectorTypeID,
+			Config:          connector.Config,
+			Secrets:         connector.Secrets,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connector", basePathKibanaConnector))
+		if connector.ID != "" {
+			path = fmt.Sprintf("%s/%s", path, connector.ID)
+		}
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		connector = &Connector{}
+		err = json.Unmarshal(resp.Body(), connector)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Connector: ", connector)
+
+		return connector, nil
+	}
+}
+
+// newKibanaConnectorGetFunc permit to get connector with it ID
+func newKibanaConnectorGetFunc(c *resty.Client) KibanaConnectorGet {
+	return func(id string, kibanaSpace string) (*Connector, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide connector ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connector/%s", basePathKibanaConnector, id))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		connector := &Connector{}
+		err = json.Unmarshal(resp.Body(), connector)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Connector: ", connector)
+
+		return connector, nil
+	}
+}
+
+// newKibanaConnectorListFunc permit to get all connectors
+func newKibanaConnectorListFunc(c *resty.Client) KibanaConnectorList {
+	return func(kibanaSpace string) (Connectors, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connectors", basePathKibanaConnector))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		connectors := make(Connectors, 0, 1)
+		err = json.Unmarshal(resp.Body(), &connectors)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Connectors: ", connectors)
+
+		return connectors, nil
+	}
+}
+
+// newKibanaConnectorUpdateFunc permit to update connector
+func newKibanaConnectorUpdateFunc(c *resty.Client) KibanaConnectorUpdate {
+	return func(connector *Connector, kibanaSpace string) (*Connector, error) {
+
+		if connector == nil {
+			return nil, NewAPIError(600, "You must provide connector object")
+		}
+		if connector.ID == "" {
+			return nil, NewAPIError(600, "You must provide connector ID")
+		}
+		log.Debug("Connector: ", connector)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// The connector type can't be updated. The payload is not logged because it contain secrets
+		jsonData, err := json.Marshal(&connectorRequest{
+			Name:    connector.Name,
+			Config:  connector.Config,
+			Secrets: connector.Secrets,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connector/%s", basePathKibanaConnector, connector.ID))
+		resp, err := c.R().SetBody(jsonData).Put(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		connector = &Connector{}
+		err = json.Unmarshal(resp.Body(), connector)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Connector: ", connector)
+
+		return connector, nil
+	}
+}
+
+// newKibanaConnectorDeleteFunc permit to delete connector with it ID
+func newKibanaConnectorDeleteFunc(c *resty.Client) KibanaConnectorDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide connector ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connector/%s", basePathKibanaConnector, id))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaConnectorListTypesFunc permit to get the connector types
+func newKibanaConnectorListTypesFunc(c *resty.Client) KibanaConnectorListTypes {
+	return func(featureID string, kibanaSpace string) (ConnectorTypes, error) {
+
+		log.Debug("FeatureID: ", featureID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := c.R()
+		if featureID != "" {
+			request = request.SetQueryParam("feature_id", featureID)
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connector_types", basePathKibanaConnector))
+		resp, err := request.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		connectorTypes := make(ConnectorTypes, 0, 1)
+		err = json.Unmarshal(resp.Body(), &connectorTypes)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ConnectorTypes: ", connectorTypes)
+
+		return connectorTypes, nil
+	}
+}
+
+// newKibanaConnectorExecuteFunc permit to run connector
+func newKibanaConnectorExecuteFunc(c *resty.Client) KibanaConnectorExecute {
+	return func(id string, params interface{}, kibanaSpace string) (*ConnectorExecuteResponse, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide connector ID")
+		}
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"params": params,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/connector/%s/_execute", basePathKibanaConnector, id))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		connectorExecuteResponse := &ConnectorExecuteResponse{}
+		err = json.Unmarshal(resp.Body(), connectorExecuteResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ConnectorExecuteResponse: ", connectorExecuteResponse)
+
+		return connectorExecuteResponse, nil
+	}
+}
@@ -0,0 +1,81 @@
+package kbapi
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaConnectors() {
+
+	// Create new connector
+	connector := &Connector{
+		ID:              "test-connector",
+		Name:            "test",
+		ConnectorTypeID: ConnectorTypeIndex,
+	}
+	err := connector.SetConfig(&ConnectorIndexConfig{
+		Index:   "test-connector",
+		Refresh: true,
+	})
+	assert.NoError(s.T(), err)
+	connector, err = s.API.KibanaConnectors.Create(connector, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), connector)
+	assert.Equal(s.T(), "test-connector", connector.ID)
+
+	// Get connector
+	connector, err = s.API.KibanaConnectors.Get("test-connector", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), connector)
+	config := &ConnectorIndexConfig{}
+	err = connector.DecodeConfig(config)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test-connector", config.Index)
+
+	// List connectors
+	connectors, err := s.API.KibanaConnectors.List("default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), connectors)
+
+	// Update connector
+	connector.Name = "test2"
+	connector, err = s.API.KibanaConnectors.Update(connector, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test2", connector.Name)
+
+	// List connector types
+	connectorTypes, err := s.API.KibanaConnectors.ListTypes("", "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), connectorTypes)
+
+	// Execute connector
+	connectorExecuteResponse, err := s.API.KibanaConnectors.Execute("test-connector", map[string]interface{}{
+		"documents": []map[string]interface{}{
+			{
+				"message": "test",
+			},
+		},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ok", connectorExecuteResponse.Status)
+
+	// Secrets are redacted
+	webhookConnector := &Connector{
+		Name:            "test",
+		ConnectorTypeID: ConnectorTypeSlack,
+	}
+	err = webhookConnector.SetSecrets(&ConnectorSlackSecrets{
+		WebhookURL: "https://hooks.slack.com/services/secret",
+	})
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), strings.Contains(webhookConnector.String(), "hooks.slack.com"))
+
+	// Delete connector
+	err = s.API.KibanaConnectors.Delete("test-connector", "default")
+	assert.NoError(s.T(), err)
+	connector, err = s.API.KibanaConnectors.Get("test-connector", "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), connector)
+
+}
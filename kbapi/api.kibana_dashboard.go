@@ -1,6 +1,8 @@
 package kbapi
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/go-resty/resty/v2"
@@ -12,19 +14,90 @@ const (
 	basePathKibanaDashboard = "/api/kibana/dashboards" // Base URL to access on Kibana dashboard
 )
 
-// KibanaDashboardExport permit to export dashboard
+// SavedObjectImportResponse is the result when import saved objects
+type SavedObjectImportResponse struct {
+	Success        bool                     `json:"success"`
+	SuccessCount   int                      `json:"successCount"`
+	SuccessResults []map[string]interface{} `json:"successResults,omitempty"`
+	Errors         []SavedObjectImportError `json:"errors,omitempty"`
+}
+
+// SavedObjectImportError is the saved object that can't be imported
+type SavedObjectImportError struct {
+	ID    string                 `json:"id"`
+	Type  string                 `json:"type"`
+	Title string                 `json:"title,omitempty"`
+	Error map[string]interface{} `json:"error"`
+}
+
+// KibanaDashboardExport permit to export dashboard. This API is removed since Kibana 9.0, use ExportNDJSON instead.
+// An error is returned when the Kibana version can't be read.
 type KibanaDashboardExport func(listID []string, kibanaSpace string) (map[string]interface{}, error)
 
-// KibanaDashboardImport permit to import dashboard
+// KibanaDashboardImport permit to import dashboard. This API is removed since Kibana 9.0, use ImportNDJSON instead.
+// An error is returned when the Kibana version can't be read.
 type KibanaDashboardImport func(data map[string]interface{}, listExcludeType []string, force bool, kibanaSpace string) error
 
+// KibanaDashboardExportNDJSON permit to export dashboards with all objects they use, as NDJSON
+type KibanaDashboardExportNDJSON func(listID []string, kibanaSpace string) ([]byte, error)
+
+// KibanaDashboardImportNDJSON permit to import dashboards exported as NDJSON. The objects of excluded types are not imported.
+// Objects that can't be imported are on response errors.
+type KibanaDashboardImportNDJSON func(data []byte, listExcludeType []string, force bool, kibanaSpace string) (*SavedObjectImportResponse, error)
+
+// String permit to return SavedObjectImportResponse object as JSON string
+func (o *SavedObjectImportResponse) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// ConvertDashboardExportToNDJSON permit to convert dashboards exported with the legacy dashboard API to NDJSON,
+// so they can be imported with ImportNDJSON
+func ConvertDashboardExportToNDJSON(data map[string]interface{}) ([]byte, error) {
+	if data == nil {
+		return nil, NewAPIError(600, "You must provide dashboard export")
+	}
+	objects, ok := data["objects"].([]interface{})
+	if !ok {
+		return nil, NewAPIError(600, "Dashboard export must have objects list")
+	}
+
+	var buf bytes.Buffer
+	for _, object := range objects {
+		savedObject, ok := object.(map[string]interface{})
+		if !ok {
+			return nil, NewAPIError(600, "Dashboard export must have only objects")
+		}
+		// Objects not found on Kibana when exported
+		if _, isError := savedObject["error"]; isError {
+			log.Debugf("Skip object %v with error", savedObject["id"])
+			continue
+		}
+		line, err := json.Marshal(savedObject)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
 // newKibanaDashboardExportFunc permit to export Kibana dashboard by its names
-func newKibanaDashboardExportFunc(c *resty.Client) KibanaDashboardExport {
+func newKibanaDashboardExportFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaDashboardExport {
 	return func(listID []string, kibanaSpace string) (map[string]interface{}, error) {
 
 		if len(listID) == 0 {
 			return nil, NewAPIError(600, "You must provide on or more dashboard ID")
 		}
+		version, err := versionCache.get()
+		if err != nil {
+			return nil, err
+		}
+		if version.AtLeast(9, 0, 0) {
+			return nil, NewUnsupportedVersionError("Dashboard export API", version)
+		}
 		log.Debug("listID: ", listID)
 		log.Debug("kibanaSpace: ", kibanaSpace)
 
@@ -63,12 +136,19 @@ func newKibanaDashboardExportFunc(c *resty.Client) KibanaDashboardExport {
 }
 
 // newKibanaDashboardImportFunc permit to import kibana dashboard
-func newKibanaDashboardImportFunc(c *resty.Client) KibanaDashboardImport {
+func newKibanaDashboardImportFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaDashboardImport {
 	return func(data map[string]interface{}, listExcludeType []string, force bool, kibanaSpace string) error {
 
 		if data == nil {
 			return NewAPIError(600, "You must provide one or more dashboard to import")
 		}
+		version, err := versionCache.get()
+		if err != nil {
+			return err
+		}
+		if version.AtLeast(9, 0, 0) {
+			return NewUnsupportedVersionError("Dashboard import API", version)
+		}
 		log.Debug("data: ", data)
 		log.Debug("List type to exclude: ", listExcludeType)
 		log.Debug("Force import: ", force)
@@ -112,3 +192,121 @@ func newKibanaDashboardImportFunc(c *resty.Client) KibanaDashboardImport {
 	}
 
 }
+
+// newKibanaDashboardExportNDJSONFunc permit to export Kibana dashboards by their ID with saved objects API
+func newKibanaDashboardExportNDJSONFunc(c *resty.Client) KibanaDashboardExportNDJSON {
+	return func(listID []string, kibanaSpace string) ([]byte, error) {
+
+		if len(listID) == 0 {
+			return nil, NewAPIError(600, "You must provide on or more dashboard ID")
+		}
+		log.Debug("listID: ", listID)
+		log.Debug("kibanaSpace: ", kibanaSpace)
+
+		objects := make([]map[string]string, 0, len(listID))
+		for _, id := range listID {
+			objects = append(objects, map[string]string{
+				"type": "dashboard",
+				"id":   id,
+			})
+		}
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"objects":               objects,
+			"includeReferencesDeep": true,
+			"excludeExportDetails":  true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_export", basePathKibanaSavedObject))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return resp.Body(), nil
+	}
+}
+
+// newKibanaDashboardImportNDJSONFunc permit to import Kibana dashboards with saved objects API
+func newKibanaDashboardImportNDJSONFunc(c *resty.Client) KibanaDashboardImportNDJSON {
+	return func(data []byte, listExcludeType []string, force bool, kibanaSpace string) (*SavedObjectImportResponse, error) {
+
+		if len(data) == 0 {
+			return nil, NewAPIError(600, "You must provide one or more dashboard to import")
+		}
+		log.Debug("List type to exclude: ", listExcludeType)
+		log.Debug("Force import: ", force)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		if len(listExcludeType) > 0 {
+			var err error
+			data, err = excludeNDJSONTypes(data, listExcludeType)
+			if err != nil {
+				return nil, err
+			}
+			if len(data) == 0 {
+				return &SavedObjectImportResponse{Success: true}, nil
+			}
+		}
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_import", basePathKibanaSavedObject))
+		resp, err := c.R().
+			SetQueryString(fmt.Sprintf("overwrite=%t", force)).
+			SetFileReader("file", "dashboards.ndjson", bytes.NewReader(data)).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		importResponse := &SavedObjectImportResponse{}
+		err = json.Unmarshal(resp.Body(), importResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("SavedObjectImportResponse: ", importResponse)
+
+		return importResponse, nil
+	}
+}
+
+// excludeNDJSONTypes permit to remove the saved objects of types from NDJSON
+func excludeNDJSONTypes(data []byte, listExcludeType []string) ([]byte, error) {
+	excludeTypes := make(map[string]bool, len(listExcludeType))
+	for _, excludeType := range listExcludeType {
+		excludeTypes[excludeType] = true
+	}
+
+	var buf bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		savedObject := struct {
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(line, &savedObject); err != nil {
+			return nil, err
+		}
+		if excludeTypes[savedObject.Type] {
+			continue
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
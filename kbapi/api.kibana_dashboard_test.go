@@ -1,6 +1,7 @@
 package kbapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 
@@ -9,7 +10,50 @@ import (
 
 func (s *KBAPITestSuite) TestKibanaDashboard() {
 
-	// Import dashboard from fixtures
+	version, err := s.API.KibanaStatus.GetVersion()
+	assert.NoError(s.T(), err)
+
+	// Legacy dashboard API is removed since Kibana 9.0
+	if version.LessThan(9, 0, 0) {
+		// Import dashboard from fixtures
+		b, err := os.ReadFile("../fixtures/kibana-dashboard.json")
+		if err != nil {
+			panic(err)
+		}
+		data := make(map[string]interface{})
+		if err = json.Unmarshal(b, &data); err != nil {
+			panic(err)
+		}
+		err = s.API.KibanaDashboard.Import(data, nil, true, "default")
+		assert.NoError(s.T(), err)
+
+		// Export dashboard
+		data, err = s.API.KibanaDashboard.Export([]string{"edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"}, "default")
+		assert.NoError(s.T(), err)
+		assert.NotNil(s.T(), data)
+
+		// Import dashboard from fixtures in specific space
+		b, err = os.ReadFile("../fixtures/kibana-dashboard.json")
+		if err != nil {
+			panic(err)
+		}
+		data = make(map[string]interface{})
+		if err = json.Unmarshal(b, &data); err != nil {
+			panic(err)
+		}
+		err = s.API.KibanaDashboard.Import(data, nil, true, "testacc")
+		assert.NoError(s.T(), err)
+
+		// Export dashboard from specific space
+		data, err = s.API.KibanaDashboard.Export([]string{"edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"}, "testacc")
+		assert.NoError(s.T(), err)
+		assert.NotNil(s.T(), data)
+	} else {
+		_, err = s.API.KibanaDashboard.Export([]string{"edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"}, "default")
+		assert.Error(s.T(), err)
+	}
+
+	// Convert legacy export to NDJSON
 	b, err := os.ReadFile("../fixtures/kibana-dashboard.json")
 	if err != nil {
 		panic(err)
@@ -18,29 +62,37 @@ func (s *KBAPITestSuite) TestKibanaDashboard() {
 	if err = json.Unmarshal(b, &data); err != nil {
 		panic(err)
 	}
-	err = s.API.KibanaDashboard.Import(data, nil, true, "default")
+	ndjson, err := ConvertDashboardExportToNDJSON(data)
 	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), len(data["objects"].([]interface{})), bytes.Count(ndjson, []byte("\n")))
+	_, err = ConvertDashboardExportToNDJSON(map[string]interface{}{})
+	assert.Error(s.T(), err)
 
-	// Export dashboard
-	data, err = s.API.KibanaDashboard.Export([]string{"edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"}, "default")
+	// Import dashboard as NDJSON without index pattern
+	importResponse, err := s.API.KibanaDashboard.ImportNDJSON(ndjson, []string{"index-pattern"}, true, "testacc")
 	assert.NoError(s.T(), err)
-	assert.NotNil(s.T(), data)
-
-	// Import dashboard from fixtures in specific space
-	b, err = os.ReadFile("../fixtures/kibana-dashboard.json")
-	if err != nil {
-		panic(err)
+	if assert.NotNil(s.T(), importResponse) {
+		for _, importError := range importResponse.Errors {
+			assert.NotEqual(s.T(), "index-pattern", importError.Type)
+		}
 	}
-	data = make(map[string]interface{})
-	if err = json.Unmarshal(b, &data); err != nil {
-		panic(err)
-	}
-	err = s.API.KibanaDashboard.Import(data, nil, true, "testacc")
+
+	// Import dashboard as NDJSON
+	importResponse, err = s.API.KibanaDashboard.ImportNDJSON(ndjson, nil, true, "testacc")
 	assert.NoError(s.T(), err)
+	if assert.NotNil(s.T(), importResponse) {
+		assert.True(s.T(), importResponse.Success)
+		assert.Empty(s.T(), importResponse.Errors)
+		assert.NotZero(s.T(), importResponse.SuccessCount)
+	}
 
-	// Export dashboard from specific space
-	data, err = s.API.KibanaDashboard.Export([]string{"edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"}, "testacc")
+	// Export dashboard with all its objects as NDJSON
+	ndjson, err = s.API.KibanaDashboard.ExportNDJSON([]string{"edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"}, "testacc")
 	assert.NoError(s.T(), err)
-	assert.NotNil(s.T(), data)
+	assert.Contains(s.T(), string(ndjson), "\"type\":\"dashboard\"")
+	assert.Contains(s.T(), string(ndjson), "\"type\":\"visualization\"")
 
+	// Export dashboard that not exist
+	_, err = s.API.KibanaDashboard.ExportNDJSON([]string{"fake"}, "testacc")
+	assert.Error(s.T(), err)
 }
@@ -0,0 +1,581 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaDataView = "/api/data_views" // Base URL to access on Kibana data views API
+)
+
+// DataView is the data view object
+type DataView struct {
+	ID              string                          `json:"id,omitempty"`
+	Title           string                          `json:"title"`
+	Name            string                          `json:"name,omitempty"`
+	Version         string                          `json:"version,omitempty"`
+	Type            string                          `json:"type,omitempty"`
+	TypeMeta        map[string]interface{}          `json:"typeMeta,omitempty"`
+	TimeFieldName   string                          `json:"timeFieldName,omitempty"`
+	SourceFilters   []DataViewSourceFilter          `json:"sourceFilters,omitempty"`
+	Fields          map[string]interface{}          `json:"fields,omitempty"`
+	FieldFormats    map[string]DataViewFieldFormat  `json:"fieldFormats,omitempty"`
+	FieldAttrs      map[string]DataViewFieldAttrs   `json:"fieldAttrs,omitempty"`
+	RuntimeFieldMap map[string]DataViewRuntimeField `json:"runtimeFieldMap,omitempty"`
+	AllowNoIndex    bool                            `json:"allowNoIndex,omitempty"`
+	AllowHidden     bool                            `json:"allowHidden,omitempty"`
+	Namespaces      []string                        `json:"namespaces,omitempty"`
+}
+
+// DataViewSourceFilter is the data view source filter object
+type DataViewSourceFilter struct {
+	Value string `json:"value"`
+}
+
+// DataViewFieldFormat is the data view field format object
+type DataViewFieldFormat struct {
+	ID     string                 `json:"id"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// DataViewFieldAttrs is the data view field attributes object
+type DataViewFieldAttrs struct {
+	CustomLabel string `json:"customLabel,omitempty"`
+	Count       int    `json:"count,omitempty"`
+}
+
+// DataViewFieldUpdate is the metadata to update on data view field.
+// Only the not nil values are updated.
+type DataViewFieldUpdate struct {
+	CustomLabel *string              `json:"customLabel,omitempty"`
+	Count       *int                 `json:"count,omitempty"`
+	Format      *DataViewFieldFormat `json:"format,omitempty"`
+}
+
+// DataViewRuntimeField is the data view runtime field object
+type DataViewRuntimeField struct {
+	Type   string                      `json:"type"`
+	Script *DataViewRuntimeFieldScript `json:"script,omitempty"`
+}
+
+// DataViewRuntimeFieldScript is the script of data view runtime field
+type DataViewRuntimeFieldScript struct {
+	Source string `json:"source"`
+}
+
+// DataViewListItem is the data view object returned when list data views
+type DataViewListItem struct {
+	ID         string                 `json:"id"`
+	Title      string                 `json:"title"`
+	Name       string                 `json:"name,omitempty"`
+	Namespaces []string               `json:"namespaces,omitempty"`
+	TypeMeta   map[string]interface{} `json:"typeMeta,omitempty"`
+}
+
+// DataViewListItems is the list of DataViewListItem object
+type DataViewListItems []DataViewListItem
+
+// dataViewUpdate is the data view attributes that can be updated.
+// The time field and the flags are always sent, so they can be reset.
+type dataViewUpdate struct {
+	Title           string                          `json:"title,omitempty"`
+	Name            string                          `json:"name,omitempty"`
+	Type            string                          `json:"type,omitempty"`
+	TypeMeta        map[string]interface{}          `json:"typeMeta,omitempty"`
+	TimeFieldName   *string                         `json:"timeFieldName"`
+	SourceFilters   []DataViewSourceFilter          `json:"sourceFilters,omitempty"`
+	FieldFormats    map[string]DataViewFieldFormat  `json:"fieldFormats,omitempty"`
+	RuntimeFieldMap map[string]DataViewRuntimeField `json:"runtimeFieldMap,omitempty"`
+	AllowNoIndex    *bool                           `json:"allowNoIndex"`
+	AllowHidden     *bool                           `json:"allowHidden"`
+}
+
+// dataViewResponse is the response of data view API
+type dataViewResponse struct {
+	DataView *DataView `json:"data_view"`
+}
+
+// KibanaDataViewList permit to get all data views
+type KibanaDataViewList func(kibanaSpace string) (DataViewListItems, error)
+
+// KibanaDataViewGet permit to get data view
+type KibanaDataViewGet func(id string, kibanaSpace string) (*DataView, error)
+
+// KibanaDataViewCreate permit to create data view
+type KibanaDataViewCreate func(dataView *DataView, override bool, kibanaSpace string) (*DataView, error)
+
+// KibanaDataViewUpdate permit to update data view
+type KibanaDataViewUpdate func(dataView *DataView, refreshFields bool, kibanaSpace string) (*DataView, error)
+
+// KibanaDataViewDelete permit to delete data view
+type KibanaDataViewDelete func(id string, kibanaSpace string) error
+
+// KibanaDataViewGetDefault permit to get the default data view ID
+type KibanaDataViewGetDefault func(kibanaSpace string) (string, error)
+
+// KibanaDataViewSetDefault permit to set the default data view. Set force to replace existing default data view
+type KibanaDataViewSetDefault func(id string, force bool, kibanaSpace string) error
+
+// KibanaDataViewUpdateFields permit to update the fields metadata of data view
+type KibanaDataViewUpdateFields func(id string, fields map[string]DataViewFieldUpdate, kibanaSpace string) (*DataView, error)
+
+// KibanaDataViewGetRuntimeField permit to get runtime field of data view
+type KibanaDataViewGetRuntimeField func(id string, name string, kibanaSpace string) (*DataViewRuntimeField, error)
+
+// KibanaDataViewCreateRuntimeField permit to create runtime field on data view
+type KibanaDataViewCreateRuntimeField func(id string, name string, runtimeField *DataViewRuntimeField, kibanaSpace string) (*DataView, error)
+
+// KibanaDataViewUpdateRuntimeField permit to update runtime field on data view
+type KibanaDataViewUpdateRuntimeField func(id string, name string, runtimeField *DataViewRuntimeField, kibanaSpace string) (*DataView, error)
+
+// KibanaDataViewDeleteRuntimeField permit to delete runtime field on data view
+type KibanaDataViewDeleteRuntimeField func(id string, name string, kibanaSpace string) error
+
+// String permit to return DataView object as JSON string
+func (o *DataView) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// newKibanaDataViewListFunc permit to get all data views
+func newKibanaDataViewListFunc(c *resty.Client) KibanaDataViewList {
+	return func(kibanaSpace string) (DataViewListItems, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, basePathKibanaDataView)
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := struct {
+			DataViews DataViewListItems `json:"data_view"`
+		}{}
+		err = json.Unmarshal(resp.Body(), &data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataViews: ", data.DataViews)
+
+		return data.DataViews, nil
+	}
+}
+
+// newKibanaDataViewGetFunc permit to get data view with it ID
+func newKibanaDataViewGetFunc(c *resty.Client) KibanaDataViewGet {
+	return func(id string, kibanaSpace string) (*DataView, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide data view ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s", basePathKibanaDataView, id))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := &dataViewResponse{}
+		err = json.Unmarshal(resp.Body(), data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataView: ", data.DataView)
+
+		return data.DataView, nil
+	}
+}
+
+// newKibanaDataViewCreateFunc permit to create data view
+func newKibanaDataViewCreateFunc(c *resty.Client) KibanaDataViewCreate {
+	return func(dataView *DataView, override bool, kibanaSpace string) (*DataView, error) {
+
+		if dataView == nil {
+			return nil, NewAPIError(600, "You must provide data view object")
+		}
+		log.Debug("DataView: ", dataView)
+		log.Debug("Override: ", override)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"data_view": dataView,
+			"override":  override,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view", basePathKibanaDataView))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := &dataViewResponse{}
+		err = json.Unmarshal(resp.Body(), data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataView: ", data.DataView)
+
+		return data.DataView, nil
+	}
+}
+
+// newKibanaDataViewUpdateFunc permit to update data view
+func newKibanaDataViewUpdateFunc(c *resty.Client) KibanaDataViewUpdate {
+	return func(dataView *DataView, refreshFields bool, kibanaSpace string) (*DataView, error) {
+
+		if dataView == nil {
+			return nil, NewAPIError(600, "You must provide data view object")
+		}
+		if dataView.ID == "" {
+			return nil, NewAPIError(600, "You must provide data view ID")
+		}
+		log.Debug("DataView: ", dataView)
+		log.Debug("RefreshFields: ", refreshFields)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// Kibana refuse the attributes that can't be updated
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"data_view": &dataViewUpdate{
+				Title:           dataView.Title,
+				Name:            dataView.Name,
+				Type:            dataView.Type,
+				TypeMeta:        dataView.TypeMeta,
+				TimeFieldName:   &dataView.TimeFieldName,
+				SourceFilters:   dataView.SourceFilters,
+				FieldFormats:    dataView.FieldFormats,
+				RuntimeFieldMap: dataView.RuntimeFieldMap,
+				AllowNoIndex:    &dataView.AllowNoIndex,
+				AllowHidden:     &dataView.AllowHidden,
+			},
+			"refresh_fields": refreshFields,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s", basePathKibanaDataView, dataView.ID))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := &dataViewResponse{}
+		err = json.Unmarshal(resp.Body(), data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataView: ", data.DataView)
+
+		return data.DataView, nil
+	}
+}
+
+// newKibanaDataViewDeleteFunc permit to delete data view with it ID
+func newKibanaDataViewDeleteFunc(c *resty.Client) KibanaDataViewDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide data view ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s", basePathKibanaDataView, id))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaDataViewGetDefaultFunc permit to get the default data view ID
+func newKibanaDataViewGetDefaultFunc(c *resty.Client) KibanaDataViewGetDefault {
+	return func(kibanaSpace string) (string, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/default", basePathKibanaDataView))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return "", err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return "", NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := struct {
+			DataViewID string `json:"data_view_id"`
+		}{}
+		err = json.Unmarshal(resp.Body(), &data)
+		if err != nil {
+			return "", err
+		}
+		log.Debug("Default data view: ", data.DataViewID)
+
+		return data.DataViewID, nil
+	}
+}
+
+// newKibanaDataViewSetDefaultFunc permit to set the default data view
+func newKibanaDataViewSetDefaultFunc(c *resty.Client) KibanaDataViewSetDefault {
+	return func(id string, force bool, kibanaSpace string) error {
+
+		log.Debug("ID: ", id)
+		log.Debug("Force: ", force)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// Null data view ID permit to unset the default data view
+		var dataViewID interface{}
+		if id != "" {
+			dataViewID = id
+		}
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"data_view_id": dataViewID,
+			"force":        force,
+		})
+		if err != nil {
+			return err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/default", basePathKibanaDataView))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaDataViewUpdateFieldsFunc permit to update the fields metadata of data view
+func newKibanaDataViewUpdateFieldsFunc(c *resty.Client) KibanaDataViewUpdateFields {
+	return func(id string, fields map[string]DataViewFieldUpdate, kibanaSpace string) (*DataView, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide data view ID")
+		}
+		if len(fields) == 0 {
+			return nil, NewAPIError(600, "You must provide one or more fields to update")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Fields: ", fields)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"fields": fields,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s/fields", basePathKibanaDataView, id))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := &dataViewResponse{}
+		err = json.Unmarshal(resp.Body(), data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataView: ", data.DataView)
+
+		return data.DataView, nil
+	}
+}
+
+// newKibanaDataViewGetRuntimeFieldFunc permit to get runtime field of data view
+func newKibanaDataViewGetRuntimeFieldFunc(c *resty.Client) KibanaDataViewGetRuntimeField {
+	return func(id string, name string, kibanaSpace string) (*DataViewRuntimeField, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide data view ID")
+		}
+		if name == "" {
+			return nil, NewAPIError(600, "You must provide runtime field name")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Name: ", name)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s/runtime_field/%s", basePathKibanaDataView, id, name))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := struct {
+			Fields []struct {
+				RuntimeField *DataViewRuntimeField `json:"runtimeField"`
+			} `json:"fields"`
+		}{}
+		err = json.Unmarshal(resp.Body(), &data)
+		if err != nil {
+			return nil, err
+		}
+		if len(data.Fields) == 0 {
+			return nil, nil
+		}
+		log.Debug("RuntimeField: ", data.Fields[0].RuntimeField)
+
+		return data.Fields[0].RuntimeField, nil
+	}
+}
+
+// newKibanaDataViewCreateRuntimeFieldFunc permit to create runtime field on data view
+func newKibanaDataViewCreateRuntimeFieldFunc(c *resty.Client) KibanaDataViewCreateRuntimeField {
+	return func(id string, name string, runtimeField *DataViewRuntimeField, kibanaSpace string) (*DataView, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide data view ID")
+		}
+		if name == "" {
+			return nil, NewAPIError(600, "You must provide runtime field name")
+		}
+		if runtimeField == nil {
+			return nil, NewAPIError(600, "You must provide runtime field object")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Name: ", name)
+		log.Debug("RuntimeField: ", runtimeField)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"name":         name,
+			"runtimeField": runtimeField,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s/runtime_field", basePathKibanaDataView, id))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := &dataViewResponse{}
+		err = json.Unmarshal(resp.Body(), data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataView: ", data.DataView)
+
+		return data.DataView, nil
+	}
+}
+
+// newKibanaDataViewUpdateRuntimeFieldFunc permit to update runtime field on data view
+func newKibanaDataViewUpdateRuntimeFieldFunc(c *resty.Client) KibanaDataViewUpdateRuntimeField {
+	return func(id string, name string, runtimeField *DataViewRuntimeField, kibanaSpace string) (*DataView, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide data view ID")
+		}
+		if name == "" {
+			return nil, NewAPIError(600, "You must provide runtime field name")
+		}
+		if runtimeField == nil {
+			return nil, NewAPIError(600, "You must provide runtime field object")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Name: ", name)
+		log.Debug("RuntimeField: ", runtimeField)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"runtimeField": runtimeField,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s/runtime_field/%s", basePathKibanaDataView, id, name))
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := &dataViewResponse{}
+		err = json.Unmarshal(resp.Body(), data)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DataView: ", data.DataView)
+
+		return data.DataView, nil
+	}
+}
+
+// newKibanaDataViewDeleteRuntimeFieldFunc permit to delete runtime field on data view
+func newKibanaDataViewDeleteRuntimeFieldFunc(c *resty.Client) KibanaDataViewDeleteRuntimeField {
+	return func(id string, name string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide data view ID")
+		}
+		if name == "" {
+			return NewAPIError(600, "You must provide runtime field name")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Name: ", name)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/data_view/%s/runtime_field/%s", basePathKibanaDataView, id, name))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
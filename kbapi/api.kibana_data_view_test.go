@@ -0,0 +1,143 @@
+package kbapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaDataViews() {
+
+	// Create new data view
+	dataView := &DataView{
+		ID:            "test-data-view",
+		Title:         "logs-test-*",
+		Name:          "Test logs",
+		TimeFieldName: "@timestamp",
+		AllowNoIndex:  true,
+	}
+	dataView, err := s.API.KibanaDataViews.Create(dataView, true, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), dataView)
+	assert.Equal(s.T(), "test-data-view", dataView.ID)
+
+	// Create new data view in space
+	dataView2 := &DataView{
+		ID:           "test-data-view2",
+		Title:        "logs-test2-*",
+		AllowNoIndex: true,
+	}
+	dataView2, err = s.API.KibanaDataViews.Create(dataView2, true, "testacc")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), dataView2)
+
+	// Get data view
+	dataView, err = s.API.KibanaDataViews.Get("test-data-view", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), dataView)
+	assert.Equal(s.T(), "logs-test-*", dataView.Title)
+
+	// Get data view from space
+	dataView2, err = s.API.KibanaDataViews.Get("test-data-view2", "testacc")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), dataView2)
+
+	// List data views
+	dataViews, err := s.API.KibanaDataViews.List("default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), dataViews)
+
+	// Update data view
+	dataView.Name = "Test logs updated"
+	dataView, err = s.API.KibanaDataViews.Update(dataView, false, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Test logs updated", dataView.Name)
+
+	// Update fields metadata
+	customLabel := "Event time"
+	dataView, err = s.API.KibanaDataViews.UpdateFields("test-data-view", map[string]DataViewFieldUpdate{
+		"@timestamp": {
+			CustomLabel: &customLabel,
+		},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Event time", dataView.FieldAttrs["@timestamp"].CustomLabel)
+
+	// Handle runtime fields
+	runtimeField := &DataViewRuntimeField{
+		Type: "keyword",
+		Script: &DataViewRuntimeFieldScript{
+			Source: "emit('test')",
+		},
+	}
+	_, err = s.API.KibanaDataViews.CreateRuntimeField("test-data-view", "test_runtime", runtimeField, "default")
+	assert.NoError(s.T(), err)
+	runtimeField, err = s.API.KibanaDataViews.GetRuntimeField("test-data-view", "test_runtime", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), runtimeField)
+	assert.Equal(s.T(), "keyword", runtimeField.Type)
+	runtimeField.Type = "long"
+	runtimeField.Script.Source = "emit(1)"
+	_, err = s.API.KibanaDataViews.UpdateRuntimeField("test-data-view", "test_runtime", runtimeField, "default")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaDataViews.DeleteRuntimeField("test-data-view", "test_runtime", "default")
+	assert.NoError(s.T(), err)
+
+	// Handle default data view
+	err = s.API.KibanaDataViews.SetDefault("test-data-view", true, "default")
+	assert.NoError(s.T(), err)
+	defaultDataView, err := s.API.KibanaDataViews.GetDefault("default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test-data-view", defaultDataView)
+	err = s.API.KibanaDataViews.SetDefault("", true, "default")
+	assert.NoError(s.T(), err)
+
+	// Delete data views
+	err = s.API.KibanaDataViews.Delete("test-data-view", "default")
+	assert.NoError(s.T(), err)
+	dataView, err = s.API.KibanaDataViews.Get("test-data-view", "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), dataView)
+	err = s.API.KibanaDataViews.Delete("test-data-view2", "testacc")
+	assert.NoError(s.T(), err)
+
+}
+
+func TestKibanaDataViewsUpdateReset(t *testing.T) {
+
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		return http.StatusOK, `{"data_view": {"id": "test-data-view", "title": "logs-test-*"}}`
+	})
+	sentDataView := func() map[string]interface{} {
+		body := struct {
+			DataView map[string]interface{} `json:"data_view"`
+		}{}
+		kibana.lastRequest(t).decodeBody(t, &body)
+		return body.DataView
+	}
+
+	// Time field and flags are sent, even when they are reset
+	_, err := kibana.API.KibanaDataViews.Update(&DataView{
+		ID:    "test-data-view",
+		Title: "logs-test-*",
+	}, false, "default")
+	assert.NoError(t, err)
+	sent := sentDataView()
+	assert.Equal(t, "", sent["timeFieldName"])
+	assert.Equal(t, false, sent["allowNoIndex"])
+	assert.Equal(t, false, sent["allowHidden"])
+
+	_, err = kibana.API.KibanaDataViews.Update(&DataView{
+		ID:            "test-data-view",
+		Title:         "logs-test-*",
+		TimeFieldName: "@timestamp",
+		AllowNoIndex:  true,
+		AllowHidden:   true,
+	}, false, "default")
+	assert.NoError(t, err)
+	sent = sentDataView()
+	assert.Equal(t, "@timestamp", sent["timeFieldName"])
+	assert.Equal(t, true, sent["allowNoIndex"])
+	assert.Equal(t, true, sent["allowHidden"])
+}
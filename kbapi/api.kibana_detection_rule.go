@@ -0,0 +1,856 @@
+package kbapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaDetectionRule = "/api/detection_engine/rules" // Base URL to access on Kibana detection rules API
+)
+
+// Types of detection rule
+const (
+	DetectionRuleTypeQuery           = "query"
+	DetectionRuleTypeSavedQuery      = "saved_query"
+	DetectionRuleTypeEQL             = "eql"
+	DetectionRuleTypeThreshold       = "threshold"
+	DetectionRuleTypeMachineLearning = "machine_learning"
+	DetectionRuleTypeThreatMatch     = "threat_match"
+	DetectionRuleTypeNewTerms        = "new_terms"
+	DetectionRuleTypeESQL            = "esql"
+)
+
+// Actions of detection rules bulk action
+const (
+	DetectionRuleBulkActionEnable    = "enable"
+	DetectionRuleBulkActionDisable   = "disable"
+	DetectionRuleBulkActionDuplicate = "duplicate"
+	DetectionRuleBulkActionDelete    = "delete"
+	DetectionRuleBulkActionEdit      = "edit"
+)
+
+// Edit types of detection rules bulk edit action
+const (
+	DetectionRuleBulkEditAddTags             = "add_tags"
+	DetectionRuleBulkEditDeleteTags          = "delete_tags"
+	DetectionRuleBulkEditSetTags             = "set_tags"
+	DetectionRuleBulkEditAddIndexPatterns    = "add_index_patterns"
+	DetectionRuleBulkEditDeleteIndexPatterns = "delete_index_patterns"
+	DetectionRuleBulkEditSetIndexPatterns    = "set_index_patterns"
+	DetectionRuleBulkEditSetTimeline         = "set_timeline"
+	DetectionRuleBulkEditSetSchedule         = "set_schedule"
+	DetectionRuleBulkEditAddRuleActions      = "add_rule_actions"
+	DetectionRuleBulkEditSetRuleActions      = "set_rule_actions"
+)
+
+// detectionRuleReadOnlyFields is the fields computed by Kibana, that can't be written
+var detectionRuleReadOnlyFields = []string{"immutable", "created_at", "created_by", "updated_at", "updated_by", "revision", "execution_summary", "rule_source"}
+
+// DetectionRule is the security detection rule.
+// The fields to use depend on the rule type, call Validate to check them.
+type DetectionRule struct {
+	ID                  string                       `json:"id,omitempty"`
+	RuleID              string                       `json:"rule_id,omitempty"`
+	Type                string                       `json:"type"`
+	Name                string                       `json:"name"`
+	Description         string                       `json:"description"`
+	RiskScore           int                          `json:"risk_score"`
+	Severity            string                       `json:"severity"`
+	Enabled             *bool                        `json:"enabled,omitempty"`
+	Interval            string                       `json:"interval,omitempty"`
+	From                string                       `json:"from,omitempty"`
+	To                  string                       `json:"to,omitempty"`
+	MaxSignals          int                          `json:"max_signals,omitempty"`
+	Tags                []string                     `json:"tags,omitempty"`
+	Author              []string                     `json:"author,omitempty"`
+	License             string                       `json:"license,omitempty"`
+	References          []string                     `json:"references,omitempty"`
+	FalsePositives      []string                     `json:"false_positives,omitempty"`
+	Threat              []DetectionRuleThreat        `json:"threat,omitempty"`
+	Note                string                       `json:"note,omitempty"`
+	Setup               string                       `json:"setup,omitempty"`
+	Version             int                          `json:"version,omitempty"`
+	ExceptionsList      []DetectionRuleExceptionList `json:"exceptions_list,omitempty"`
+	Actions             []DetectionRuleAction        `json:"actions,omitempty"`
+	Throttle            string                       `json:"throttle,omitempty"`
+	BuildingBlockType   string                       `json:"building_block_type,omitempty"`
+	TimelineID          string                       `json:"timeline_id,omitempty"`
+	TimelineTitle       string                       `json:"timeline_title,omitempty"`
+	RuleNameOverride    string                       `json:"rule_name_override,omitempty"`
+	TimestampOverride   string                       `json:"timestamp_override,omitempty"`
+	RiskScoreMapping    []map[string]interface{}     `json:"risk_score_mapping,omitempty"`
+	SeverityMapping     []map[string]interface{}     `json:"severity_mapping,omitempty"`
+	InvestigationFields map[string]interface{}       `json:"investigation_fields,omitempty"`
+	RelatedIntegrations []map[string]interface{}     `json:"related_integrations,omitempty"`
+	RequiredFields      []map[string]interface{}     `json:"required_fields,omitempty"`
+	AlertSuppression    map[string]interface{}       `json:"alert_suppression,omitempty"`
+	Meta                map[string]interface{}       `json:"meta,omitempty"`
+	Namespace           string                       `json:"namespace,omitempty"`
+
+	// Query, saved query, EQL, threshold, threat match, new terms and ES|QL rules
+	Index      []string      `json:"index,omitempty"`
+	DataViewID string        `json:"data_view_id,omitempty"`
+	Query      string        `json:"query,omitempty"`
+	Language   string        `json:"language,omitempty"`
+	Filters    []interface{} `json:"filters,omitempty"`
+	SavedID    string        `json:"saved_id,omitempty"`
+
+	// EQL rules
+	EventCategoryOverride string `json:"event_category_override,omitempty"`
+	TiebreakerField       string `json:"tiebreaker_field,omitempty"`
+	TimestampField        string `json:"timestamp_field,omitempty"`
+
+	// Threshold rules
+	Threshold *DetectionRuleThreshold `json:"threshold,omitempty"`
+
+	// Machine learning rules
+	AnomalyThreshold     *int     `json:"anomaly_threshold,omitempty"`
+	MachineLearningJobID []string `json:"machine_learning_job_id,omitempty"`
+
+	// Threat match rules
+	ThreatIndex         []string                     `json:"threat_index,omitempty"`
+	ThreatQuery         string                       `json:"threat_query,omitempty"`
+	ThreatMapping       []DetectionRuleThreatMapping `json:"threat_mapping,omitempty"`
+	ThreatLanguage      string                       `json:"threat_language,omitempty"`
+	ThreatFilters       []interface{}                `json:"threat_filters,omitempty"`
+	ThreatIndicatorPath string                       `json:"threat_indicator_path,omitempty"`
+	ItemsPerSearch      int                          `json:"items_per_search,omitempty"`
+	ConcurrentSearches  int                          `json:"concurrent_searches,omitempty"`
+
+	// New terms rules
+	NewTermsFields     []string `json:"new_terms_fields,omitempty"`
+	HistoryWindowStart string   `json:"history_window_start,omitempty"`
+
+	// Computed by Kibana
+	Immutable        bool                   `json:"immutable,omitempty"`
+	CreatedAt        string                 `json:"created_at,omitempty"`
+	CreatedBy        string                 `json:"created_by,omitempty"`
+	UpdatedAt        string                 `json:"updated_at,omitempty"`
+	UpdatedBy        string                 `json:"updated_by,omitempty"`
+	Revision         int                    `json:"revision,omitempty"`
+	ExecutionSummary map[string]interface{} `json:"execution_summary,omitempty"`
+	RuleSource       map[string]interface{} `json:"rule_source,omitempty"`
+}
+
+// DetectionRules is list of DetectionRule object
+type DetectionRules []DetectionRule
+
+// DetectionRuleThreat is the MITRE ATT&CK tactic and techniques detected by rule
+type DetectionRuleThreat struct {
+	Framework string                         `json:"framework"`
+	Tactic    DetectionRuleThreatItem        `json:"tactic"`
+	Technique []DetectionRuleThreatTechnique `json:"technique,omitempty"`
+}
+
+// DetectionRuleThreatItem is the MITRE ATT&CK tactic, technique or subtechnique
+type DetectionRuleThreatItem struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Reference string `json:"reference"`
+}
+
+// DetectionRuleThreatTechnique is the MITRE ATT&CK technique with it subtechniques
+type DetectionRuleThreatTechnique struct {
+	DetectionRuleThreatItem
+	Subtechnique []DetectionRuleThreatItem `json:"subtechnique,omitempty"`
+}
+
+// DetectionRuleExceptionList is the exception list attached on rule
+type DetectionRuleExceptionList struct {
+	ID            string `json:"id"`
+	ListID        string `json:"list_id"`
+	Type          string `json:"type"`
+	NamespaceType string `json:"namespace_type"`
+}
+
+// DetectionRuleAction is the action run when rule generate alerts
+type DetectionRuleAction struct {
+	Group        string                 `json:"group,omitempty"`
+	ID           string                 `json:"id"`
+	ActionTypeID string                 `json:"action_type_id"`
+	UUID         string                 `json:"uuid,omitempty"`
+	Params       map[string]interface{} `json:"params"`
+	Frequency    map[string]interface{} `json:"frequency,omitempty"`
+}
+
+// DetectionRuleThreshold is the threshold of threshold rule
+type DetectionRuleThreshold struct {
+	Field       []string                            `json:"field"`
+	Value       int                                 `json:"value"`
+	Cardinality []DetectionRuleThresholdCardinality `json:"cardinality,omitempty"`
+}
+
+// DetectionRuleThresholdCardinality is the cardinality condition of threshold rule
+type DetectionRuleThresholdCardinality struct {
+	Field string `json:"field"`
+	Value int    `json:"value"`
+}
+
+// DetectionRuleThreatMapping is the mapping between source event and threat indicator fields of threat match rule
+type DetectionRuleThreatMapping struct {
+	Entries []DetectionRuleThreatMappingEntry `json:"entries"`
+}
+
+// DetectionRuleThreatMappingEntry is one field mapping of threat match rule
+type DetectionRuleThreatMappingEntry struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// DetectionRuleFindParameters contain optional parameters to find detection rules
+type DetectionRuleFindParameters struct {
+	Page      int
+	PerPage   int
+	SortField string
+	SortOrder string
+	Filter    string
+	Fields    []string
+}
+
+// DetectionRuleFindResponse is the result when find detection rules
+type DetectionRuleFindResponse struct {
+	Page    int            `json:"page"`
+	PerPage int            `json:"perPage"`
+	Total   int            `json:"total"`
+	Data    DetectionRules `json:"data"`
+}
+
+// DetectionRuleBulkAction is the action to run on detection rules selected with their IDs or with query
+type DetectionRuleBulkAction struct {
+	Action    string                      `json:"action"`
+	IDs       []string                    `json:"ids,omitempty"`
+	Query     string                      `json:"query,omitempty"`
+	Duplicate *DetectionRuleBulkDuplicate `json:"duplicate,omitempty"`
+	Edit      []DetectionRuleBulkEdit     `json:"edit,omitempty"`
+}
+
+// DetectionRuleBulkDuplicate is the options of duplicate bulk action
+type DetectionRuleBulkDuplicate struct {
+	IncludeExceptions        bool `json:"include_exceptions"`
+	IncludeExpiredExceptions bool `json:"include_expired_exceptions"`
+}
+
+// DetectionRuleBulkEdit is the edit to apply with edit bulk action
+type DetectionRuleBulkEdit struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// DetectionRuleBulkActionResponse is the result of bulk action
+type DetectionRuleBulkActionResponse struct {
+	Success    bool                              `json:"success"`
+	RulesCount int                               `json:"rules_count"`
+	Attributes DetectionRuleBulkActionAttributes `json:"attributes"`
+}
+
+// DetectionRuleBulkActionAttributes is the detail of bulk action result
+type DetectionRuleBulkActionAttributes struct {
+	Results struct {
+		Updated DetectionRules           `json:"updated"`
+		Created DetectionRules           `json:"created"`
+		Deleted DetectionRules           `json:"deleted"`
+		Skipped []map[string]interface{} `json:"skipped"`
+	} `json:"results"`
+	Summary struct {
+		Failed    int `json:"failed"`
+		Skipped   int `json:"skipped"`
+		Succeeded int `json:"succeeded"`
+		Total     int `json:"total"`
+	} `json:"summary"`
+	Errors []map[string]interface{} `json:"errors,omitempty"`
+}
+
+// DetectionRuleImportParameters contain optional parameters to import detection rules
+type DetectionRuleImportParameters struct {
+	Overwrite                 bool
+	OverwriteExceptions       bool
+	OverwriteActionConnectors bool
+}
+
+// DetectionRuleImportResponse is the result of detection rules import
+type DetectionRuleImportResponse struct {
+	Success                      bool                       `json:"success"`
+	SuccessCount                 int                        `json:"success_count"`
+	RulesCount                   int                        `json:"rules_count"`
+	Errors                       []DetectionRuleImportError `json:"errors"`
+	ExceptionsSuccess            bool                       `json:"exceptions_success"`
+	ExceptionsSuccessCount       int                        `json:"exceptions_success_count"`
+	ExceptionsErrors             []map[string]interface{}   `json:"exceptions_errors"`
+	ActionConnectorsSuccess      bool                       `json:"action_connectors_success"`
+	ActionConnectorsSuccessCount int                        `json:"action_connectors_success_count"`
+	ActionConnectorsErrors       []map[string]interface{}   `json:"action_connectors_errors"`
+	ActionConnectorsWarnings     []map[string]interface{}   `json:"action_connectors_warnings"`
+}
+
+// DetectionRuleImportError is the error when import one detection rule
+type DetectionRuleImportError struct {
+	RuleID string `json:"rule_id,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Error  struct {
+		StatusCode int    `json:"status_code"`
+		Message    string `json:"message"`
+	} `json:"error"`
+}
+
+// DetectionRulePrebuiltInstallResponse is the result of prebuilt rules install
+type DetectionRulePrebuiltInstallResponse struct {
+	RulesInstalled     int `json:"rules_installed"`
+	RulesUpdated       int `json:"rules_updated"`
+	TimelinesInstalled int `json:"timelines_installed"`
+	TimelinesUpdated   int `json:"timelines_updated"`
+}
+
+// DetectionRulePrebuiltStatus is the status of prebuilt rules and timelines
+type DetectionRulePrebuiltStatus struct {
+	RulesCustomInstalled  int `json:"rules_custom_installed"`
+	RulesInstalled        int `json:"rules_installed"`
+	RulesNotInstalled     int `json:"rules_not_installed"`
+	RulesNotUpdated       int `json:"rules_not_updated"`
+	TimelinesInstalled    int `json:"timelines_installed"`
+	TimelinesNotInstalled int `json:"timelines_not_installed"`
+	TimelinesNotUpdated   int `json:"timelines_not_updated"`
+}
+
+// KibanaDetectionRuleCreate permit to create detection rule
+type KibanaDetectionRuleCreate func(rule *DetectionRule, kibanaSpace string) (*DetectionRule, error)
+
+// KibanaDetectionRuleGet permit to get detection rule with it ID
+type KibanaDetectionRuleGet func(id string, kibanaSpace string) (*DetectionRule, error)
+
+// KibanaDetectionRuleGetByRuleID permit to get detection rule with it rule ID
+type KibanaDetectionRuleGetByRuleID func(ruleID string, kibanaSpace string) (*DetectionRule, error)
+
+// KibanaDetectionRuleUpdate permit to replace detection rule, identified with it ID or rule ID
+type KibanaDetectionRuleUpdate func(rule *DetectionRule, kibanaSpace string) (*DetectionRule, error)
+
+// KibanaDetectionRuleDelete permit to delete detection rule with it ID
+type KibanaDetectionRuleDelete func(id string, kibanaSpace string) error
+
+// KibanaDetectionRuleDeleteByRuleID permit to delete detection rule with it rule ID
+type KibanaDetectionRuleDeleteByRuleID func(ruleID string, kibanaSpace string) error
+
+// KibanaDetectionRuleFind permit to find detection rules
+type KibanaDetectionRuleFind func(parameters *DetectionRuleFindParameters, kibanaSpace string) (*DetectionRuleFindResponse, error)
+
+// KibanaDetectionRuleBulkAction permit to run action on many detection rules
+type KibanaDetectionRuleBulkAction func(action *DetectionRuleBulkAction, dryRun bool, kibanaSpace string) (*DetectionRuleBulkActionResponse, error)
+
+// KibanaDetectionRuleImport permit to import detection rules from NDJSON
+type KibanaDetectionRuleImport func(data []byte, parameters *DetectionRuleImportParameters, kibanaSpace string) (*DetectionRuleImportResponse, error)
+
+// KibanaDetectionRuleExport permit to export detection rules as NDJSON. When ruleIDs is empty, all rules are exported.
+type KibanaDetectionRuleExport func(ruleIDs []string, excludeExportDetails bool, kibanaSpace string) ([]byte, error)
+
+// KibanaDetectionRuleInstallPrebuilt permit to install and update the prebuilt rules and timelines
+type KibanaDetectionRuleInstallPrebuilt func(kibanaSpace string) (*DetectionRulePrebuiltInstallResponse, error)
+
+// KibanaDetectionRulePrebuiltStatus permit to get the status of prebuilt rules and timelines
+type KibanaDetectionRulePrebuiltStatus func(kibanaSpace string) (*DetectionRulePrebuiltStatus, error)
+
+// String permit to return DetectionRule object as JSON string
+func (o *DetectionRule) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// Validate check that the fields required by the rule type are provided
+func (o *DetectionRule) Validate() error {
+	var errors []string
+
+	if o.Name == "" {
+		errors = append(errors, "name must be provided")
+	}
+	if o.Description == "" {
+		errors = append(errors, "description must be provided")
+	}
+	if o.RiskScore < 0 || o.RiskScore > 100 {
+		errors = append(errors, "risk_score must be between 0 and 100")
+	}
+	switch o.Severity {
+	case "low", "medium", "high", "critical":
+	default:
+		errors = append(errors, fmt.Sprintf("severity '%s' must be low, medium, high or critical", o.Severity))
+	}
+
+	switch o.Type {
+	case DetectionRuleTypeQuery:
+		// Query can be empty to match all documents
+	case DetectionRuleTypeSavedQuery:
+		if o.SavedID == "" {
+			errors = append(errors, "saved_id must be provided for saved_query rule")
+		}
+	case DetectionRuleTypeEQL:
+		if o.Query == "" {
+			errors = append(errors, "query must be provided for eql rule")
+		}
+		if o.Language != "" && o.Language != "eql" {
+			errors = append(errors, "language must be eql for eql rule")
+		}
+	case DetectionRuleTypeThreshold:
+		if o.Threshold == nil {
+			errors = append(errors, "threshold must be provided for threshold rule")
+		} else if o.Threshold.Value < 1 {
+			errors = append(errors, "threshold value must be greater than 0")
+		}
+	case DetectionRuleTypeMachineLearning:
+		if o.AnomalyThreshold == nil {
+			errors = append(errors, "anomaly_threshold must be provided for machine_learning rule")
+		}
+		if len(o.MachineLearningJobID) == 0 {
+			errors = append(errors, "machine_learning_job_id must be provided for machine_learning rule")
+		}
+	case DetectionRuleTypeThreatMatch:
+		if len(o.ThreatIndex) == 0 || o.ThreatQuery == "" || len(o.ThreatMapping) == 0 {
+			errors = append(errors, "threat_index, threat_query and threat_mapping must be provided for threat_match rule")
+		}
+	case DetectionRuleTypeNewTerms:
+		if len(o.NewTermsFields) == 0 || len(o.NewTermsFields) > 3 {
+			errors = append(errors, "new_terms_fields must contain 1 to 3 fields for new_terms rule")
+		}
+		if o.HistoryWindowStart == "" {
+			errors = append(errors, "history_window_start must be provided for new_terms rule")
+		}
+	case DetectionRuleTypeESQL:
+		if o.Query == "" {
+			errors = append(errors, "query must be provided for esql rule")
+		}
+		if o.Language != "esql" {
+			errors = append(errors, "language must be esql for esql rule")
+		}
+		if len(o.Index) > 0 {
+			errors = append(errors, "index can't be used with esql rule")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("type '%s' is not valid rule type", o.Type))
+	}
+
+	if len(errors) > 0 {
+		return NewAPIError(600, "Detection rule is not valid: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+// toRequest return the detection rule fields that can be written
+func (o *DetectionRule) toRequest() (map[string]interface{}, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{}
+	if err = json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	for _, field := range detectionRuleReadOnlyFields {
+		delete(request, field)
+	}
+
+	return request, nil
+}
+
+// newKibanaDetectionRuleCreateFunc permit to create detection rule
+func newKibanaDetectionRuleCreateFunc(c *resty.Client) KibanaDetectionRuleCreate {
+	return func(rule *DetectionRule, kibanaSpace string) (*DetectionRule, error) {
+
+		if rule == nil {
+			return nil, NewAPIError(600, "You must provide detection rule object")
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		log.Debug("Rule: ", rule)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request, err := rule.toRequest()
+		if err != nil {
+			return nil, err
+		}
+		delete(request, "id")
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, basePathKibanaDetectionRule)
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalDetectionRule(resp.Body())
+	}
+}
+
+// newKibanaDetectionRuleGetFunc permit to get detection rule with it ID
+func newKibanaDetectionRuleGetFunc(c *resty.Client) KibanaDetectionRuleGet {
+	return func(id string, kibanaSpace string) (*DetectionRule, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide detection rule ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return getDetectionRule(c, "id", id, kibanaSpace)
+	}
+}
+
+// newKibanaDetectionRuleGetByRuleIDFunc permit to get detection rule with it rule ID
+func newKibanaDetectionRuleGetByRuleIDFunc(c *resty.Client) KibanaDetectionRuleGetByRuleID {
+	return func(ruleID string, kibanaSpace string) (*DetectionRule, error) {
+
+		if ruleID == "" {
+			return nil, NewAPIError(600, "You must provide detection rule rule ID")
+		}
+		log.Debug("RuleID: ", ruleID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return getDetectionRule(c, "rule_id", ruleID, kibanaSpace)
+	}
+}
+
+// newKibanaDetectionRuleUpdateFunc permit to replace detection rule
+func newKibanaDetectionRuleUpdateFunc(c *resty.Client) KibanaDetectionRuleUpdate {
+	return func(rule *DetectionRule, kibanaSpace string) (*DetectionRule, error) {
+
+		if rule == nil {
+			return nil, NewAPIError(600, "You must provide detection rule object")
+		}
+		if rule.ID == "" && rule.RuleID == "" {
+			return nil, NewAPIError(600, "You must provide detection rule ID or rule ID")
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		log.Debug("Rule: ", rule)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request, err := rule.toRequest()
+		if err != nil {
+			return nil, err
+		}
+		// Rule is identified with only one of ID and rule ID
+		if rule.ID != "" {
+			delete(request, "rule_id")
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, basePathKibanaDetectionRule)
+		resp, err := c.R().SetBody(jsonData).Put(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalDetectionRule(resp.Body())
+	}
+}
+
+// newKibanaDetectionRuleDeleteFunc permit to delete detection rule with it ID
+func newKibanaDetectionRuleDeleteFunc(c *resty.Client) KibanaDetectionRuleDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide detection rule ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return deleteDetectionRule(c, "id", id, kibanaSpace)
+	}
+}
+
+// newKibanaDetectionRuleDeleteByRuleIDFunc permit to delete detection rule with it rule ID
+func newKibanaDetectionRuleDeleteByRuleIDFunc(c *resty.Client) KibanaDetectionRuleDeleteByRuleID {
+	return func(ruleID string, kibanaSpace string) error {
+
+		if ruleID == "" {
+			return NewAPIError(600, "You must provide detection rule rule ID")
+		}
+		log.Debug("RuleID: ", ruleID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return deleteDetectionRule(c, "rule_id", ruleID, kibanaSpace)
+	}
+}
+
+// newKibanaDetectionRuleFindFunc permit to find detection rules
+func newKibanaDetectionRuleFindFunc(c *resty.Client) KibanaDetectionRuleFind {
+	return func(parameters *DetectionRuleFindParameters, kibanaSpace string) (*DetectionRuleFindResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := map[string]string{}
+		if parameters != nil {
+			if parameters.Page != 0 {
+				queryParams["page"] = strconv.Itoa(parameters.Page)
+			}
+			if parameters.PerPage != 0 {
+				queryParams["per_page"] = strconv.Itoa(parameters.PerPage)
+			}
+			if parameters.SortField != "" {
+				queryParams["sort_field"] = parameters.SortField
+			}
+			if parameters.SortOrder != "" {
+				queryParams["sort_order"] = parameters.SortOrder
+			}
+			if parameters.Filter != "" {
+				queryParams["filter"] = parameters.Filter
+			}
+			if parameters.Fields != nil {
+				queryParams["fields"] = strings.Join(parameters.Fields, ",")
+			}
+		}
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_find", basePathKibanaDetectionRule))
+		resp, err := c.R().SetQueryParams(queryParams).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		detectionRuleFindResponse := &DetectionRuleFindResponse{}
+		err = json.Unmarshal(resp.Body(), detectionRuleFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("DetectionRules: ", detectionRuleFindResponse.Data)
+
+		return detectionRuleFindResponse, nil
+	}
+}
+
+// newKibanaDetectionRuleBulkActionFunc permit to run action on many detection rules
+func newKibanaDetectionRuleBulkActionFunc(c *resty.Client) KibanaDetectionRuleBulkAction {
+	return func(action *DetectionRuleBulkAction, dryRun bool, kibanaSpace string) (*DetectionRuleBulkActionResponse, error) {
+
+		if action == nil || action.Action == "" {
+			return nil, NewAPIError(600, "You must provide bulk action")
+		}
+		if len(action.IDs) > 0 && action.Query != "" {
+			return nil, NewAPIError(600, "You can't provide rule IDs and query together")
+		}
+		if action.Action == DetectionRuleBulkActionEdit && len(action.Edit) == 0 {
+			return nil, NewAPIError(600, "You must provide edit when action is '%s'", DetectionRuleBulkActionEdit)
+		}
+		log.Debug("Action: ", action)
+		log.Debug("DryRun: ", dryRun)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(action)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_bulk_action", basePathKibanaDetectionRule))
+		resp, err := c.R().
+			SetQueryString(fmt.Sprintf("dry_run=%t", dryRun)).
+			SetBody(jsonData).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		detectionRuleBulkActionResponse := &DetectionRuleBulkActionResponse{}
+		err = json.Unmarshal(resp.Body(), detectionRuleBulkActionResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("BulkActionResponse: ", detectionRuleBulkActionResponse.Attributes.Summary)
+
+		return detectionRuleBulkActionResponse, nil
+	}
+}
+
+// newKibanaDetectionRuleImportFunc permit to import detection rules
+func newKibanaDetectionRuleImportFunc(c *resty.Client) KibanaDetectionRuleImport {
+	return func(data []byte, parameters *DetectionRuleImportParameters, kibanaSpace string) (*DetectionRuleImportResponse, error) {
+
+		if len(data) == 0 {
+			return nil, NewAPIError(600, "You must provide data parameters")
+		}
+		if parameters == nil {
+			parameters = &DetectionRuleImportParameters{}
+		}
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_import", basePathKibanaDetectionRule))
+		resp, err := c.R().
+			SetQueryParams(map[string]string{
+				"overwrite":                   strconv.FormatBool(parameters.Overwrite),
+				"overwrite_exceptions":        strconv.FormatBool(parameters.OverwriteExceptions),
+				"overwrite_action_connectors": strconv.FormatBool(parameters.OverwriteActionConnectors),
+			}).
+			SetFileReader("file", "rules.ndjson", bytes.NewReader(data)).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		detectionRuleImportResponse := &DetectionRuleImportResponse{}
+		err = json.Unmarshal(resp.Body(), detectionRuleImportResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ImportResponse: ", detectionRuleImportResponse)
+
+		return detectionRuleImportResponse, nil
+	}
+}
+
+// newKibanaDetectionRuleExportFunc permit to export detection rules
+func newKibanaDetectionRuleExportFunc(c *resty.Client) KibanaDetectionRuleExport {
+	return func(ruleIDs []string, excludeExportDetails bool, kibanaSpace string) ([]byte, error) {
+
+		log.Debug("RuleIDs: ", ruleIDs)
+		log.Debug("ExcludeExportDetails: ", excludeExportDetails)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := c.R().SetQueryString(fmt.Sprintf("exclude_export_details=%t", excludeExportDetails))
+		if len(ruleIDs) > 0 {
+			objects := make([]map[string]string, 0, len(ruleIDs))
+			for _, ruleID := range ruleIDs {
+				objects = append(objects, map[string]string{"rule_id": ruleID})
+			}
+			jsonData, err := json.Marshal(map[string]interface{}{
+				"objects": objects,
+			})
+			if err != nil {
+				return nil, err
+			}
+			request = request.SetBody(jsonData)
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_export", basePathKibanaDetectionRule))
+		resp, err := request.Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return resp.Body(), nil
+	}
+}
+
+// newKibanaDetectionRuleInstallPrebuiltFunc permit to install the prebuilt rules and timelines
+func newKibanaDetectionRuleInstallPrebuiltFunc(c *resty.Client) KibanaDetectionRuleInstallPrebuilt {
+	return func(kibanaSpace string) (*DetectionRulePrebuiltInstallResponse, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/prepackaged", basePathKibanaDetectionRule))
+		resp, err := c.R().Put(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		detectionRulePrebuiltInstallResponse := &DetectionRulePrebuiltInstallResponse{}
+		err = json.Unmarshal(resp.Body(), detectionRulePrebuiltInstallResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("PrebuiltInstallResponse: ", detectionRulePrebuiltInstallResponse)
+
+		return detectionRulePrebuiltInstallResponse, nil
+	}
+}
+
+// newKibanaDetectionRulePrebuiltStatusFunc permit to get the status of prebuilt rules and timelines
+func newKibanaDetectionRulePrebuiltStatusFunc(c *resty.Client) KibanaDetectionRulePrebuiltStatus {
+	return func(kibanaSpace string) (*DetectionRulePrebuiltStatus, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/prepackaged/_status", basePathKibanaDetectionRule))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		detectionRulePrebuiltStatus := &DetectionRulePrebuiltStatus{}
+		err = json.Unmarshal(resp.Body(), detectionRulePrebuiltStatus)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("PrebuiltStatus: ", detectionRulePrebuiltStatus)
+
+		return detectionRulePrebuiltStatus, nil
+	}
+}
+
+// getDetectionRule permit to get detection rule with it ID or rule ID
+func getDetectionRule(c *resty.Client, key string, value string, kibanaSpace string) (*DetectionRule, error) {
+	path := spacePath(kibanaSpace, basePathKibanaDetectionRule)
+	resp, err := c.R().SetQueryParam(key, value).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return unmarshalDetectionRule(resp.Body())
+}
+
+// deleteDetectionRule permit to delete detection rule with it ID or rule ID
+func deleteDetectionRule(c *resty.Client, key string, value string, kibanaSpace string) error {
+	path := spacePath(kibanaSpace, basePathKibanaDetectionRule)
+	resp, err := c.R().SetQueryParam(key, value).Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// unmarshalDetectionRule permit to read detection rule from response body
+func unmarshalDetectionRule(data []byte) (*DetectionRule, error) {
+	rule := &DetectionRule{}
+	if err := json.Unmarshal(data, rule); err != nil {
+		return nil, err
+	}
+	log.Debug("DetectionRule: ", rule)
+
+	return rule, nil
+}
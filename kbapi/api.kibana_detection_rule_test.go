@@ -0,0 +1,142 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaDetectionRules() {
+
+	// Validate rules
+	err := (&DetectionRule{
+		Type:        DetectionRuleTypeThreshold,
+		Name:        "test",
+		Description: "test",
+		RiskScore:   50,
+		Severity:    "low",
+	}).Validate()
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+	_, err = s.API.KibanaDetectionRules.Create(&DetectionRule{
+		Type:        DetectionRuleTypeEQL,
+		Name:        "test",
+		Description: "test",
+		RiskScore:   150,
+		Severity:    "unknown",
+	}, "default")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// Create new rule
+	rule, err := s.API.KibanaDetectionRules.Create(&DetectionRule{
+		RuleID:      "test-detection-rule",
+		Type:        DetectionRuleTypeQuery,
+		Name:        "test",
+		Description: "test rule",
+		RiskScore:   21,
+		Severity:    "low",
+		Index:       []string{"logs-*"},
+		Query:       "event.action:test",
+		Language:    "kuery",
+		Tags:        []string{"test"},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), rule)
+	assert.NotEmpty(s.T(), rule.ID)
+	assert.False(s.T(), rule.Immutable)
+	id := rule.ID
+
+	// Create threshold rule
+	thresholdRule, err := s.API.KibanaDetectionRules.Create(&DetectionRule{
+		Type:        DetectionRuleTypeThreshold,
+		Name:        "test threshold",
+		Description: "test rule",
+		RiskScore:   47,
+		Severity:    "medium",
+		Index:       []string{"logs-*"},
+		Query:       "event.action:test",
+		Language:    "kuery",
+		Threshold: &DetectionRuleThreshold{
+			Field: []string{"host.name"},
+			Value: 10,
+		},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 10, thresholdRule.Threshold.Value)
+
+	// Get rule
+	rule, err = s.API.KibanaDetectionRules.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), rule)
+	assert.Equal(s.T(), "test-detection-rule", rule.RuleID)
+	rule, err = s.API.KibanaDetectionRules.GetByRuleID("test-detection-rule", "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), id, rule.ID)
+
+	// Update rule
+	rule.Description = "test rule 2"
+	rule, err = s.API.KibanaDetectionRules.Update(rule, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test rule 2", rule.Description)
+
+	// Find rules
+	detectionRuleFindResponse, err := s.API.KibanaDetectionRules.Find(&DetectionRuleFindParameters{
+		Filter: "alert.attributes.tags:test",
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), detectionRuleFindResponse.Data)
+
+	// Bulk actions
+	detectionRuleBulkActionResponse, err := s.API.KibanaDetectionRules.BulkAction(&DetectionRuleBulkAction{
+		Action: DetectionRuleBulkActionEdit,
+		IDs:    []string{id},
+		Edit: []DetectionRuleBulkEdit{
+			{
+				Type:  DetectionRuleBulkEditAddTags,
+				Value: []string{"test2"},
+			},
+		},
+	}, false, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, detectionRuleBulkActionResponse.Attributes.Summary.Succeeded)
+	if assert.Len(s.T(), detectionRuleBulkActionResponse.Attributes.Results.Updated, 1) {
+		assert.Contains(s.T(), detectionRuleBulkActionResponse.Attributes.Results.Updated[0].Tags, "test2")
+	}
+	_, err = s.API.KibanaDetectionRules.BulkAction(&DetectionRuleBulkAction{
+		Action: DetectionRuleBulkActionEdit,
+		IDs:    []string{id},
+	}, false, "default")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+	detectionRuleBulkActionResponse, err = s.API.KibanaDetectionRules.BulkAction(&DetectionRuleBulkAction{
+		Action: DetectionRuleBulkActionDuplicate,
+		IDs:    []string{id},
+	}, false, "default")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), detectionRuleBulkActionResponse.Attributes.Results.Created, 1)
+	duplicatedRules := detectionRuleBulkActionResponse.Attributes.Results.Created
+
+	// Export and import rules
+	data, err := s.API.KibanaDetectionRules.Export([]string{"test-detection-rule"}, true, "default")
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), string(data), "test-detection-rule")
+	detectionRuleImportResponse, err := s.API.KibanaDetectionRules.Import(data, &DetectionRuleImportParameters{
+		Overwrite: true,
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), detectionRuleImportResponse.Success)
+	assert.Equal(s.T(), 1, detectionRuleImportResponse.SuccessCount)
+
+	// Prebuilt rules status
+	_, err = s.API.KibanaDetectionRules.PrebuiltStatus("default")
+	assert.NoError(s.T(), err)
+
+	// Delete rules
+	err = s.API.KibanaDetectionRules.Delete(thresholdRule.ID, "default")
+	assert.NoError(s.T(), err)
+	for _, duplicatedRule := range duplicatedRules {
+		err = s.API.KibanaDetectionRules.Delete(duplicatedRule.ID, "default")
+		assert.NoError(s.T(), err)
+	}
+	err = s.API.KibanaDetectionRules.DeleteByRuleID("test-detection-rule", "default")
+	assert.NoError(s.T(), err)
+	rule, err = s.API.KibanaDetectionRules.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), rule)
+}
@@ -0,0 +1,888 @@
+package kbapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaExceptionList = "/api/exception_lists" // Base URL to access on Kibana exception lists API
+	basePathKibanaEndpointList  = "/api/endpoint_list"   // Base URL to access on Kibana endpoint exception list API
+)
+
+// Types of exception list
+const (
+	ExceptionListTypeDetection             = "detection"
+	ExceptionListTypeRuleDefault           = "rule_default"
+	ExceptionListTypeEndpoint              = "endpoint"
+	ExceptionListTypeEndpointTrustedApps   = "endpoint_trusted_apps"
+	ExceptionListTypeEndpointEvents        = "endpoint_events"
+	ExceptionListTypeEndpointHostIsolation = "endpoint_host_isolation_exceptions"
+	ExceptionListTypeEndpointBlocklists    = "endpoint_blocklists"
+)
+
+// Namespace types of exception list. Agnostic lists are shared by all spaces.
+const (
+	ExceptionListNamespaceSingle   = "single"
+	ExceptionListNamespaceAgnostic = "agnostic"
+)
+
+// Types of exception list item entry
+const (
+	ExceptionEntryTypeMatch    = "match"
+	ExceptionEntryTypeMatchAny = "match_any"
+	ExceptionEntryTypeList     = "list"
+	ExceptionEntryTypeExists   = "exists"
+	ExceptionEntryTypeNested   = "nested"
+	ExceptionEntryTypeWildcard = "wildcard"
+)
+
+// Operators of exception list item entry
+const (
+	ExceptionEntryOperatorIncluded = "included"
+	ExceptionEntryOperatorExcluded = "excluded"
+)
+
+// ExceptionList is the container of exception items, attached on detection rules
+type ExceptionList struct {
+	ID            string                 `json:"id,omitempty"`
+	ListID        string                 `json:"list_id,omitempty"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Type          string                 `json:"type"`
+	NamespaceType string                 `json:"namespace_type,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	OSTypes       []string               `json:"os_types,omitempty"`
+	Meta          map[string]interface{} `json:"meta,omitempty"`
+	Version       int                    `json:"version,omitempty"`
+	Immutable     bool                   `json:"immutable,omitempty"`
+	TieBreakerID  string                 `json:"tie_breaker_id,omitempty"`
+	CreatedAt     string                 `json:"created_at,omitempty"`
+	CreatedBy     string                 `json:"created_by,omitempty"`
+	UpdatedAt     string                 `json:"updated_at,omitempty"`
+	UpdatedBy     string                 `json:"updated_by,omitempty"`
+}
+
+// ExceptionLists is list of ExceptionList object
+type ExceptionLists []ExceptionList
+
+// exceptionListRequest is the exception list fields that can be written
+type exceptionListRequest struct {
+	ID            string                 `json:"id,omitempty"`
+	ListID        string                 `json:"list_id,omitempty"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Type          string                 `json:"type"`
+	NamespaceType string                 `json:"namespace_type,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	OSTypes       []string               `json:"os_types,omitempty"`
+	Meta          map[string]interface{} `json:"meta,omitempty"`
+	Version       int                    `json:"version,omitempty"`
+}
+
+// ExceptionListItem is the exception that prevent rule to generate alert when all entries match
+type ExceptionListItem struct {
+	ID            string                     `json:"id,omitempty"`
+	ItemID        string                     `json:"item_id,omitempty"`
+	ListID        string                     `json:"list_id,omitempty"`
+	Name          string                     `json:"name"`
+	Description   string                     `json:"description"`
+	Type          string                     `json:"type"`
+	NamespaceType string                     `json:"namespace_type,omitempty"`
+	Entries       []ExceptionListItemEntry   `json:"entries"`
+	OSTypes       []string                   `json:"os_types,omitempty"`
+	Tags          []string                   `json:"tags,omitempty"`
+	Comments      []ExceptionListItemComment `json:"comments,omitempty"`
+	ExpireTime    string                     `json:"expire_time,omitempty"`
+	Meta          map[string]interface{}     `json:"meta,omitempty"`
+	TieBreakerID  string                     `json:"tie_breaker_id,omitempty"`
+	CreatedAt     string                     `json:"created_at,omitempty"`
+	CreatedBy     string                     `json:"created_by,omitempty"`
+	UpdatedAt     string                     `json:"updated_at,omitempty"`
+	UpdatedBy     string                     `json:"updated_by,omitempty"`
+}
+
+// ExceptionListItems is list of ExceptionListItem object
+type ExceptionListItems []ExceptionListItem
+
+// exceptionListItemRequest is the exception list item fields that can be written
+type exceptionListItemRequest struct {
+	ID            string                            `json:"id,omitempty"`
+	ItemID        string                            `json:"item_id,omitempty"`
+	ListID        string                            `json:"list_id,omitempty"`
+	Name          string                            `json:"name"`
+	Description   string                            `json:"description"`
+	Type          string                            `json:"type"`
+	NamespaceType string                            `json:"namespace_type,omitempty"`
+	Entries       []ExceptionListItemEntry          `json:"entries"`
+	OSTypes       []string                          `json:"os_types,omitempty"`
+	Tags          []string                          `json:"tags,omitempty"`
+	Comments      []exceptionListItemCommentRequest `json:"comments,omitempty"`
+	ExpireTime    string                            `json:"expire_time,omitempty"`
+	Meta          map[string]interface{}            `json:"meta,omitempty"`
+}
+
+// ExceptionListItemComment is the comment on exception list item
+type ExceptionListItemComment struct {
+	ID        string `json:"id,omitempty"`
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"created_at,omitempty"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// exceptionListItemCommentRequest is the comment fields that can be written
+type exceptionListItemCommentRequest struct {
+	ID      string `json:"id,omitempty"`
+	Comment string `json:"comment"`
+}
+
+// ExceptionListItemEntry is the condition of exception list item.
+// The fields to use depend on the entry type:
+//   - match and wildcard: Field, Operator and Value
+//   - match_any: Field, Operator and Values
+//   - list: Field, Operator and List
+//   - exists: Field and Operator
+//   - nested: Field and Entries
+type ExceptionListItemEntry struct {
+	Field    string
+	Type     string
+	Operator string
+	Value    string
+	Values   []string
+	List     *ExceptionListItemEntryList
+	Entries  []ExceptionListItemEntry
+}
+
+// ExceptionListItemEntryList is the value list used by list entry
+type ExceptionListItemEntryList struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// ExceptionListFindParameters contain optional parameters to find exception lists or items
+type ExceptionListFindParameters struct {
+	Filter        string
+	NamespaceType []string
+	Page          int
+	PerPage       int
+	SortField     string
+	SortOrder     string
+}
+
+// ExceptionListFindResponse is the result when find exception lists
+type ExceptionListFindResponse struct {
+	Page    int            `json:"page"`
+	PerPage int            `json:"per_page"`
+	Total   int            `json:"total"`
+	Data    ExceptionLists `json:"data"`
+}
+
+// ExceptionListItemFindResponse is the result when find exception list items
+type ExceptionListItemFindResponse struct {
+	Page    int                `json:"page"`
+	PerPage int                `json:"per_page"`
+	Total   int                `json:"total"`
+	Data    ExceptionListItems `json:"data"`
+}
+
+// ExceptionListImportResponse is the result of exception lists import
+type ExceptionListImportResponse struct {
+	Success                        bool                     `json:"success"`
+	SuccessCount                   int                      `json:"success_count"`
+	SuccessExceptionLists          bool                     `json:"success_exception_lists"`
+	SuccessCountExceptionLists     int                      `json:"success_count_exception_lists"`
+	SuccessExceptionListItems      bool                     `json:"success_exception_list_items"`
+	SuccessCountExceptionListItems int                      `json:"success_count_exception_list_items"`
+	Errors                         []map[string]interface{} `json:"errors"`
+}
+
+// KibanaExceptionListCreate permit to create exception list
+type KibanaExceptionListCreate func(list *ExceptionList, kibanaSpace string) (*ExceptionList, error)
+
+// KibanaExceptionListGet permit to get exception list with it list ID
+type KibanaExceptionListGet func(listID string, namespaceType string, kibanaSpace string) (*ExceptionList, error)
+
+// KibanaExceptionListUpdate permit to update exception list
+type KibanaExceptionListUpdate func(list *ExceptionList, kibanaSpace string) (*ExceptionList, error)
+
+// KibanaExceptionListDelete permit to delete exception list and all it items with it list ID
+type KibanaExceptionListDelete func(listID string, namespaceType string, kibanaSpace string) error
+
+// KibanaExceptionListFind permit to find exception lists
+type KibanaExceptionListFind func(parameters *ExceptionListFindParameters, kibanaSpace string) (*ExceptionListFindResponse, error)
+
+// KibanaExceptionListExport permit to export exception list and it items as NDJSON
+type KibanaExceptionListExport func(list *ExceptionList, kibanaSpace string) ([]byte, error)
+
+// KibanaExceptionListImport permit to import exception lists and items from NDJSON
+type KibanaExceptionListImport func(data []byte, overwrite bool, kibanaSpace string) (*ExceptionListImportResponse, error)
+
+// KibanaExceptionListCreateItem permit to create exception list item
+type KibanaExceptionListCreateItem func(item *ExceptionListItem, kibanaSpace string) (*ExceptionListItem, error)
+
+// KibanaExceptionListGetItem permit to get exception list item with it item ID
+type KibanaExceptionListGetItem func(itemID string, namespaceType string, kibanaSpace string) (*ExceptionListItem, error)
+
+// KibanaExceptionListUpdateItem permit to update exception list item
+type KibanaExceptionListUpdateItem func(item *ExceptionListItem, kibanaSpace string) (*ExceptionListItem, error)
+
+// KibanaExceptionListDeleteItem permit to delete exception list item with it item ID
+type KibanaExceptionListDeleteItem func(itemID string, namespaceType string, kibanaSpace string) error
+
+// KibanaExceptionListFindItems permit to find items of exception list
+type KibanaExceptionListFindItems func(listID string, parameters *ExceptionListFindParameters, kibanaSpace string) (*ExceptionListItemFindResponse, error)
+
+// KibanaExceptionListCreateEndpointList permit to create the Elastic Endpoint exception list. The list returned is empty when it already exist.
+type KibanaExceptionListCreateEndpointList func() (*ExceptionList, error)
+
+// KibanaExceptionListCreateEndpointItem permit to create item on Elastic Endpoint exception list
+type KibanaExceptionListCreateEndpointItem func(item *ExceptionListItem) (*ExceptionListItem, error)
+
+// KibanaExceptionListGetEndpointItem permit to get item of Elastic Endpoint exception list with it item ID
+type KibanaExceptionListGetEndpointItem func(itemID string) (*ExceptionListItem, error)
+
+// KibanaExceptionListUpdateEndpointItem permit to update item of Elastic Endpoint exception list
+type KibanaExceptionListUpdateEndpointItem func(item *ExceptionListItem) (*ExceptionListItem, error)
+
+// KibanaExceptionListDeleteEndpointItem permit to delete item of Elastic Endpoint exception list with it item ID
+type KibanaExceptionListDeleteEndpointItem func(itemID string) error
+
+// KibanaExceptionListFindEndpointItems permit to find items of Elastic Endpoint exception list
+type KibanaExceptionListFindEndpointItems func(parameters *ExceptionListFindParameters) (*ExceptionListItemFindResponse, error)
+
+// String permit to return ExceptionList object as JSON string
+func (o *ExceptionList) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// String permit to return ExceptionListItem object as JSON string
+func (o *ExceptionListItem) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// toRequest return the exception list fields that can be written
+func (o *ExceptionList) toRequest() *exceptionListRequest {
+	return &exceptionListRequest{
+		ID:            o.ID,
+		ListID:        o.ListID,
+		Name:          o.Name,
+		Description:   o.Description,
+		Type:          o.Type,
+		NamespaceType: o.NamespaceType,
+		Tags:          o.Tags,
+		OSTypes:       o.OSTypes,
+		Meta:          o.Meta,
+		Version:       o.Version,
+	}
+}
+
+// toRequest return the exception list item fields that can be written
+func (o *ExceptionListItem) toRequest() *exceptionListItemRequest {
+	request := &exceptionListItemRequest{
+		ID:            o.ID,
+		ItemID:        o.ItemID,
+		ListID:        o.ListID,
+		Name:          o.Name,
+		Description:   o.Description,
+		Type:          o.Type,
+		NamespaceType: o.NamespaceType,
+		Entries:       o.Entries,
+		OSTypes:       o.OSTypes,
+		Tags:          o.Tags,
+		ExpireTime:    o.ExpireTime,
+		Meta:          o.Meta,
+	}
+	if request.Type == "" {
+		request.Type = "simple"
+	}
+	for _, comment := range o.Comments {
+		request.Comments = append(request.Comments, exceptionListItemCommentRequest{
+			ID:      comment.ID,
+			Comment: comment.Comment,
+		})
+	}
+
+	return request
+}
+
+// MarshalJSON permit to write only the fields expected by the entry type
+func (o ExceptionListItemEntry) MarshalJSON() ([]byte, error) {
+	entry := map[string]interface{}{
+		"field": o.Field,
+		"type":  o.Type,
+	}
+	if o.Type != ExceptionEntryTypeNested {
+		entry["operator"] = o.Operator
+		if o.Operator == "" {
+			entry["operator"] = ExceptionEntryOperatorIncluded
+		}
+	}
+
+	switch o.Type {
+	case ExceptionEntryTypeMatch, ExceptionEntryTypeWildcard:
+		entry["value"] = o.Value
+	case ExceptionEntryTypeMatchAny:
+		entry["value"] = o.Values
+	case ExceptionEntryTypeList:
+		if o.List == nil {
+			return nil, NewAPIError(600, "You must provide list on '%s' entry of field %s", o.Type, o.Field)
+		}
+		entry["list"] = o.List
+	case ExceptionEntryTypeExists:
+	case ExceptionEntryTypeNested:
+		entry["entries"] = o.Entries
+	default:
+		return nil, NewAPIError(600, "Entry type '%s' of field %s is not supported", o.Type, o.Field)
+	}
+
+	return json.Marshal(entry)
+}
+
+// UnmarshalJSON permit to read the entry value according to the entry type
+func (o *ExceptionListItemEntry) UnmarshalJSON(data []byte) error {
+	entry := struct {
+		Field    string                      `json:"field"`
+		Type     string                      `json:"type"`
+		Operator string                      `json:"operator"`
+		Value    json.RawMessage             `json:"value"`
+		List     *ExceptionListItemEntryList `json:"list"`
+		Entries  []ExceptionListItemEntry    `json:"entries"`
+	}{}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+
+	*o = ExceptionListItemEntry{
+		Field:    entry.Field,
+		Type:     entry.Type,
+		Operator: entry.Operator,
+		List:     entry.List,
+		Entries:  entry.Entries,
+	}
+	if len(entry.Value) > 0 {
+		if entry.Type == ExceptionEntryTypeMatchAny {
+			return json.Unmarshal(entry.Value, &o.Values)
+		}
+		return json.Unmarshal(entry.Value, &o.Value)
+	}
+
+	return nil
+}
+
+// toQueryParams return the find parameters as expected by exception lists API
+func (o *ExceptionListFindParameters) toQueryParams() map[string]string {
+	queryParams := map[string]string{}
+	if o == nil {
+		return queryParams
+	}
+	if o.Filter != "" {
+		queryParams["filter"] = o.Filter
+	}
+	if len(o.NamespaceType) > 0 {
+		queryParams["namespace_type"] = strings.Join(o.NamespaceType, ",")
+	}
+	if o.Page != 0 {
+		queryParams["page"] = strconv.Itoa(o.Page)
+	}
+	if o.PerPage != 0 {
+		queryParams["per_page"] = strconv.Itoa(o.PerPage)
+	}
+	if o.SortField != "" {
+		queryParams["sort_field"] = o.SortField
+	}
+	if o.SortOrder != "" {
+		queryParams["sort_order"] = o.SortOrder
+	}
+
+	return queryParams
+}
+
+// newKibanaExceptionListCreateFunc permit to create exception list
+func newKibanaExceptionListCreateFunc(c *resty.Client) KibanaExceptionListCreate {
+	return func(list *ExceptionList, kibanaSpace string) (*ExceptionList, error) {
+
+		if list == nil {
+			return nil, NewAPIError(600, "You must provide exception list object")
+		}
+		if list.Name == "" || list.Type == "" {
+			return nil, NewAPIError(600, "You must provide exception list name and type")
+		}
+		log.Debug("ExceptionList: ", list)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := list.toRequest()
+		request.ID = ""
+		result := &ExceptionList{}
+		path := spacePath(kibanaSpace, basePathKibanaExceptionList)
+		err := sendListsObject(c, http.MethodPost, path, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ExceptionList: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaExceptionListGetFunc permit to get exception list with it list ID
+func newKibanaExceptionListGetFunc(c *resty.Client) KibanaExceptionListGet {
+	return func(listID string, namespaceType string, kibanaSpace string) (*ExceptionList, error) {
+
+		if listID == "" {
+			return nil, NewAPIError(600, "You must provide exception list ID")
+		}
+		log.Debug("ListID: ", listID)
+		log.Debug("NamespaceType: ", namespaceType)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		list := &ExceptionList{}
+		path := spacePath(kibanaSpace, basePathKibanaExceptionList)
+		found, err := getListsObject(c, path, listsQueryParams("list_id", listID, namespaceType), list)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("ExceptionList: ", list)
+
+		return list, nil
+	}
+}
+
+// newKibanaExceptionListUpdateFunc permit to update exception list
+func newKibanaExceptionListUpdateFunc(c *resty.Client) KibanaExceptionListUpdate {
+	return func(list *ExceptionList, kibanaSpace string) (*ExceptionList, error) {
+
+		if list == nil {
+			return nil, NewAPIError(600, "You must provide exception list object")
+		}
+		if list.ID == "" && list.ListID == "" {
+			return nil, NewAPIError(600, "You must provide exception list ID")
+		}
+		log.Debug("ExceptionList: ", list)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		result := &ExceptionList{}
+		path := spacePath(kibanaSpace, basePathKibanaExceptionList)
+		err := sendListsObject(c, http.MethodPut, path, list.toRequest(), result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ExceptionList: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaExceptionListDeleteFunc permit to delete exception list with it list ID
+func newKibanaExceptionListDeleteFunc(c *resty.Client) KibanaExceptionListDelete {
+	return func(listID string, namespaceType string, kibanaSpace string) error {
+
+		if listID == "" {
+			return NewAPIError(600, "You must provide exception list ID")
+		}
+		log.Debug("ListID: ", listID)
+		log.Debug("NamespaceType: ", namespaceType)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, basePathKibanaExceptionList)
+		return deleteListsObject(c, path, listsQueryParams("list_id", listID, namespaceType))
+	}
+}
+
+// newKibanaExceptionListFindFunc permit to find exception lists
+func newKibanaExceptionListFindFunc(c *resty.Client) KibanaExceptionListFind {
+	return func(parameters *ExceptionListFindParameters, kibanaSpace string) (*ExceptionListFindResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		exceptionListFindResponse := &ExceptionListFindResponse{}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_find", basePathKibanaExceptionList))
+		err := findListsObjects(c, path, parameters.toQueryParams(), exceptionListFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ExceptionLists: ", exceptionListFindResponse.Data)
+
+		return exceptionListFindResponse, nil
+	}
+}
+
+// newKibanaExceptionListExportFunc permit to export exception list
+func newKibanaExceptionListExportFunc(c *resty.Client) KibanaExceptionListExport {
+	return func(list *ExceptionList, kibanaSpace string) ([]byte, error) {
+
+		if list == nil || list.ID == "" || list.ListID == "" {
+			return nil, NewAPIError(600, "You must provide exception list with ID and list ID")
+		}
+		log.Debug("ExceptionList: ", list)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := listsQueryParams("list_id", list.ListID, list.NamespaceType)
+		queryParams["id"] = list.ID
+		if queryParams["namespace_type"] == "" {
+			queryParams["namespace_type"] = ExceptionListNamespaceSingle
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_export", basePathKibanaExceptionList))
+		resp, err := c.R().SetQueryParams(queryParams).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return resp.Body(), nil
+	}
+}
+
+// newKibanaExceptionListImportFunc permit to import exception lists
+func newKibanaExceptionListImportFunc(c *resty.Client) KibanaExceptionListImport {
+	return func(data []byte, overwrite bool, kibanaSpace string) (*ExceptionListImportResponse, error) {
+
+		if len(data) == 0 {
+			return nil, NewAPIError(600, "You must provide data parameters")
+		}
+		log.Debug("Overwrite: ", overwrite)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_import", basePathKibanaExceptionList))
+		resp, err := c.R().
+			SetQueryString(fmt.Sprintf("overwrite=%t", overwrite)).
+			SetFileReader("file", "exceptions.ndjson", bytes.NewReader(data)).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		exceptionListImportResponse := &ExceptionListImportResponse{}
+		err = json.Unmarshal(resp.Body(), exceptionListImportResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ImportResponse: ", exceptionListImportResponse)
+
+		return exceptionListImportResponse, nil
+	}
+}
+
+// newKibanaExceptionListCreateItemFunc permit to create exception list item
+func newKibanaExceptionListCreateItemFunc(c *resty.Client) KibanaExceptionListCreateItem {
+	return func(item *ExceptionListItem, kibanaSpace string) (*ExceptionListItem, error) {
+
+		if item == nil {
+			return nil, NewAPIError(600, "You must provide exception list item object")
+		}
+		if item.ListID == "" {
+			return nil, NewAPIError(600, "You must provide exception list ID of item")
+		}
+		log.Debug("ExceptionListItem: ", item)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request := item.toRequest()
+		request.ID = ""
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items", basePathKibanaExceptionList))
+		return sendExceptionListItem(c, http.MethodPost, path, request)
+	}
+}
+
+// newKibanaExceptionListGetItemFunc permit to get exception list item with it item ID
+func newKibanaExceptionListGetItemFunc(c *resty.Client) KibanaExceptionListGetItem {
+	return func(itemID string, namespaceType string, kibanaSpace string) (*ExceptionListItem, error) {
+
+		if itemID == "" {
+			return nil, NewAPIError(600, "You must provide exception list item ID")
+		}
+		log.Debug("ItemID: ", itemID)
+		log.Debug("NamespaceType: ", namespaceType)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items", basePathKibanaExceptionList))
+		return getExceptionListItem(c, path, listsQueryParams("item_id", itemID, namespaceType))
+	}
+}
+
+// newKibanaExceptionListUpdateItemFunc permit to update exception list item
+func newKibanaExceptionListUpdateItemFunc(c *resty.Client) KibanaExceptionListUpdateItem {
+	return func(item *ExceptionListItem, kibanaSpace string) (*ExceptionListItem, error) {
+
+		if item == nil {
+			return nil, NewAPIError(600, "You must provide exception list item object")
+		}
+		if item.ID == "" && item.ItemID == "" {
+			return nil, NewAPIError(600, "You must provide exception list item ID")
+		}
+		log.Debug("ExceptionListItem: ", item)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// The list of item can't be changed
+		request := item.toRequest()
+		request.ListID = ""
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items", basePathKibanaExceptionList))
+		return sendExceptionListItem(c, http.MethodPut, path, request)
+	}
+}
+
+// newKibanaExceptionListDeleteItemFunc permit to delete exception list item with it item ID
+func newKibanaExceptionListDeleteItemFunc(c *resty.Client) KibanaExceptionListDeleteItem {
+	return func(itemID string, namespaceType string, kibanaSpace string) error {
+
+		if itemID == "" {
+			return NewAPIError(600, "You must provide exception list item ID")
+		}
+		log.Debug("ItemID: ", itemID)
+		log.Debug("NamespaceType: ", namespaceType)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items", basePathKibanaExceptionList))
+		return deleteListsObject(c, path, listsQueryParams("item_id", itemID, namespaceType))
+	}
+}
+
+// newKibanaExceptionListFindItemsFunc permit to find items of exception list
+func newKibanaExceptionListFindItemsFunc(c *resty.Client) KibanaExceptionListFindItems {
+	return func(listID string, parameters *ExceptionListFindParameters, kibanaSpace string) (*ExceptionListItemFindResponse, error) {
+
+		if listID == "" {
+			return nil, NewAPIError(600, "You must provide exception list ID")
+		}
+		log.Debug("ListID: ", listID)
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := parameters.toQueryParams()
+		queryParams["list_id"] = listID
+		exceptionListItemFindResponse := &ExceptionListItemFindResponse{}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items/_find", basePathKibanaExceptionList))
+		err := findListsObjects(c, path, queryParams, exceptionListItemFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ExceptionListItems: ", exceptionListItemFindResponse.Data)
+
+		return exceptionListItemFindResponse, nil
+	}
+}
+
+// newKibanaExceptionListCreateEndpointListFunc permit to create the Elastic Endpoint exception list
+func newKibanaExceptionListCreateEndpointListFunc(c *resty.Client) KibanaExceptionListCreateEndpointList {
+	return func() (*ExceptionList, error) {
+
+		list := &ExceptionList{}
+		err := sendListsObject(c, http.MethodPost, basePathKibanaEndpointList, nil, list)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ExceptionList: ", list)
+
+		return list, nil
+	}
+}
+
+// newKibanaExceptionListCreateEndpointItemFunc permit to create item on Elastic Endpoint exception list
+func newKibanaExceptionListCreateEndpointItemFunc(c *resty.Client) KibanaExceptionListCreateEndpointItem {
+	return func(item *ExceptionListItem) (*ExceptionListItem, error) {
+
+		if item == nil {
+			return nil, NewAPIError(600, "You must provide exception list item object")
+		}
+		log.Debug("ExceptionListItem: ", item)
+
+		// Endpoint list is always agnostic
+		request := item.toRequest()
+		request.ID = ""
+		request.ListID = ""
+		request.NamespaceType = ""
+		path := fmt.Sprintf("%s/items", basePathKibanaEndpointList)
+		return sendExceptionListItem(c, http.MethodPost, path, request)
+	}
+}
+
+// newKibanaExceptionListGetEndpointItemFunc permit to get item of Elastic Endpoint exception list
+func newKibanaExceptionListGetEndpointItemFunc(c *resty.Client) KibanaExceptionListGetEndpointItem {
+	return func(itemID string) (*ExceptionListItem, error) {
+
+		if itemID == "" {
+			return nil, NewAPIError(600, "You must provide exception list item ID")
+		}
+		log.Debug("ItemID: ", itemID)
+
+		path := fmt.Sprintf("%s/items", basePathKibanaEndpointList)
+		return getExceptionListItem(c, path, listsQueryParams("item_id", itemID, ""))
+	}
+}
+
+// newKibanaExceptionListUpdateEndpointItemFunc permit to update item of Elastic Endpoint exception list
+func newKibanaExceptionListUpdateEndpointItemFunc(c *resty.Client) KibanaExceptionListUpdateEndpointItem {
+	return func(item *ExceptionListItem) (*ExceptionListItem, error) {
+
+		if item == nil {
+			return nil, NewAPIError(600, "You must provide exception list item object")
+		}
+		if item.ID == "" && item.ItemID == "" {
+			return nil, NewAPIError(600, "You must provide exception list item ID")
+		}
+		log.Debug("ExceptionListItem: ", item)
+
+		request := item.toRequest()
+		request.ListID = ""
+		request.NamespaceType = ""
+		path := fmt.Sprintf("%s/items", basePathKibanaEndpointList)
+		return sendExceptionListItem(c, http.MethodPut, path, request)
+	}
+}
+
+// newKibanaExceptionListDeleteEndpointItemFunc permit to delete item of Elastic Endpoint exception list
+func newKibanaExceptionListDeleteEndpointItemFunc(c *resty.Client) KibanaExceptionListDeleteEndpointItem {
+	return func(itemID string) error {
+
+		if itemID == "" {
+			return NewAPIError(600, "You must provide exception list item ID")
+		}
+		log.Debug("ItemID: ", itemID)
+
+		path := fmt.Sprintf("%s/items", basePathKibanaEndpointList)
+		return deleteListsObject(c, path, listsQueryParams("item_id", itemID, ""))
+	}
+}
+
+// newKibanaExceptionListFindEndpointItemsFunc permit to find items of Elastic Endpoint exception list
+func newKibanaExceptionListFindEndpointItemsFunc(c *resty.Client) KibanaExceptionListFindEndpointItems {
+	return func(parameters *ExceptionListFindParameters) (*ExceptionListItemFindResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+
+		queryParams := parameters.toQueryParams()
+		delete(queryParams, "namespace_type")
+		exceptionListItemFindResponse := &ExceptionListItemFindResponse{}
+		path := fmt.Sprintf("%s/items/_find", basePathKibanaEndpointList)
+		err := findListsObjects(c, path, queryParams, exceptionListItemFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ExceptionListItems: ", exceptionListItemFindResponse.Data)
+
+		return exceptionListItemFindResponse, nil
+	}
+}
+
+// getExceptionListItem permit to get exception list item. It return nil if the item not exist.
+func getExceptionListItem(c *resty.Client, path string, queryParams map[string]string) (*ExceptionListItem, error) {
+	item := &ExceptionListItem{}
+	found, err := getListsObject(c, path, queryParams, item)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	log.Debug("ExceptionListItem: ", item)
+
+	return item, nil
+}
+
+// sendExceptionListItem permit to create or update exception list item
+func sendExceptionListItem(c *resty.Client, method string, path string, request *exceptionListItemRequest) (*ExceptionListItem, error) {
+	item := &ExceptionListItem{}
+	err := sendListsObject(c, method, path, request, item)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("ExceptionListItem: ", item)
+
+	return item, nil
+}
+
+// listsQueryParams return the query parameters to identify object of lists API
+func listsQueryParams(key string, value string, namespaceType string) map[string]string {
+	queryParams := map[string]string{
+		key: value,
+	}
+	if namespaceType != "" {
+		queryParams["namespace_type"] = namespaceType
+	}
+
+	return queryParams
+}
+
+// getListsObject permit to read the object returned by lists API. It return false if the object not exist.
+func getListsObject(c *resty.Client, path string, queryParams map[string]string, object interface{}) (bool, error) {
+	resp, err := c.R().SetQueryParams(queryParams).Get(path)
+	if err != nil {
+		return false, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return false, nil
+		}
+		return false, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return true, json.Unmarshal(resp.Body(), object)
+}
+
+// sendListsObject permit to create or update object with lists API and read the object returned
+func sendListsObject(c *resty.Client, method string, path string, payload interface{}, object interface{}) error {
+	request := c.R()
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		request = request.SetBody(jsonData)
+	}
+	resp, err := request.Execute(method, path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return json.Unmarshal(resp.Body(), object)
+}
+
+// deleteListsObject permit to delete object with lists API
+func deleteListsObject(c *resty.Client, path string, queryParams map[string]string) error {
+	resp, err := c.R().SetQueryParams(queryParams).Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// findListsObjects permit to read the page of objects returned by lists API
+func findListsObjects(c *resty.Client, path string, queryParams map[string]string, response interface{}) error {
+	resp, err := c.R().SetQueryParams(queryParams).Get(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return json.Unmarshal(resp.Body(), response)
+}
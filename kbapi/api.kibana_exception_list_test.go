@@ -0,0 +1,164 @@
+package kbapi
+
+import (
+	"encoding/json"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaExceptionLists() {
+
+	// Marshal entries according to their type
+	data, err := json.Marshal([]ExceptionListItemEntry{
+		{
+			Field:  "host.name",
+			Type:   ExceptionEntryTypeMatchAny,
+			Values: []string{"host1", "host2"},
+		},
+		{
+			Field: "process.parent",
+			Type:  ExceptionEntryTypeNested,
+			Entries: []ExceptionListItemEntry{
+				{
+					Field:    "name",
+					Type:     ExceptionEntryTypeExists,
+					Operator: ExceptionEntryOperatorExcluded,
+				},
+			},
+		},
+	})
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `[{"field":"host.name","type":"match_any","operator":"included","value":["host1","host2"]},{"field":"process.parent","type":"nested","entries":[{"field":"name","type":"exists","operator":"excluded"}]}]`, string(data))
+	entries := []ExceptionListItemEntry{}
+	err = json.Unmarshal(data, &entries)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"host1", "host2"}, entries[0].Values)
+	assert.Equal(s.T(), ExceptionEntryTypeExists, entries[1].Entries[0].Type)
+	_, err = json.Marshal(ExceptionListItemEntry{Field: "host.name", Type: ExceptionEntryTypeList})
+	assert.Error(s.T(), err)
+
+	// Create new exception list
+	list, err := s.API.KibanaExceptionLists.Create(&ExceptionList{
+		ListID:        "test-exception-list",
+		Name:          "test",
+		Description:   "test exception list",
+		Type:          ExceptionListTypeDetection,
+		NamespaceType: ExceptionListNamespaceSingle,
+		Tags:          []string{"test"},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), list)
+	assert.NotEmpty(s.T(), list.ID)
+
+	// Get exception list
+	list, err = s.API.KibanaExceptionLists.Get("test-exception-list", ExceptionListNamespaceSingle, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), list)
+	assert.Equal(s.T(), "test", list.Name)
+
+	// Update exception list
+	list.Description = "test exception list 2"
+	list, err = s.API.KibanaExceptionLists.Update(list, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test exception list 2", list.Description)
+
+	// Find exception lists
+	exceptionListFindResponse, err := s.API.KibanaExceptionLists.Find(&ExceptionListFindParameters{
+		Filter: "exception-list.attributes.name:test",
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), exceptionListFindResponse.Data)
+
+	// Create exception list item
+	item, err := s.API.KibanaExceptionLists.CreateItem(&ExceptionListItem{
+		ItemID:      "test-exception-item",
+		ListID:      "test-exception-list",
+		Name:        "test",
+		Description: "test exception item",
+		Entries: []ExceptionListItemEntry{
+			{
+				Field: "host.name",
+				Type:  ExceptionEntryTypeMatch,
+				Value: "host1",
+			},
+		},
+		Comments: []ExceptionListItemComment{
+			{
+				Comment: "test comment",
+			},
+		},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), item)
+	assert.Equal(s.T(), "host1", item.Entries[0].Value)
+
+	// Get exception list item
+	item, err = s.API.KibanaExceptionLists.GetItem("test-exception-item", ExceptionListNamespaceSingle, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), item)
+	assert.Len(s.T(), item.Comments, 1)
+
+	// Update exception list item
+	item.Entries = append(item.Entries, ExceptionListItemEntry{
+		Field:  "user.name",
+		Type:   ExceptionEntryTypeMatchAny,
+		Values: []string{"root", "admin"},
+	})
+	item, err = s.API.KibanaExceptionLists.UpdateItem(item, "default")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), item.Entries, 2)
+
+	// Find exception list items
+	exceptionListItemFindResponse, err := s.API.KibanaExceptionLists.FindItems("test-exception-list", nil, "default")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), exceptionListItemFindResponse.Data, 1)
+
+	// Export and import exception list
+	data, err = s.API.KibanaExceptionLists.Export(list, "default")
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), string(data), "test-exception-item")
+	exceptionListImportResponse, err := s.API.KibanaExceptionLists.Import(data, true, "default")
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), exceptionListImportResponse.Success)
+
+	// Delete exception list item
+	err = s.API.KibanaExceptionLists.DeleteItem("test-exception-item", ExceptionListNamespaceSingle, "default")
+	assert.NoError(s.T(), err)
+	item, err = s.API.KibanaExceptionLists.GetItem("test-exception-item", ExceptionListNamespaceSingle, "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), item)
+
+	// Delete exception list
+	err = s.API.KibanaExceptionLists.Delete("test-exception-list", ExceptionListNamespaceSingle, "default")
+	assert.NoError(s.T(), err)
+	list, err = s.API.KibanaExceptionLists.Get("test-exception-list", ExceptionListNamespaceSingle, "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), list)
+
+	// Handle endpoint exception list
+	_, err = s.API.KibanaExceptionLists.CreateEndpointList()
+	assert.NoError(s.T(), err)
+	item, err = s.API.KibanaExceptionLists.CreateEndpointItem(&ExceptionListItem{
+		ItemID:      "test-endpoint-item",
+		Name:        "test",
+		Description: "test endpoint item",
+		OSTypes:     []string{"linux"},
+		Entries: []ExceptionListItemEntry{
+			{
+				Field: "process.executable.caseless",
+				Type:  ExceptionEntryTypeWildcard,
+				Value: "/opt/test/*",
+			},
+		},
+	})
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), item)
+	item, err = s.API.KibanaExceptionLists.GetEndpointItem("test-endpoint-item")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), item)
+	exceptionListItemFindResponse, err = s.API.KibanaExceptionLists.FindEndpointItems(nil)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), exceptionListItemFindResponse.Data)
+	err = s.API.KibanaExceptionLists.DeleteEndpointItem("test-endpoint-item")
+	assert.NoError(s.T(), err)
+}
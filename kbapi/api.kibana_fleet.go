@@ -0,0 +1,173 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaFleet = "/api/fleet" // Base URL to access on Kibana fleet API
+)
+
+// FleetListParameters contain optional parameters to list fleet objects
+type FleetListParameters struct {
+	Page      int
+	PerPage   int
+	Kuery     string
+	SortField string
+	SortOrder string
+}
+
+// FleetPage is the pagination returned when list fleet objects
+type FleetPage struct {
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+	Total   int `json:"total"`
+}
+
+// HasNextPage return true if there are objects after this page
+func (o *FleetPage) HasNextPage() bool {
+	return o.Page*o.PerPage < o.Total
+}
+
+// NextPage return copy of list parameters to get the next page
+func (o *FleetListParameters) NextPage() *FleetListParameters {
+	parameters := &FleetListParameters{}
+	if o != nil {
+		*parameters = *o
+	}
+	if parameters.Page == 0 {
+		parameters.Page = 1
+	}
+	parameters.Page++
+
+	return parameters
+}
+
+// toQueryParams return the list parameters as query parameters
+func (o *FleetListParameters) toQueryParams() map[string]string {
+	queryParams := map[string]string{}
+	if o == nil {
+		return queryParams
+	}
+	if o.Page != 0 {
+		queryParams["page"] = strconv.Itoa(o.Page)
+	}
+	if o.PerPage != 0 {
+		queryParams["perPage"] = strconv.Itoa(o.PerPage)
+	}
+	if o.Kuery != "" {
+		queryParams["kuery"] = o.Kuery
+	}
+	if o.SortField != "" {
+		queryParams["sortField"] = o.SortField
+	}
+	if o.SortOrder != "" {
+		queryParams["sortOrder"] = o.SortOrder
+	}
+
+	return queryParams
+}
+
+// newFleetRequest return request to call Kibana fleet API
+func newFleetRequest(c *resty.Client) *resty.Request {
+	return c.R().SetHeader(HeaderElasticAPIVersion, ElasticAPIVersionPublic)
+}
+
+// getFleetItem permit to read the item returned by fleet API. It return false if the item not exist.
+func getFleetItem(c *resty.Client, path string, queryParams map[string]string, item interface{}) (bool, error) {
+	resp, err := newFleetRequest(c).SetQueryParams(queryParams).Get(path)
+	if err != nil {
+		return false, err
+	}
+	log.Debug("Response status: ", resp.StatusCode())
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return false, nil
+		}
+		return false, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return true, unmarshalFleetItem(resp.Body(), item)
+}
+
+// sendFleetItem permit to create or update item with fleet API and read the item returned
+func sendFleetItem(c *resty.Client, method string, path string, queryParams map[string]string, payload interface{}, item interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := newFleetRequest(c).SetQueryParams(queryParams).SetBody(jsonData).Execute(method, path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response status: ", resp.StatusCode())
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return unmarshalFleetItem(resp.Body(), item)
+}
+
+// deleteFleetItem permit to delete item with fleet API
+func deleteFleetItem(c *resty.Client, path string) error {
+	resp, err := newFleetRequest(c).Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response status: ", resp.StatusCode())
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// listFleetItems permit to read the page of items returned by fleet API
+func listFleetItems(c *resty.Client, path string, queryParams map[string]string, response interface{}) error {
+	resp, err := newFleetRequest(c).SetQueryParams(queryParams).Get(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response status: ", resp.StatusCode())
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return json.Unmarshal(resp.Body(), response)
+}
+
+// postFleetAction permit to call action with fleet API and read the response. Response can be nil when not needed.
+func postFleetAction(c *resty.Client, path string, payload interface{}, response interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := newFleetRequest(c).SetBody(jsonData).Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response status: ", resp.StatusCode())
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	if response != nil {
+		return json.Unmarshal(resp.Body(), response)
+	}
+
+	return nil
+}
+
+// unmarshalFleetItem permit to read the item field from response body
+func unmarshalFleetItem(data []byte, item interface{}) error {
+	response := struct {
+		Item interface{} `json:"item"`
+	}{
+		Item: item,
+	}
+
+	return json.Unmarshal(data, &response)
+}
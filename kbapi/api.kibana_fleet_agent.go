@@ -0,0 +1,315 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Agent is the Elastic Agent enrolled on fleet
+type Agent struct {
+	ID                    string                   `json:"id"`
+	Type                  string                   `json:"type,omitempty"`
+	Active                bool                     `json:"active"`
+	Status                string                   `json:"status,omitempty"`
+	PolicyID              string                   `json:"policy_id,omitempty"`
+	PolicyRevision        int                      `json:"policy_revision,omitempty"`
+	Tags                  []string                 `json:"tags,omitempty"`
+	EnrolledAt            string                   `json:"enrolled_at,omitempty"`
+	LastCheckin           string                   `json:"last_checkin,omitempty"`
+	LastCheckinStatus     string                   `json:"last_checkin_status,omitempty"`
+	UnenrolledAt          string                   `json:"unenrolled_at,omitempty"`
+	UnenrollmentStartedAt string                   `json:"unenrollment_started_at,omitempty"`
+	UpgradedAt            string                   `json:"upgraded_at,omitempty"`
+	UpgradeStartedAt      string                   `json:"upgrade_started_at,omitempty"`
+	LocalMetadata         map[string]interface{}   `json:"local_metadata,omitempty"`
+	UserProvidedMetadata  map[string]interface{}   `json:"user_provided_metadata,omitempty"`
+	Components            []map[string]interface{} `json:"components,omitempty"`
+}
+
+// Agents is list of Agent object
+type Agents []Agent
+
+// AgentListParameters contain optional parameters to list agents. Kuery is KQL filter on agents.
+type AgentListParameters struct {
+	FleetListParameters
+	ShowInactive    bool
+	ShowUpgradeable bool
+}
+
+// AgentListResponse is the result when list agents
+type AgentListResponse struct {
+	FleetPage
+	Items Agents `json:"items"`
+}
+
+// AgentSelection is the agents selected by bulk action, with their IDs or with KQL filter
+type AgentSelection struct {
+	IDs   []string
+	Kuery string
+}
+
+// AgentUpgradeParameters contain the parameters to upgrade agents
+type AgentUpgradeParameters struct {
+	Version                string
+	SourceURI              string
+	Force                  bool
+	RolloutDurationSeconds int
+	StartTime              string
+}
+
+// AgentBulkActionResponse is the result of bulk action on agents
+type AgentBulkActionResponse struct {
+	ActionID string `json:"actionId,omitempty"`
+}
+
+// KibanaFleetAgentList permit to list agents
+type KibanaFleetAgentList func(parameters *AgentListParameters) (*AgentListResponse, error)
+
+// KibanaFleetAgentGet permit to get agent
+type KibanaFleetAgentGet func(id string) (*Agent, error)
+
+// KibanaFleetAgentReassign permit to reassign agent on other agent policy
+type KibanaFleetAgentReassign func(id string, policyID string) error
+
+// KibanaFleetAgentUnenroll permit to unenroll agent. When revoke is true, the agent API key is revoked immediately.
+type KibanaFleetAgentUnenroll func(id string, revoke bool) error
+
+// KibanaFleetAgentUpgrade permit to upgrade agent
+type KibanaFleetAgentUpgrade func(id string, parameters *AgentUpgradeParameters) error
+
+// KibanaFleetAgentBulkReassign permit to reassign agents on other agent policy
+type KibanaFleetAgentBulkReassign func(agents *AgentSelection, policyID string) (*AgentBulkActionResponse, error)
+
+// KibanaFleetAgentBulkUnenroll permit to unenroll agents
+type KibanaFleetAgentBulkUnenroll func(agents *AgentSelection, revoke bool) (*AgentBulkActionResponse, error)
+
+// KibanaFleetAgentBulkUpgrade permit to upgrade agents
+type KibanaFleetAgentBulkUpgrade func(agents *AgentSelection, parameters *AgentUpgradeParameters) (*AgentBulkActionResponse, error)
+
+// String permit to return Agent object as JSON string
+func (o *Agent) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// value return the agents selection as expected by fleet API, list of IDs or KQL filter
+func (o *AgentSelection) value() (interface{}, error) {
+	if o == nil || (len(o.IDs) == 0 && o.Kuery == "") {
+		return nil, NewAPIError(600, "You must provide agent IDs or kuery")
+	}
+	if len(o.IDs) > 0 && o.Kuery != "" {
+		return nil, NewAPIError(600, "You can't provide agent IDs and kuery together")
+	}
+	if o.Kuery != "" {
+		return o.Kuery, nil
+	}
+
+	return o.IDs, nil
+}
+
+// newKibanaFleetAgentListFunc permit to list agents
+func newKibanaFleetAgentListFunc(c *resty.Client) KibanaFleetAgentList {
+	return func(parameters *AgentListParameters) (*AgentListResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+
+		queryParams := map[string]string{}
+		if parameters != nil {
+			queryParams = parameters.FleetListParameters.toQueryParams()
+			if parameters.ShowInactive {
+				queryParams["showInactive"] = "true"
+			}
+			if parameters.ShowUpgradeable {
+				queryParams["showUpgradeable"] = "true"
+			}
+		}
+
+		agentListResponse := &AgentListResponse{}
+		path := fmt.Sprintf("%s/agents", basePathKibanaFleet)
+		err := listFleetItems(c, path, queryParams, agentListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Agents: ", agentListResponse.Items)
+
+		return agentListResponse, nil
+	}
+}
+
+// newKibanaFleetAgentGetFunc permit to get agent with it ID
+func newKibanaFleetAgentGetFunc(c *resty.Client) KibanaFleetAgentGet {
+	return func(id string) (*Agent, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide agent ID")
+		}
+		log.Debug("ID: ", id)
+
+		agent := &Agent{}
+		path := fmt.Sprintf("%s/agents/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, agent)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("Agent: ", agent)
+
+		return agent, nil
+	}
+}
+
+// newKibanaFleetAgentReassignFunc permit to reassign agent
+func newKibanaFleetAgentReassignFunc(c *resty.Client) KibanaFleetAgentReassign {
+	return func(id string, policyID string) error {
+
+		if id == "" || policyID == "" {
+			return NewAPIError(600, "You must provide agent ID and agent policy ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("PolicyID: ", policyID)
+
+		return postAgentAction(c, fmt.Sprintf("%s/reassign", id), map[string]interface{}{
+			"policy_id": policyID,
+		}, nil)
+	}
+}
+
+// newKibanaFleetAgentUnenrollFunc permit to unenroll agent
+func newKibanaFleetAgentUnenrollFunc(c *resty.Client) KibanaFleetAgentUnenroll {
+	return func(id string, revoke bool) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide agent ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Revoke: ", revoke)
+
+		return postAgentAction(c, fmt.Sprintf("%s/unenroll", id), map[string]interface{}{
+			"revoke": revoke,
+		}, nil)
+	}
+}
+
+// newKibanaFleetAgentUpgradeFunc permit to upgrade agent
+func newKibanaFleetAgentUpgradeFunc(c *resty.Client) KibanaFleetAgentUpgrade {
+	return func(id string, parameters *AgentUpgradeParameters) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide agent ID")
+		}
+		if parameters == nil || parameters.Version == "" {
+			return NewAPIError(600, "You must provide the version to upgrade agent")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Parameters: ", parameters)
+
+		payload := map[string]interface{}{
+			"version": parameters.Version,
+			"force":   parameters.Force,
+		}
+		if parameters.SourceURI != "" {
+			payload["source_uri"] = parameters.SourceURI
+		}
+		return postAgentAction(c, fmt.Sprintf("%s/upgrade", id), payload, nil)
+	}
+}
+
+// newKibanaFleetAgentBulkReassignFunc permit to reassign agents
+func newKibanaFleetAgentBulkReassignFunc(c *resty.Client) KibanaFleetAgentBulkReassign {
+	return func(agents *AgentSelection, policyID string) (*AgentBulkActionResponse, error) {
+
+		selection, err := agents.value()
+		if err != nil {
+			return nil, err
+		}
+		if policyID == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("Agents: ", selection)
+		log.Debug("PolicyID: ", policyID)
+
+		agentBulkActionResponse := &AgentBulkActionResponse{}
+		err = postAgentAction(c, "bulk_reassign", map[string]interface{}{
+			"agents":    selection,
+			"policy_id": policyID,
+		}, agentBulkActionResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		return agentBulkActionResponse, nil
+	}
+}
+
+// newKibanaFleetAgentBulkUnenrollFunc permit to unenroll agents
+func newKibanaFleetAgentBulkUnenrollFunc(c *resty.Client) KibanaFleetAgentBulkUnenroll {
+	return func(agents *AgentSelection, revoke bool) (*AgentBulkActionResponse, error) {
+
+		selection, err := agents.value()
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Agents: ", selection)
+		log.Debug("Revoke: ", revoke)
+
+		agentBulkActionResponse := &AgentBulkActionResponse{}
+		err = postAgentAction(c, "bulk_unenroll", map[string]interface{}{
+			"agents": selection,
+			"revoke": revoke,
+		}, agentBulkActionResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		return agentBulkActionResponse, nil
+	}
+}
+
+// newKibanaFleetAgentBulkUpgradeFunc permit to upgrade agents
+func newKibanaFleetAgentBulkUpgradeFunc(c *resty.Client) KibanaFleetAgentBulkUpgrade {
+	return func(agents *AgentSelection, parameters *AgentUpgradeParameters) (*AgentBulkActionResponse, error) {
+
+		selection, err := agents.value()
+		if err != nil {
+			return nil, err
+		}
+		if parameters == nil || parameters.Version == "" {
+			return nil, NewAPIError(600, "You must provide the version to upgrade agents")
+		}
+		log.Debug("Agents: ", selection)
+		log.Debug("Parameters: ", parameters)
+
+		payload := map[string]interface{}{
+			"agents":  selection,
+			"version": parameters.Version,
+			"force":   parameters.Force,
+		}
+		if parameters.SourceURI != "" {
+			payload["source_uri"] = parameters.SourceURI
+		}
+		if parameters.RolloutDurationSeconds != 0 {
+			payload["rollout_duration_seconds"] = parameters.RolloutDurationSeconds
+		}
+		if parameters.StartTime != "" {
+			payload["start_time"] = parameters.StartTime
+		}
+		agentBulkActionResponse := &AgentBulkActionResponse{}
+		err = postAgentAction(c, "bulk_upgrade", payload, agentBulkActionResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		return agentBulkActionResponse, nil
+	}
+}
+
+// postAgentAction permit to call action on agents
+func postAgentAction(c *resty.Client, action string, payload map[string]interface{}, response interface{}) error {
+	path := fmt.Sprintf("%s/agents/%s", basePathKibanaFleet, action)
+	return postFleetAction(c, path, payload, response)
+}
@@ -0,0 +1,336 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// AgentPolicy is the fleet agent policy object
+type AgentPolicy struct {
+	ID                   string                 `json:"id,omitempty"`
+	Name                 string                 `json:"name"`
+	Namespace            string                 `json:"namespace"`
+	Description          string                 `json:"description,omitempty"`
+	MonitoringEnabled    []string               `json:"monitoring_enabled,omitempty"`
+	DataOutputID         string                 `json:"data_output_id,omitempty"`
+	MonitoringOutputID   string                 `json:"monitoring_output_id,omitempty"`
+	FleetServerHostID    string                 `json:"fleet_server_host_id,omitempty"`
+	DownloadSourceID     string                 `json:"download_source_id,omitempty"`
+	UnenrollTimeout      int                    `json:"unenroll_timeout,omitempty"`
+	InactivityTimeout    int                    `json:"inactivity_timeout,omitempty"`
+	IsProtected          bool                   `json:"is_protected,omitempty"`
+	AgentFeatures        []AgentPolicyFeature   `json:"agent_features,omitempty"`
+	Overrides            map[string]interface{} `json:"overrides,omitempty"`
+	HasFleetServer       bool                   `json:"has_fleet_server,omitempty"`
+	IsDefault            bool                   `json:"is_default,omitempty"`
+	IsDefaultFleetServer bool                   `json:"is_default_fleet_server,omitempty"`
+	IsManaged            bool                   `json:"is_managed,omitempty"`
+	Status               string                 `json:"status,omitempty"`
+	Revision             int                    `json:"revision,omitempty"`
+	Agents               int                    `json:"agents,omitempty"`
+	PackagePolicies      PackagePolicies        `json:"package_policies,omitempty"`
+	UpdatedAt            string                 `json:"updated_at,omitempty"`
+	UpdatedBy            string                 `json:"updated_by,omitempty"`
+}
+
+// AgentPolicies is list of AgentPolicy object
+type AgentPolicies []AgentPolicy
+
+// AgentPolicyFeature is the agent feature enabled or disabled by agent policy
+type AgentPolicyFeature struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// AgentPolicyListResponse is the result when list agent policies
+type AgentPolicyListResponse struct {
+	FleetPage
+	Items AgentPolicies `json:"items"`
+}
+
+// AgentPolicyListParameters contain optional parameters to list agent policies
+type AgentPolicyListParameters struct {
+	FleetListParameters
+	Full         bool
+	NoAgentCount bool
+}
+
+// agentPolicyRequest is the agent policy attributes that can be written
+type agentPolicyRequest struct {
+	ID                 string                 `json:"id,omitempty"`
+	Name               string                 `json:"name"`
+	Namespace          string                 `json:"namespace"`
+	Description        string                 `json:"description,omitempty"`
+	MonitoringEnabled  []string               `json:"monitoring_enabled,omitempty"`
+	DataOutputID       string                 `json:"data_output_id,omitempty"`
+	MonitoringOutputID string                 `json:"monitoring_output_id,omitempty"`
+	FleetServerHostID  string                 `json:"fleet_server_host_id,omitempty"`
+	DownloadSourceID   string                 `json:"download_source_id,omitempty"`
+	UnenrollTimeout    int                    `json:"unenroll_timeout,omitempty"`
+	InactivityTimeout  int                    `json:"inactivity_timeout,omitempty"`
+	IsProtected        bool                   `json:"is_protected,omitempty"`
+	AgentFeatures      []AgentPolicyFeature   `json:"agent_features,omitempty"`
+	Overrides          map[string]interface{} `json:"overrides,omitempty"`
+	HasFleetServer     bool                   `json:"has_fleet_server,omitempty"`
+}
+
+// KibanaFleetAgentPolicyList permit to list agent policies
+type KibanaFleetAgentPolicyList func(parameters *AgentPolicyListParameters) (*AgentPolicyListResponse, error)
+
+// KibanaFleetAgentPolicyGet permit to get agent policy
+type KibanaFleetAgentPolicyGet func(id string) (*AgentPolicy, error)
+
+// KibanaFleetAgentPolicyCreate permit to create agent policy.
+// When sysMonitoring is true, the system integration is added on agent policy.
+type KibanaFleetAgentPolicyCreate func(agentPolicy *AgentPolicy, sysMonitoring bool) (*AgentPolicy, error)
+
+// KibanaFleetAgentPolicyUpdate permit to update agent policy
+type KibanaFleetAgentPolicyUpdate func(agentPolicy *AgentPolicy) (*AgentPolicy, error)
+
+// KibanaFleetAgentPolicyDelete permit to delete agent policy
+type KibanaFleetAgentPolicyDelete func(id string) error
+
+// KibanaFleetAgentPolicyCopy permit to copy agent policy with it package policies
+type KibanaFleetAgentPolicyCopy func(id string, name string, description string) (*AgentPolicy, error)
+
+// KibanaFleetAgentPolicyGetFull permit to get the full agent policy, as sent to agents
+type KibanaFleetAgentPolicyGetFull func(id string, standalone bool) (map[string]interface{}, error)
+
+// KibanaFleetAgentPolicyDownload permit to download the full agent policy as YAML file
+type KibanaFleetAgentPolicyDownload func(id string, standalone bool) ([]byte, error)
+
+// String permit to return AgentPolicy object as JSON string
+func (o *AgentPolicy) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// toRequest return the agent policy attributes that can be written
+func (o *AgentPolicy) toRequest() *agentPolicyRequest {
+	return &agentPolicyRequest{
+		ID:                 o.ID,
+		Name:               o.Name,
+		Namespace:          o.Namespace,
+		Description:        o.Description,
+		MonitoringEnabled:  o.MonitoringEnabled,
+		DataOutputID:       o.DataOutputID,
+		MonitoringOutputID: o.MonitoringOutputID,
+		FleetServerHostID:  o.FleetServerHostID,
+		DownloadSourceID:   o.DownloadSourceID,
+		UnenrollTimeout:    o.UnenrollTimeout,
+		InactivityTimeout:  o.InactivityTimeout,
+		IsProtected:        o.IsProtected,
+		AgentFeatures:      o.AgentFeatures,
+		Overrides:          o.Overrides,
+		HasFleetServer:     o.HasFleetServer,
+	}
+}
+
+// newKibanaFleetAgentPolicyListFunc permit to list agent policies
+func newKibanaFleetAgentPolicyListFunc(c *resty.Client) KibanaFleetAgentPolicyList {
+	return func(parameters *AgentPolicyListParameters) (*AgentPolicyListResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+
+		queryParams := map[string]string{}
+		if parameters != nil {
+			queryParams = parameters.FleetListParameters.toQueryParams()
+			if parameters.Full {
+				queryParams["full"] = "true"
+			}
+			if parameters.NoAgentCount {
+				queryParams["noAgentCount"] = "true"
+			}
+		}
+
+		agentPolicyListResponse := &AgentPolicyListResponse{}
+		path := fmt.Sprintf("%s/agent_policies", basePathKibanaFleet)
+		err := listFleetItems(c, path, queryParams, agentPolicyListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("AgentPolicies: ", agentPolicyListResponse.Items)
+
+		return agentPolicyListResponse, nil
+	}
+}
+
+// newKibanaFleetAgentPolicyGetFunc permit to get agent policy with it ID
+func newKibanaFleetAgentPolicyGetFunc(c *resty.Client) KibanaFleetAgentPolicyGet {
+	return func(id string) (*AgentPolicy, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("ID: ", id)
+
+		agentPolicy := &AgentPolicy{}
+		path := fmt.Sprintf("%s/agent_policies/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, agentPolicy)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("AgentPolicy: ", agentPolicy)
+
+		return agentPolicy, nil
+	}
+}
+
+// newKibanaFleetAgentPolicyCreateFunc permit to create agent policy
+func newKibanaFleetAgentPolicyCreateFunc(c *resty.Client) KibanaFleetAgentPolicyCreate {
+	return func(agentPolicy *AgentPolicy, sysMonitoring bool) (*AgentPolicy, error) {
+
+		if agentPolicy == nil {
+			return nil, NewAPIError(600, "You must provide agent policy object")
+		}
+		if agentPolicy.Name == "" || agentPolicy.Namespace == "" {
+			return nil, NewAPIError(600, "You must provide agent policy name and namespace")
+		}
+		log.Debug("AgentPolicy: ", agentPolicy)
+		log.Debug("SysMonitoring: ", sysMonitoring)
+
+		result := &AgentPolicy{}
+		path := fmt.Sprintf("%s/agent_policies", basePathKibanaFleet)
+		queryParams := map[string]string{}
+		if sysMonitoring {
+			queryParams["sys_monitoring"] = "true"
+		}
+		err := sendFleetItem(c, http.MethodPost, path, queryParams, agentPolicy.toRequest(), result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("AgentPolicy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetAgentPolicyUpdateFunc permit to update agent policy
+func newKibanaFleetAgentPolicyUpdateFunc(c *resty.Client) KibanaFleetAgentPolicyUpdate {
+	return func(agentPolicy *AgentPolicy) (*AgentPolicy, error) {
+
+		if agentPolicy == nil {
+			return nil, NewAPIError(600, "You must provide agent policy object")
+		}
+		if agentPolicy.ID == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("AgentPolicy: ", agentPolicy)
+
+		request := agentPolicy.toRequest()
+		request.ID = ""
+		result := &AgentPolicy{}
+		path := fmt.Sprintf("%s/agent_policies/%s", basePathKibanaFleet, agentPolicy.ID)
+		err := sendFleetItem(c, http.MethodPut, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("AgentPolicy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetAgentPolicyDeleteFunc permit to delete agent policy with it ID
+func newKibanaFleetAgentPolicyDeleteFunc(c *resty.Client) KibanaFleetAgentPolicyDelete {
+	return func(id string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("ID: ", id)
+
+		path := fmt.Sprintf("%s/agent_policies/delete", basePathKibanaFleet)
+		return postFleetAction(c, path, map[string]string{
+			"agentPolicyId": id,
+		}, nil)
+	}
+}
+
+// newKibanaFleetAgentPolicyCopyFunc permit to copy agent policy
+func newKibanaFleetAgentPolicyCopyFunc(c *resty.Client) KibanaFleetAgentPolicyCopy {
+	return func(id string, name string, description string) (*AgentPolicy, error) {
+
+		if id == "" || name == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID and the name of the copy")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Name: ", name)
+		log.Debug("Description: ", description)
+
+		payload := map[string]string{
+			"name": name,
+		}
+		if description != "" {
+			payload["description"] = description
+		}
+		result := &AgentPolicy{}
+		path := fmt.Sprintf("%s/agent_policies/%s/copy", basePathKibanaFleet, id)
+		err := sendFleetItem(c, http.MethodPost, path, nil, payload, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("AgentPolicy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetAgentPolicyGetFullFunc permit to get the full agent policy
+func newKibanaFleetAgentPolicyGetFullFunc(c *resty.Client) KibanaFleetAgentPolicyGetFull {
+	return func(id string, standalone bool) (map[string]interface{}, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Standalone: ", standalone)
+
+		fullAgentPolicy := map[string]interface{}{}
+		path := fmt.Sprintf("%s/agent_policies/%s/full", basePathKibanaFleet, id)
+		queryParams := map[string]string{
+			"standalone": fmt.Sprintf("%t", standalone),
+		}
+		found, err := getFleetItem(c, path, queryParams, &fullAgentPolicy)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, NewAPIError(404, "Agent policy %s not found", id)
+		}
+
+		return fullAgentPolicy, nil
+	}
+}
+
+// newKibanaFleetAgentPolicyDownloadFunc permit to download the full agent policy as YAML file
+func newKibanaFleetAgentPolicyDownloadFunc(c *resty.Client) KibanaFleetAgentPolicyDownload {
+	return func(id string, standalone bool) ([]byte, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("Standalone: ", standalone)
+
+		// The policy is YAML file, not fleet item
+		path := fmt.Sprintf("%s/agent_policies/%s/download", basePathKibanaFleet, id)
+		resp, err := newFleetRequest(c).SetQueryParam("standalone", fmt.Sprintf("%t", standalone)).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response status: ", resp.StatusCode())
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return resp.Body(), nil
+	}
+}
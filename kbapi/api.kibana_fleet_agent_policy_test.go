@@ -0,0 +1,94 @@
+package kbapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetAgentPolicies() {
+
+	// Create new agent policy
+	agentPolicy, err := s.API.KibanaFleet.AgentPolicies.Create(&AgentPolicy{
+		Name:              "test-agent-policy",
+		Namespace:         "default",
+		Description:       "test",
+		MonitoringEnabled: []string{"logs", "metrics"},
+	}, false)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), agentPolicy)
+	assert.NotEmpty(s.T(), agentPolicy.ID)
+	id := agentPolicy.ID
+
+	// Get agent policy
+	agentPolicy, err = s.API.KibanaFleet.AgentPolicies.Get(id)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), agentPolicy)
+	assert.Equal(s.T(), "test-agent-policy", agentPolicy.Name)
+
+	// List agent policies
+	agentPolicyListResponse, err := s.API.KibanaFleet.AgentPolicies.List(&AgentPolicyListParameters{
+		FleetListParameters: FleetListParameters{
+			Kuery: "ingest-agent-policies.name:test-agent-policy",
+		},
+	})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, agentPolicyListResponse.Total)
+
+	// Update agent policy
+	agentPolicy.Description = "test2"
+	agentPolicy, err = s.API.KibanaFleet.AgentPolicies.Update(agentPolicy)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test2", agentPolicy.Description)
+
+	// Copy agent policy
+	agentPolicyCopy, err := s.API.KibanaFleet.AgentPolicies.Copy(id, "test-agent-policy-copy", "")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), agentPolicyCopy)
+	assert.Equal(s.T(), "test-agent-policy-copy", agentPolicyCopy.Name)
+
+	// Get full agent policy
+	fullAgentPolicy, err := s.API.KibanaFleet.AgentPolicies.GetFull(id, false)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), id, fullAgentPolicy["id"])
+	yaml, err := s.API.KibanaFleet.AgentPolicies.Download(id, true)
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), string(yaml), "outputs:")
+
+	// Delete agent policies
+	err = s.API.KibanaFleet.AgentPolicies.Delete(agentPolicyCopy.ID)
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaFleet.AgentPolicies.Delete(id)
+	assert.NoError(s.T(), err)
+	agentPolicy, err = s.API.KibanaFleet.AgentPolicies.Get(id)
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), agentPolicy)
+}
+
+func TestKibanaFleetAgentPoliciesQueryParams(t *testing.T) {
+
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		return http.StatusOK, `{"item": {"id": "test"}}`
+	})
+
+	// Create with system monitoring
+	_, err := kibana.API.KibanaFleet.AgentPolicies.Create(&AgentPolicy{Name: "test", Namespace: "default"}, true)
+	assert.NoError(t, err)
+	request := kibana.lastRequest(t)
+	assert.Equal(t, "/api/fleet/agent_policies", request.Path)
+	assert.Equal(t, "true", request.Query.Get("sys_monitoring"))
+	assert.Equal(t, ElasticAPIVersionPublic, request.Header.Get(HeaderElasticAPIVersion))
+
+	// Create without system monitoring
+	_, err = kibana.API.KibanaFleet.AgentPolicies.Create(&AgentPolicy{Name: "test", Namespace: "default"}, false)
+	assert.NoError(t, err)
+	assert.False(t, kibana.lastRequest(t).Query.Has("sys_monitoring"))
+
+	// Get full standalone policy
+	_, err = kibana.API.KibanaFleet.AgentPolicies.GetFull("test", true)
+	assert.NoError(t, err)
+	request = kibana.lastRequest(t)
+	assert.Equal(t, "/api/fleet/agent_policies/test/full", request.Path)
+	assert.Equal(t, "true", request.Query.Get("standalone"))
+}
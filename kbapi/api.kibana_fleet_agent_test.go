@@ -0,0 +1,34 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetAgents() {
+
+	// List agents with KQL filter
+	agentListResponse, err := s.API.KibanaFleet.Agents.List(&AgentListParameters{
+		FleetListParameters: FleetListParameters{
+			Kuery: "status:online",
+		},
+		ShowInactive: true,
+	})
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), agentListResponse)
+
+	// Get agent that not exist
+	agent, err := s.API.KibanaFleet.Agents.Get("fake")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), agent)
+
+	// Bulk actions need agent IDs or kuery, not both
+	_, err = s.API.KibanaFleet.Agents.BulkUnenroll(&AgentSelection{}, false)
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+	_, err = s.API.KibanaFleet.Agents.BulkReassign(&AgentSelection{
+		IDs:   []string{"fake"},
+		Kuery: "status:online",
+	}, "fake")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+	_, err = s.API.KibanaFleet.Agents.BulkUpgrade(&AgentSelection{Kuery: "status:online"}, &AgentUpgradeParameters{})
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+}
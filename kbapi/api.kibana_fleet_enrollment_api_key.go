@@ -0,0 +1,137 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// EnrollmentAPIKey is the key used by agents to enroll on agent policy
+type EnrollmentAPIKey struct {
+	ID        string `json:"id,omitempty"`
+	APIKeyID  string `json:"api_key_id,omitempty"`
+	APIKey    string `json:"api_key,omitempty"`
+	Name      string `json:"name"`
+	PolicyID  string `json:"policy_id"`
+	Active    bool   `json:"active,omitempty"`
+	Hidden    bool   `json:"hidden,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// EnrollmentAPIKeys is list of EnrollmentAPIKey object
+type EnrollmentAPIKeys []EnrollmentAPIKey
+
+// EnrollmentAPIKeyListResponse is the result when list enrollment API keys
+type EnrollmentAPIKeyListResponse struct {
+	FleetPage
+	Items EnrollmentAPIKeys `json:"items"`
+}
+
+// KibanaFleetEnrollmentAPIKeyList permit to list enrollment API keys
+type KibanaFleetEnrollmentAPIKeyList func(parameters *FleetListParameters) (*EnrollmentAPIKeyListResponse, error)
+
+// KibanaFleetEnrollmentAPIKeyGet permit to get enrollment API key
+type KibanaFleetEnrollmentAPIKeyGet func(id string) (*EnrollmentAPIKey, error)
+
+// KibanaFleetEnrollmentAPIKeyCreate permit to create enrollment API key on agent policy
+type KibanaFleetEnrollmentAPIKeyCreate func(name string, policyID string) (*EnrollmentAPIKey, error)
+
+// KibanaFleetEnrollmentAPIKeyRevoke permit to revoke enrollment API key
+type KibanaFleetEnrollmentAPIKeyRevoke func(id string) error
+
+// String permit to return EnrollmentAPIKey object as JSON string, without the API key
+func (o *EnrollmentAPIKey) String() string {
+	enrollmentAPIKey := *o
+	if enrollmentAPIKey.APIKey != "" {
+		enrollmentAPIKey.APIKey = "[REDACTED]"
+	}
+	json, _ := json.Marshal(enrollmentAPIKey)
+	return string(json)
+}
+
+// newKibanaFleetEnrollmentAPIKeyListFunc permit to list enrollment API keys
+func newKibanaFleetEnrollmentAPIKeyListFunc(c *resty.Client) KibanaFleetEnrollmentAPIKeyList {
+	return func(parameters *FleetListParameters) (*EnrollmentAPIKeyListResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+
+		enrollmentAPIKeyListResponse := &EnrollmentAPIKeyListResponse{}
+		path := fmt.Sprintf("%s/enrollment_api_keys", basePathKibanaFleet)
+		err := listFleetItems(c, path, parameters.toQueryParams(), enrollmentAPIKeyListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("EnrollmentAPIKeys: ", len(enrollmentAPIKeyListResponse.Items))
+
+		return enrollmentAPIKeyListResponse, nil
+	}
+}
+
+// newKibanaFleetEnrollmentAPIKeyGetFunc permit to get enrollment API key with it ID
+func newKibanaFleetEnrollmentAPIKeyGetFunc(c *resty.Client) KibanaFleetEnrollmentAPIKeyGet {
+	return func(id string) (*EnrollmentAPIKey, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide enrollment API key ID")
+		}
+		log.Debug("ID: ", id)
+
+		enrollmentAPIKey := &EnrollmentAPIKey{}
+		path := fmt.Sprintf("%s/enrollment_api_keys/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, enrollmentAPIKey)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("EnrollmentAPIKey: ", enrollmentAPIKey)
+
+		return enrollmentAPIKey, nil
+	}
+}
+
+// newKibanaFleetEnrollmentAPIKeyCreateFunc permit to create enrollment API key
+func newKibanaFleetEnrollmentAPIKeyCreateFunc(c *resty.Client) KibanaFleetEnrollmentAPIKeyCreate {
+	return func(name string, policyID string) (*EnrollmentAPIKey, error) {
+
+		if policyID == "" {
+			return nil, NewAPIError(600, "You must provide agent policy ID")
+		}
+		log.Debug("Name: ", name)
+		log.Debug("PolicyID: ", policyID)
+
+		payload := map[string]string{
+			"policy_id": policyID,
+		}
+		if name != "" {
+			payload["name"] = name
+		}
+		enrollmentAPIKey := &EnrollmentAPIKey{}
+		path := fmt.Sprintf("%s/enrollment_api_keys", basePathKibanaFleet)
+		err := sendFleetItem(c, http.MethodPost, path, nil, payload, enrollmentAPIKey)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("EnrollmentAPIKey: ", enrollmentAPIKey)
+
+		return enrollmentAPIKey, nil
+	}
+}
+
+// newKibanaFleetEnrollmentAPIKeyRevokeFunc permit to revoke enrollment API key with it ID
+func newKibanaFleetEnrollmentAPIKeyRevokeFunc(c *resty.Client) KibanaFleetEnrollmentAPIKeyRevoke {
+	return func(id string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide enrollment API key ID")
+		}
+		log.Debug("ID: ", id)
+
+		path := fmt.Sprintf("%s/enrollment_api_keys/%s", basePathKibanaFleet, id)
+		return deleteFleetItem(c, path)
+	}
+}
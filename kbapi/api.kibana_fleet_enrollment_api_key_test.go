@@ -0,0 +1,59 @@
+package kbapi
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetEnrollmentAPIKeys() {
+
+	agentPolicy, err := s.API.KibanaFleet.AgentPolicies.Create(&AgentPolicy{
+		Name:      "test-enrollment-api-key",
+		Namespace: "default",
+	}, false)
+	assert.NoError(s.T(), err)
+
+	// Create new enrollment API key
+	enrollmentAPIKey, err := s.API.KibanaFleet.EnrollmentAPIKeys.Create("test", agentPolicy.ID)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), enrollmentAPIKey)
+	assert.NotEmpty(s.T(), enrollmentAPIKey.APIKey)
+	assert.NotContains(s.T(), enrollmentAPIKey.String(), enrollmentAPIKey.APIKey)
+	id := enrollmentAPIKey.ID
+
+	// Get enrollment API key
+	enrollmentAPIKey, err = s.API.KibanaFleet.EnrollmentAPIKeys.Get(id)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), enrollmentAPIKey)
+	assert.True(s.T(), enrollmentAPIKey.Active)
+
+	// List enrollment API keys of agent policy, page by page
+	parameters := &FleetListParameters{
+		PerPage: 1,
+		Kuery:   fmt.Sprintf("policy_id:\"%s\"", agentPolicy.ID),
+	}
+	enrollmentAPIKeys := EnrollmentAPIKeys{}
+	for {
+		enrollmentAPIKeyListResponse, err := s.API.KibanaFleet.EnrollmentAPIKeys.List(parameters)
+		assert.NoError(s.T(), err)
+		enrollmentAPIKeys = append(enrollmentAPIKeys, enrollmentAPIKeyListResponse.Items...)
+		if !enrollmentAPIKeyListResponse.HasNextPage() {
+			break
+		}
+		parameters = parameters.NextPage()
+	}
+	assert.GreaterOrEqual(s.T(), len(enrollmentAPIKeys), 1)
+
+	// Revoke enrollment API key
+	err = s.API.KibanaFleet.EnrollmentAPIKeys.Revoke(id)
+	assert.NoError(s.T(), err)
+	enrollmentAPIKey, err = s.API.KibanaFleet.EnrollmentAPIKeys.Get(id)
+	assert.NoError(s.T(), err)
+	if enrollmentAPIKey != nil {
+		assert.False(s.T(), enrollmentAPIKey.Active)
+	}
+
+	err = s.API.KibanaFleet.AgentPolicies.Delete(agentPolicy.ID)
+	assert.NoError(s.T(), err)
+}
@@ -0,0 +1,404 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Content types of package archive that can be uploaded
+const (
+	EPMArchiveZip  = "application/zip"
+	EPMArchiveGzip = "application/gzip"
+)
+
+// EPMPackage is the integration package handled by fleet
+type EPMPackage struct {
+	Name                 string                   `json:"name"`
+	Title                string                   `json:"title,omitempty"`
+	Version              string                   `json:"version"`
+	Description          string                   `json:"description,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Release              string                   `json:"release,omitempty"`
+	Categories           []string                 `json:"categories,omitempty"`
+	Status               string                   `json:"status,omitempty"`
+	LatestVersion        string                   `json:"latestVersion,omitempty"`
+	KeepPoliciesUpToDate bool                     `json:"keepPoliciesUpToDate,omitempty"`
+	Download             string                   `json:"download,omitempty"`
+	Path                 string                   `json:"path,omitempty"`
+	Owner                map[string]interface{}   `json:"owner,omitempty"`
+	Conditions           map[string]interface{}   `json:"conditions,omitempty"`
+	PolicyTemplates      []map[string]interface{} `json:"policy_templates,omitempty"`
+	DataStreams          []map[string]interface{} `json:"data_streams,omitempty"`
+	Vars                 []map[string]interface{} `json:"vars,omitempty"`
+	InstallationInfo     map[string]interface{}   `json:"installationInfo,omitempty"`
+}
+
+// EPMPackages is list of EPMPackage object
+type EPMPackages []EPMPackage
+
+// EPMPackageListParameters contain optional parameters to list packages
+type EPMPackageListParameters struct {
+	Category             string
+	Prerelease           bool
+	ExcludeInstallStatus bool
+}
+
+// EPMAsset is the asset installed by package
+type EPMAsset struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// EPMPackageToInstall is the package to install with bulk install. When version is empty, the latest version is installed.
+type EPMPackageToInstall struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// EPMBulkInstallResult is the result of bulk install for one package
+type EPMBulkInstallResult struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version,omitempty"`
+	Result     *EPMInstallResult `json:"result,omitempty"`
+	StatusCode int               `json:"statusCode,omitempty"`
+	Error      interface{}       `json:"error,omitempty"`
+}
+
+// EPMInstallResult is the result of package install
+type EPMInstallResult struct {
+	Assets      []EPMAsset `json:"assets"`
+	Status      string     `json:"status"`
+	InstallType string     `json:"installType,omitempty"`
+}
+
+// epmResponse is the response of EPM API. Kibana 7.x return result in response field, Kibana 8.x in items or item field.
+type epmResponse struct {
+	Items    json.RawMessage `json:"items"`
+	Item     json.RawMessage `json:"item"`
+	Response json.RawMessage `json:"response"`
+}
+
+// KibanaFleetEPMList permit to list packages
+type KibanaFleetEPMList func(parameters *EPMPackageListParameters) (EPMPackages, error)
+
+// KibanaFleetEPMSearch permit to search packages with name, title or description that contain the query
+type KibanaFleetEPMSearch func(query string, parameters *EPMPackageListParameters) (EPMPackages, error)
+
+// KibanaFleetEPMGet permit to get package info. When version is empty, the latest version is returned.
+type KibanaFleetEPMGet func(name string, version string) (*EPMPackage, error)
+
+// KibanaFleetEPMInstall permit to install package from registry. When version is empty, the latest version is installed.
+type KibanaFleetEPMInstall func(name string, version string, force bool) ([]EPMAsset, error)
+
+// KibanaFleetEPMUpload permit to install package from zip or tar.gz archive
+type KibanaFleetEPMUpload func(archive io.Reader, contentType string) ([]EPMAsset, error)
+
+// KibanaFleetEPMBulkInstall permit to install many packages from registry
+type KibanaFleetEPMBulkInstall func(packages []EPMPackageToInstall, force bool) ([]EPMBulkInstallResult, error)
+
+// KibanaFleetEPMUninstall permit to uninstall package
+type KibanaFleetEPMUninstall func(name string, version string, force bool) ([]EPMAsset, error)
+
+// KibanaFleetEPMReinstall permit to reinstall package, to restore its assets
+type KibanaFleetEPMReinstall func(name string, version string) ([]EPMAsset, error)
+
+// String permit to return EPMPackage object as JSON string
+func (o *EPMPackage) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// IsInstalled return true if package is installed
+func (o *EPMPackage) IsInstalled() bool {
+	return o.Status == "installed"
+}
+
+// unmarshal permit to read the result of EPM API, whatever the Kibana version
+func (o *epmResponse) unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, o); err != nil {
+		return err
+	}
+	for _, raw := range []json.RawMessage{o.Items, o.Item, o.Response} {
+		if len(raw) > 0 && string(raw) != "null" {
+			return json.Unmarshal(raw, v)
+		}
+	}
+
+	return nil
+}
+
+// toQueryParams return the list parameters as query parameters
+func (o *EPMPackageListParameters) toQueryParams() map[string]string {
+	queryParams := map[string]string{}
+	if o == nil {
+		return queryParams
+	}
+	if o.Category != "" {
+		queryParams["category"] = o.Category
+	}
+	if o.Prerelease {
+		queryParams["prerelease"] = "true"
+	}
+	if o.ExcludeInstallStatus {
+		queryParams["excludeInstallStatus"] = "true"
+	}
+
+	return queryParams
+}
+
+// epmPackagePath return the path of package, with version if provided
+func epmPackagePath(name string, version string) string {
+	if version == "" {
+		return fmt.Sprintf("%s/epm/packages/%s", basePathKibanaFleet, name)
+	}
+	return fmt.Sprintf("%s/epm/packages/%s/%s", basePathKibanaFleet, name, version)
+}
+
+// newKibanaFleetEPMListFunc permit to list packages
+func newKibanaFleetEPMListFunc(c *resty.Client) KibanaFleetEPMList {
+	return func(parameters *EPMPackageListParameters) (EPMPackages, error) {
+
+		log.Debug("Parameters: ", parameters)
+
+		path := fmt.Sprintf("%s/epm/packages", basePathKibanaFleet)
+		resp, err := newFleetRequest(c).SetQueryParams(parameters.toQueryParams()).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		epmPackages := EPMPackages{}
+		err = (&epmResponse{}).unmarshal(resp.Body(), &epmPackages)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("EPMPackages: ", epmPackages)
+
+		return epmPackages, nil
+	}
+}
+
+// newKibanaFleetEPMSearchFunc permit to search packages
+func newKibanaFleetEPMSearchFunc(c *resty.Client) KibanaFleetEPMSearch {
+	return func(query string, parameters *EPMPackageListParameters) (EPMPackages, error) {
+
+		log.Debug("Query: ", query)
+
+		epmPackages, err := newKibanaFleetEPMListFunc(c)(parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		query = strings.ToLower(query)
+		results := EPMPackages{}
+		for _, epmPackage := range epmPackages {
+			if strings.Contains(strings.ToLower(epmPackage.Name), query) ||
+				strings.Contains(strings.ToLower(epmPackage.Title), query) ||
+				strings.Contains(strings.ToLower(epmPackage.Description), query) {
+				results = append(results, epmPackage)
+			}
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Name < results[j].Name
+		})
+		log.Debug("EPMPackages: ", results)
+
+		return results, nil
+	}
+}
+
+// newKibanaFleetEPMGetFunc permit to get package info
+func newKibanaFleetEPMGetFunc(c *resty.Client) KibanaFleetEPMGet {
+	return func(name string, version string) (*EPMPackage, error) {
+
+		if name == "" {
+			return nil, NewAPIError(600, "You must provide package name")
+		}
+		log.Debug("Name: ", name)
+		log.Debug("Version: ", version)
+
+		resp, err := newFleetRequest(c).Get(epmPackagePath(name, version))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		epmPackage := &EPMPackage{}
+		err = (&epmResponse{}).unmarshal(resp.Body(), epmPackage)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("EPMPackage: ", epmPackage)
+
+		return epmPackage, nil
+	}
+}
+
+// newKibanaFleetEPMInstallFunc permit to install package from registry
+func newKibanaFleetEPMInstallFunc(c *resty.Client) KibanaFleetEPMInstall {
+	return func(name string, version string, force bool) ([]EPMAsset, error) {
+
+		if name == "" {
+			return nil, NewAPIError(600, "You must provide package name")
+		}
+		log.Debug("Name: ", name)
+		log.Debug("Version: ", version)
+		log.Debug("Force: ", force)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"force": force,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := newFleetRequest(c).SetBody(jsonData).Post(epmPackagePath(name, version))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalEPMAssets(resp.Body())
+	}
+}
+
+// newKibanaFleetEPMUploadFunc permit to install package from archive
+func newKibanaFleetEPMUploadFunc(c *resty.Client) KibanaFleetEPMUpload {
+	return func(archive io.Reader, contentType string) ([]EPMAsset, error) {
+
+		if archive == nil {
+			return nil, NewAPIError(600, "You must provide package archive")
+		}
+		if contentType != EPMArchiveZip && contentType != EPMArchiveGzip {
+			return nil, NewAPIError(600, "Content type must be '%s' or '%s', not '%s'", EPMArchiveZip, EPMArchiveGzip, contentType)
+		}
+		log.Debug("ContentType: ", contentType)
+
+		path := fmt.Sprintf("%s/epm/packages", basePathKibanaFleet)
+		resp, err := newFleetRequest(c).
+			SetHeader("Content-Type", contentType).
+			SetBody(archive).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalEPMAssets(resp.Body())
+	}
+}
+
+// newKibanaFleetEPMBulkInstallFunc permit to install many packages from registry
+func newKibanaFleetEPMBulkInstallFunc(c *resty.Client) KibanaFleetEPMBulkInstall {
+	return func(packages []EPMPackageToInstall, force bool) ([]EPMBulkInstallResult, error) {
+
+		if len(packages) == 0 {
+			return nil, NewAPIError(600, "You must provide packages to install")
+		}
+		log.Debug("Packages: ", packages)
+		log.Debug("Force: ", force)
+
+		// Package without version is sent by name, to install the latest version
+		payloadPackages := make([]interface{}, 0, len(packages))
+		for _, epmPackage := range packages {
+			if epmPackage.Version == "" {
+				payloadPackages = append(payloadPackages, epmPackage.Name)
+			} else {
+				payloadPackages = append(payloadPackages, epmPackage)
+			}
+		}
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"packages": payloadPackages,
+			"force":    force,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := fmt.Sprintf("%s/epm/packages/_bulk", basePathKibanaFleet)
+		resp, err := newFleetRequest(c).SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		results := []EPMBulkInstallResult{}
+		err = (&epmResponse{}).unmarshal(resp.Body(), &results)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Results: ", results)
+
+		return results, nil
+	}
+}
+
+// newKibanaFleetEPMUninstallFunc permit to uninstall package
+func newKibanaFleetEPMUninstallFunc(c *resty.Client) KibanaFleetEPMUninstall {
+	return func(name string, version string, force bool) ([]EPMAsset, error) {
+
+		if name == "" || version == "" {
+			return nil, NewAPIError(600, "You must provide package name and version")
+		}
+		log.Debug("Name: ", name)
+		log.Debug("Version: ", version)
+		log.Debug("Force: ", force)
+
+		request := newFleetRequest(c)
+		if force {
+			request = request.SetQueryParam("force", "true")
+		}
+		resp, err := request.Delete(epmPackagePath(name, version))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalEPMAssets(resp.Body())
+	}
+}
+
+// newKibanaFleetEPMReinstallFunc permit to reinstall package
+func newKibanaFleetEPMReinstallFunc(c *resty.Client) KibanaFleetEPMReinstall {
+	return func(name string, version string) ([]EPMAsset, error) {
+		if version == "" {
+			return nil, NewAPIError(600, "You must provide package version to reinstall")
+		}
+
+		// Force install of the same version reinstall all the assets of package
+		return newKibanaFleetEPMInstallFunc(c)(name, version, true)
+	}
+}
+
+// unmarshalEPMAssets permit to read package assets from response body
+func unmarshalEPMAssets(data []byte) ([]EPMAsset, error) {
+	assets := []EPMAsset{}
+	if err := (&epmResponse{}).unmarshal(data, &assets); err != nil {
+		return nil, err
+	}
+	log.Debug("Assets: ", assets)
+
+	return assets, nil
+}
@@ -0,0 +1,54 @@
+package kbapi
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetEPM() {
+
+	// Search package
+	epmPackages, err := s.API.KibanaFleet.EPM.Search("nginx", nil)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), epmPackages)
+
+	// Get latest package info
+	epmPackage, err := s.API.KibanaFleet.EPM.Get("nginx", "")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), epmPackage)
+	assert.NotEmpty(s.T(), epmPackage.Version)
+	version := epmPackage.Version
+
+	// Install package
+	assets, err := s.API.KibanaFleet.EPM.Install("nginx", version, false)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), assets)
+	epmPackage, err = s.API.KibanaFleet.EPM.Get("nginx", version)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), epmPackage.IsInstalled())
+
+	// Bulk install packages
+	results, err := s.API.KibanaFleet.EPM.BulkInstall([]EPMPackageToInstall{
+		{Name: "nginx", Version: version},
+		{Name: "system"},
+	}, false)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 2)
+
+	// Reinstall package
+	_, err = s.API.KibanaFleet.EPM.Reinstall("nginx", version)
+	assert.NoError(s.T(), err)
+
+	// Upload need zip or tar.gz archive
+	_, err = s.API.KibanaFleet.EPM.Upload(strings.NewReader("test"), "text/plain")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// Uninstall package
+	assets, err = s.API.KibanaFleet.EPM.Uninstall("nginx", version, false)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), assets)
+	epmPackage, err = s.API.KibanaFleet.EPM.Get("nginx", version)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), epmPackage.IsInstalled())
+}
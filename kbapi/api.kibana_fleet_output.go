@@ -0,0 +1,192 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Types of fleet output
+const (
+	OutputTypeElasticsearch       = "elasticsearch"
+	OutputTypeRemoteElasticsearch = "remote_elasticsearch"
+	OutputTypeLogstash            = "logstash"
+	OutputTypeKafka               = "kafka"
+)
+
+// Output is the fleet output, where agents send data
+type Output struct {
+	ID                   string                 `json:"id,omitempty"`
+	Name                 string                 `json:"name"`
+	Type                 string                 `json:"type"`
+	Hosts                []string               `json:"hosts,omitempty"`
+	IsDefault            bool                   `json:"is_default"`
+	IsDefaultMonitoring  bool                   `json:"is_default_monitoring"`
+	CATrustedFingerprint string                 `json:"ca_trusted_fingerprint,omitempty"`
+	CASha256             string                 `json:"ca_sha256,omitempty"`
+	ConfigYAML           string                 `json:"config_yaml,omitempty"`
+	SSL                  *OutputSSL             `json:"ssl,omitempty"`
+	ProxyID              string                 `json:"proxy_id,omitempty"`
+	Secrets              map[string]interface{} `json:"secrets,omitempty"`
+	IsPreconfigured      bool                   `json:"is_preconfigured,omitempty"`
+}
+
+// Outputs is list of Output object
+type Outputs []Output
+
+// OutputSSL is the TLS settings of output
+type OutputSSL struct {
+	CertificateAuthorities []string `json:"certificate_authorities,omitempty"`
+	Certificate            string   `json:"certificate,omitempty"`
+	Key                    string   `json:"key,omitempty"`
+}
+
+// OutputListResponse is the result when list outputs
+type OutputListResponse struct {
+	FleetPage
+	Items Outputs `json:"items"`
+}
+
+// KibanaFleetOutputList permit to list outputs
+type KibanaFleetOutputList func() (*OutputListResponse, error)
+
+// KibanaFleetOutputGet permit to get output
+type KibanaFleetOutputGet func(id string) (*Output, error)
+
+// KibanaFleetOutputCreate permit to create output
+type KibanaFleetOutputCreate func(output *Output) (*Output, error)
+
+// KibanaFleetOutputUpdate permit to update output
+type KibanaFleetOutputUpdate func(output *Output) (*Output, error)
+
+// KibanaFleetOutputDelete permit to delete output
+type KibanaFleetOutputDelete func(id string) error
+
+// String permit to return Output object as JSON string, without SSL key and secrets
+func (o *Output) String() string {
+	output := *o
+	if output.SSL != nil && output.SSL.Key != "" {
+		ssl := *output.SSL
+		ssl.Key = "[REDACTED]"
+		output.SSL = &ssl
+	}
+	if output.Secrets != nil {
+		output.Secrets = map[string]interface{}{"[REDACTED]": true}
+	}
+	json, _ := json.Marshal(output)
+	return string(json)
+}
+
+// toRequest return the output attributes that can be written
+func (o *Output) toRequest() *Output {
+	output := *o
+	output.IsPreconfigured = false
+	return &output
+}
+
+// newKibanaFleetOutputListFunc permit to list outputs
+func newKibanaFleetOutputListFunc(c *resty.Client) KibanaFleetOutputList {
+	return func() (*OutputListResponse, error) {
+
+		outputListResponse := &OutputListResponse{}
+		path := fmt.Sprintf("%s/outputs", basePathKibanaFleet)
+		err := listFleetItems(c, path, nil, outputListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Outputs: ", len(outputListResponse.Items))
+
+		return outputListResponse, nil
+	}
+}
+
+// newKibanaFleetOutputGetFunc permit to get output with it ID
+func newKibanaFleetOutputGetFunc(c *resty.Client) KibanaFleetOutputGet {
+	return func(id string) (*Output, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide output ID")
+		}
+		log.Debug("ID: ", id)
+
+		output := &Output{}
+		path := fmt.Sprintf("%s/outputs/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, output)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("Output: ", output)
+
+		return output, nil
+	}
+}
+
+// newKibanaFleetOutputCreateFunc permit to create output
+func newKibanaFleetOutputCreateFunc(c *resty.Client) KibanaFleetOutputCreate {
+	return func(output *Output) (*Output, error) {
+
+		if output == nil {
+			return nil, NewAPIError(600, "You must provide output object")
+		}
+		if output.Name == "" || output.Type == "" {
+			return nil, NewAPIError(600, "You must provide output name and type")
+		}
+		log.Debug("Output: ", output)
+
+		result := &Output{}
+		path := fmt.Sprintf("%s/outputs", basePathKibanaFleet)
+		err := sendFleetItem(c, http.MethodPost, path, nil, output.toRequest(), result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Output: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetOutputUpdateFunc permit to update output
+func newKibanaFleetOutputUpdateFunc(c *resty.Client) KibanaFleetOutputUpdate {
+	return func(output *Output) (*Output, error) {
+
+		if output == nil {
+			return nil, NewAPIError(600, "You must provide output object")
+		}
+		if output.ID == "" {
+			return nil, NewAPIError(600, "You must provide output ID")
+		}
+		log.Debug("Output: ", output)
+
+		request := output.toRequest()
+		request.ID = ""
+		result := &Output{}
+		path := fmt.Sprintf("%s/outputs/%s", basePathKibanaFleet, output.ID)
+		err := sendFleetItem(c, http.MethodPut, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Output: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetOutputDeleteFunc permit to delete output with it ID
+func newKibanaFleetOutputDeleteFunc(c *resty.Client) KibanaFleetOutputDelete {
+	return func(id string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide output ID")
+		}
+		log.Debug("ID: ", id)
+
+		path := fmt.Sprintf("%s/outputs/%s", basePathKibanaFleet, id)
+		return deleteFleetItem(c, path)
+	}
+}
@@ -0,0 +1,42 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetOutputs() {
+
+	// Create new output
+	output, err := s.API.KibanaFleet.Outputs.Create(&Output{
+		Name:  "test-output",
+		Type:  OutputTypeElasticsearch,
+		Hosts: []string{"http://localhost:9200"},
+	})
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), output)
+	id := output.ID
+
+	// Get output
+	output, err = s.API.KibanaFleet.Outputs.Get(id)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), output)
+	assert.Equal(s.T(), "test-output", output.Name)
+
+	// List outputs
+	outputListResponse, err := s.API.KibanaFleet.Outputs.List()
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), outputListResponse.Items)
+
+	// Update output
+	output.Hosts = []string{"http://localhost:9201"}
+	output, err = s.API.KibanaFleet.Outputs.Update(output)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"http://localhost:9201"}, output.Hosts)
+
+	// Delete output
+	err = s.API.KibanaFleet.Outputs.Delete(id)
+	assert.NoError(s.T(), err)
+	output, err = s.API.KibanaFleet.Outputs.Get(id)
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), output)
+}
@@ -0,0 +1,344 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// PackagePolicy is the fleet package policy object, it's the integration added on agent policy
+type PackagePolicy struct {
+	ID          string                      `json:"id,omitempty"`
+	Version     string                      `json:"version,omitempty"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	Namespace   string                      `json:"namespace,omitempty"`
+	PolicyID    string                      `json:"policy_id"`
+	OutputID    string                      `json:"output_id,omitempty"`
+	Enabled     bool                        `json:"enabled"`
+	Package     *PackagePolicyPackage       `json:"package,omitempty"`
+	Inputs      []PackagePolicyInput        `json:"inputs"`
+	Vars        map[string]PackagePolicyVar `json:"vars,omitempty"`
+	Revision    int                         `json:"revision,omitempty"`
+	CreatedAt   string                      `json:"created_at,omitempty"`
+	CreatedBy   string                      `json:"created_by,omitempty"`
+	UpdatedAt   string                      `json:"updated_at,omitempty"`
+	UpdatedBy   string                      `json:"updated_by,omitempty"`
+}
+
+// PackagePolicies is list of PackagePolicy object
+type PackagePolicies []PackagePolicy
+
+// PackagePolicyPackage is the integration package used by package policy
+type PackagePolicyPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Title   string `json:"title,omitempty"`
+}
+
+// PackagePolicyInput is the input of package policy
+type PackagePolicyInput struct {
+	Type           string                      `json:"type"`
+	PolicyTemplate string                      `json:"policy_template,omitempty"`
+	Enabled        bool                        `json:"enabled"`
+	Vars           map[string]PackagePolicyVar `json:"vars,omitempty"`
+	Config         map[string]PackagePolicyVar `json:"config,omitempty"`
+	Streams        []PackagePolicyInputStream  `json:"streams"`
+	CompiledInput  interface{}                 `json:"compiled_input,omitempty"`
+}
+
+// PackagePolicyInputStream is the stream of package policy input, it collect one data stream
+type PackagePolicyInputStream struct {
+	ID             string                      `json:"id,omitempty"`
+	Enabled        bool                        `json:"enabled"`
+	DataStream     PackagePolicyDataStream     `json:"data_stream"`
+	Vars           map[string]PackagePolicyVar `json:"vars,omitempty"`
+	Config         map[string]PackagePolicyVar `json:"config,omitempty"`
+	CompiledStream interface{}                 `json:"compiled_stream,omitempty"`
+}
+
+// PackagePolicyDataStream is the data stream where the input stream send data
+type PackagePolicyDataStream struct {
+	Type    string `json:"type"`
+	Dataset string `json:"dataset"`
+}
+
+// PackagePolicyVar is the variable of package policy, input or stream
+type PackagePolicyVar struct {
+	Type   string      `json:"type,omitempty"`
+	Value  interface{} `json:"value"`
+	Frozen bool        `json:"frozen,omitempty"`
+}
+
+// PackagePolicyListResponse is the result when list package policies
+type PackagePolicyListResponse struct {
+	FleetPage
+	Items PackagePolicies `json:"items"`
+}
+
+// PackagePolicyActionResult is the result of action on package policy, like delete or upgrade
+type PackagePolicyActionResult struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Success    bool                   `json:"success"`
+	StatusCode int                    `json:"statusCode,omitempty"`
+	Body       map[string]interface{} `json:"body,omitempty"`
+}
+
+// PackagePolicyDryRunResult is the result of package policy upgrade dry run
+type PackagePolicyDryRunResult struct {
+	Name       string                     `json:"name,omitempty"`
+	HasErrors  bool                       `json:"hasErrors"`
+	Diff       []map[string]interface{}   `json:"diff,omitempty"`
+	AgentDiff  [][]map[string]interface{} `json:"agent_diff,omitempty"`
+	StatusCode int                        `json:"statusCode,omitempty"`
+	Body       map[string]interface{}     `json:"body,omitempty"`
+}
+
+// packagePolicyRequest is the package policy attributes that can be written
+type packagePolicyRequest struct {
+	ID          string                      `json:"id,omitempty"`
+	Version     string                      `json:"version,omitempty"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	Namespace   string                      `json:"namespace,omitempty"`
+	PolicyID    string                      `json:"policy_id"`
+	OutputID    string                      `json:"output_id,omitempty"`
+	Enabled     bool                        `json:"enabled"`
+	Package     *PackagePolicyPackage       `json:"package,omitempty"`
+	Inputs      []PackagePolicyInput        `json:"inputs"`
+	Vars        map[string]PackagePolicyVar `json:"vars,omitempty"`
+	Force       bool                        `json:"force,omitempty"`
+}
+
+// KibanaFleetPackagePolicyList permit to list package policies
+type KibanaFleetPackagePolicyList func(parameters *FleetListParameters) (*PackagePolicyListResponse, error)
+
+// KibanaFleetPackagePolicyGet permit to get package policy
+type KibanaFleetPackagePolicyGet func(id string) (*PackagePolicy, error)
+
+// KibanaFleetPackagePolicyCreate permit to create package policy
+type KibanaFleetPackagePolicyCreate func(packagePolicy *PackagePolicy, force bool) (*PackagePolicy, error)
+
+// KibanaFleetPackagePolicyUpdate permit to update package policy
+type KibanaFleetPackagePolicyUpdate func(packagePolicy *PackagePolicy, force bool) (*PackagePolicy, error)
+
+// KibanaFleetPackagePolicyDelete permit to delete package policies
+type KibanaFleetPackagePolicyDelete func(ids []string, force bool) ([]PackagePolicyActionResult, error)
+
+// KibanaFleetPackagePolicyUpgrade permit to upgrade package policies to the installed package version
+type KibanaFleetPackagePolicyUpgrade func(ids []string) ([]PackagePolicyActionResult, error)
+
+// KibanaFleetPackagePolicyUpgradeDryRun permit to preview the upgrade of package policies.
+// When packageVersion is empty, the installed package version is used.
+type KibanaFleetPackagePolicyUpgradeDryRun func(ids []string, packageVersion string) ([]PackagePolicyDryRunResult, error)
+
+// String permit to return PackagePolicy object as JSON string
+func (o *PackagePolicy) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// toRequest return the package policy attributes that can be written
+func (o *PackagePolicy) toRequest() *packagePolicyRequest {
+	inputs := make([]PackagePolicyInput, 0, len(o.Inputs))
+	for _, input := range o.Inputs {
+		streams := make([]PackagePolicyInputStream, 0, len(input.Streams))
+		for _, stream := range input.Streams {
+			stream.CompiledStream = nil
+			streams = append(streams, stream)
+		}
+		input.Streams = streams
+		input.CompiledInput = nil
+		inputs = append(inputs, input)
+	}
+
+	return &packagePolicyRequest{
+		ID:          o.ID,
+		Version:     o.Version,
+		Name:        o.Name,
+		Description: o.Description,
+		Namespace:   o.Namespace,
+		PolicyID:    o.PolicyID,
+		OutputID:    o.OutputID,
+		Enabled:     o.Enabled,
+		Package:     o.Package,
+		Inputs:      inputs,
+		Vars:        o.Vars,
+	}
+}
+
+// newKibanaFleetPackagePolicyListFunc permit to list package policies
+func newKibanaFleetPackagePolicyListFunc(c *resty.Client) KibanaFleetPackagePolicyList {
+	return func(parameters *FleetListParameters) (*PackagePolicyListResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+
+		packagePolicyListResponse := &PackagePolicyListResponse{}
+		path := fmt.Sprintf("%s/package_policies", basePathKibanaFleet)
+		err := listFleetItems(c, path, parameters.toQueryParams(), packagePolicyListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("PackagePolicies: ", packagePolicyListResponse.Items)
+
+		return packagePolicyListResponse, nil
+	}
+}
+
+// newKibanaFleetPackagePolicyGetFunc permit to get package policy with it ID
+func newKibanaFleetPackagePolicyGetFunc(c *resty.Client) KibanaFleetPackagePolicyGet {
+	return func(id string) (*PackagePolicy, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide package policy ID")
+		}
+		log.Debug("ID: ", id)
+
+		packagePolicy := &PackagePolicy{}
+		path := fmt.Sprintf("%s/package_policies/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, packagePolicy)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("PackagePolicy: ", packagePolicy)
+
+		return packagePolicy, nil
+	}
+}
+
+// newKibanaFleetPackagePolicyCreateFunc permit to create package policy
+func newKibanaFleetPackagePolicyCreateFunc(c *resty.Client) KibanaFleetPackagePolicyCreate {
+	return func(packagePolicy *PackagePolicy, force bool) (*PackagePolicy, error) {
+
+		if packagePolicy == nil {
+			return nil, NewAPIError(600, "You must provide package policy object")
+		}
+		if packagePolicy.Name == "" || packagePolicy.PolicyID == "" || packagePolicy.Package == nil {
+			return nil, NewAPIError(600, "You must provide package policy name, agent policy ID and package")
+		}
+		log.Debug("PackagePolicy: ", packagePolicy)
+		log.Debug("Force: ", force)
+
+		request := packagePolicy.toRequest()
+		request.Version = ""
+		request.Force = force
+		result := &PackagePolicy{}
+		path := fmt.Sprintf("%s/package_policies", basePathKibanaFleet)
+		err := sendFleetItem(c, http.MethodPost, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("PackagePolicy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetPackagePolicyUpdateFunc permit to update package policy
+func newKibanaFleetPackagePolicyUpdateFunc(c *resty.Client) KibanaFleetPackagePolicyUpdate {
+	return func(packagePolicy *PackagePolicy, force bool) (*PackagePolicy, error) {
+
+		if packagePolicy == nil {
+			return nil, NewAPIError(600, "You must provide package policy object")
+		}
+		if packagePolicy.ID == "" {
+			return nil, NewAPIError(600, "You must provide package policy ID")
+		}
+		log.Debug("PackagePolicy: ", packagePolicy)
+		log.Debug("Force: ", force)
+
+		request := packagePolicy.toRequest()
+		request.ID = ""
+		request.Force = force
+		result := &PackagePolicy{}
+		path := fmt.Sprintf("%s/package_policies/%s", basePathKibanaFleet, packagePolicy.ID)
+		err := sendFleetItem(c, http.MethodPut, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("PackagePolicy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetPackagePolicyDeleteFunc permit to delete package policies with their IDs
+func newKibanaFleetPackagePolicyDeleteFunc(c *resty.Client) KibanaFleetPackagePolicyDelete {
+	return func(ids []string, force bool) ([]PackagePolicyActionResult, error) {
+
+		if len(ids) == 0 {
+			return nil, NewAPIError(600, "You must provide package policy IDs")
+		}
+		log.Debug("IDs: ", ids)
+		log.Debug("Force: ", force)
+
+		return postPackagePolicyAction(c, "delete", map[string]interface{}{
+			"packagePolicyIds": ids,
+			"force":            force,
+		})
+	}
+}
+
+// newKibanaFleetPackagePolicyUpgradeFunc permit to upgrade package policies
+func newKibanaFleetPackagePolicyUpgradeFunc(c *resty.Client) KibanaFleetPackagePolicyUpgrade {
+	return func(ids []string) ([]PackagePolicyActionResult, error) {
+
+		if len(ids) == 0 {
+			return nil, NewAPIError(600, "You must provide package policy IDs")
+		}
+		log.Debug("IDs: ", ids)
+
+		return postPackagePolicyAction(c, "upgrade", map[string]interface{}{
+			"packagePolicyIds": ids,
+		})
+	}
+}
+
+// newKibanaFleetPackagePolicyUpgradeDryRunFunc permit to preview the upgrade of package policies
+func newKibanaFleetPackagePolicyUpgradeDryRunFunc(c *resty.Client) KibanaFleetPackagePolicyUpgradeDryRun {
+	return func(ids []string, packageVersion string) ([]PackagePolicyDryRunResult, error) {
+
+		if len(ids) == 0 {
+			return nil, NewAPIError(600, "You must provide package policy IDs")
+		}
+		log.Debug("IDs: ", ids)
+		log.Debug("PackageVersion: ", packageVersion)
+
+		payload := map[string]interface{}{
+			"packagePolicyIds": ids,
+		}
+		if packageVersion != "" {
+			payload["packageVersion"] = packageVersion
+		}
+		results := []PackagePolicyDryRunResult{}
+		path := fmt.Sprintf("%s/package_policies/upgrade/dryrun", basePathKibanaFleet)
+		err := postFleetAction(c, path, payload, &results)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Results: ", results)
+
+		return results, nil
+	}
+}
+
+// postPackagePolicyAction permit to call bulk action on package policies
+func postPackagePolicyAction(c *resty.Client, action string, payload map[string]interface{}) ([]PackagePolicyActionResult, error) {
+
+	results := []PackagePolicyActionResult{}
+	path := fmt.Sprintf("%s/package_policies/%s", basePathKibanaFleet, action)
+	err := postFleetAction(c, path, payload, &results)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Results: ", results)
+
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package kbapi
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetPackagePolicies() {
+
+	// Create agent policy with system integration
+	agentPolicy, err := s.API.KibanaFleet.AgentPolicies.Create(&AgentPolicy{
+		Name:      "test-package-policy",
+		Namespace: "default",
+	}, true)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), agentPolicy)
+
+	// List package policies of agent policy
+	packagePolicyListResponse, err := s.API.KibanaFleet.PackagePolicies.List(&FleetListParameters{
+		Kuery: fmt.Sprintf("ingest-package-policies.policy_id:%s", agentPolicy.ID),
+	})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), packagePolicyListResponse.Items, 1)
+	systemPackagePolicy := packagePolicyListResponse.Items[0]
+	assert.Equal(s.T(), "system", systemPackagePolicy.Package.Name)
+
+	// Create new package policy
+	packagePolicy, err := s.API.KibanaFleet.PackagePolicies.Create(&PackagePolicy{
+		Name:      "test-package-policy",
+		Namespace: "default",
+		PolicyID:  agentPolicy.ID,
+		Enabled:   true,
+		Package: &PackagePolicyPackage{
+			Name:    "system",
+			Version: systemPackagePolicy.Package.Version,
+		},
+		Inputs: []PackagePolicyInput{},
+	}, false)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), packagePolicy)
+	id := packagePolicy.ID
+
+	// Get package policy
+	packagePolicy, err = s.API.KibanaFleet.PackagePolicies.Get(id)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), packagePolicy)
+	assert.Equal(s.T(), "test-package-policy", packagePolicy.Name)
+
+	// Update package policy
+	packagePolicy.Description = "test"
+	packagePolicy, err = s.API.KibanaFleet.PackagePolicies.Update(packagePolicy, false)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test", packagePolicy.Description)
+
+	// Upgrade package policy with installed version
+	dryRunResults, err := s.API.KibanaFleet.PackagePolicies.UpgradeDryRun([]string{systemPackagePolicy.ID}, "")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), dryRunResults, 1)
+	_, err = s.API.KibanaFleet.PackagePolicies.Upgrade([]string{systemPackagePolicy.ID})
+	assert.NoError(s.T(), err)
+
+	// Delete package policy
+	results, err := s.API.KibanaFleet.PackagePolicies.Delete([]string{id}, false)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+	assert.True(s.T(), results[0].Success)
+	packagePolicy, err = s.API.KibanaFleet.PackagePolicies.Get(id)
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), packagePolicy)
+
+	err = s.API.KibanaFleet.AgentPolicies.Delete(agentPolicy.ID)
+	assert.NoError(s.T(), err)
+}
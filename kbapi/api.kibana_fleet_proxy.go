@@ -0,0 +1,163 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Proxy is the proxy used by agents to reach outputs and fleet server
+type Proxy struct {
+	ID                     string            `json:"id,omitempty"`
+	Name                   string            `json:"name"`
+	URL                    string            `json:"url"`
+	Certificate            string            `json:"certificate,omitempty"`
+	CertificateAuthorities string            `json:"certificate_authorities,omitempty"`
+	CertificateKey         string            `json:"certificate_key,omitempty"`
+	ProxyHeaders           map[string]string `json:"proxy_headers,omitempty"`
+	IsPreconfigured        bool              `json:"is_preconfigured,omitempty"`
+}
+
+// Proxies is list of Proxy object
+type Proxies []Proxy
+
+// ProxyListResponse is the result when list proxies
+type ProxyListResponse struct {
+	FleetPage
+	Items Proxies `json:"items"`
+}
+
+// KibanaFleetProxyList permit to list proxies
+type KibanaFleetProxyList func() (*ProxyListResponse, error)
+
+// KibanaFleetProxyGet permit to get proxy
+type KibanaFleetProxyGet func(id string) (*Proxy, error)
+
+// KibanaFleetProxyCreate permit to create proxy
+type KibanaFleetProxyCreate func(proxy *Proxy) (*Proxy, error)
+
+// KibanaFleetProxyUpdate permit to update proxy
+type KibanaFleetProxyUpdate func(proxy *Proxy) (*Proxy, error)
+
+// KibanaFleetProxyDelete permit to delete proxy
+type KibanaFleetProxyDelete func(id string) error
+
+// String permit to return Proxy object as JSON string, without certificate key
+func (o *Proxy) String() string {
+	proxy := *o
+	if proxy.CertificateKey != "" {
+		proxy.CertificateKey = "[REDACTED]"
+	}
+	json, _ := json.Marshal(proxy)
+	return string(json)
+}
+
+// newKibanaFleetProxyListFunc permit to list proxies
+func newKibanaFleetProxyListFunc(c *resty.Client) KibanaFleetProxyList {
+	return func() (*ProxyListResponse, error) {
+
+		proxyListResponse := &ProxyListResponse{}
+		path := fmt.Sprintf("%s/proxies", basePathKibanaFleet)
+		err := listFleetItems(c, path, nil, proxyListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Proxies: ", len(proxyListResponse.Items))
+
+		return proxyListResponse, nil
+	}
+}
+
+// newKibanaFleetProxyGetFunc permit to get proxy with it ID
+func newKibanaFleetProxyGetFunc(c *resty.Client) KibanaFleetProxyGet {
+	return func(id string) (*Proxy, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide proxy ID")
+		}
+		log.Debug("ID: ", id)
+
+		proxy := &Proxy{}
+		path := fmt.Sprintf("%s/proxies/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, proxy)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("Proxy: ", proxy)
+
+		return proxy, nil
+	}
+}
+
+// newKibanaFleetProxyCreateFunc permit to create proxy
+func newKibanaFleetProxyCreateFunc(c *resty.Client) KibanaFleetProxyCreate {
+	return func(proxy *Proxy) (*Proxy, error) {
+
+		if proxy == nil {
+			return nil, NewAPIError(600, "You must provide proxy object")
+		}
+		if proxy.Name == "" || proxy.URL == "" {
+			return nil, NewAPIError(600, "You must provide proxy name and URL")
+		}
+		log.Debug("Proxy: ", proxy)
+
+		request := *proxy
+		request.IsPreconfigured = false
+		result := &Proxy{}
+		path := fmt.Sprintf("%s/proxies", basePathKibanaFleet)
+		err := sendFleetItem(c, http.MethodPost, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Proxy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetProxyUpdateFunc permit to update proxy
+func newKibanaFleetProxyUpdateFunc(c *resty.Client) KibanaFleetProxyUpdate {
+	return func(proxy *Proxy) (*Proxy, error) {
+
+		if proxy == nil {
+			return nil, NewAPIError(600, "You must provide proxy object")
+		}
+		if proxy.ID == "" {
+			return nil, NewAPIError(600, "You must provide proxy ID")
+		}
+		log.Debug("Proxy: ", proxy)
+
+		request := *proxy
+		request.ID = ""
+		request.IsPreconfigured = false
+		result := &Proxy{}
+		path := fmt.Sprintf("%s/proxies/%s", basePathKibanaFleet, proxy.ID)
+		err := sendFleetItem(c, http.MethodPut, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Proxy: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetProxyDeleteFunc permit to delete proxy with it ID
+func newKibanaFleetProxyDeleteFunc(c *resty.Client) KibanaFleetProxyDelete {
+	return func(id string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide proxy ID")
+		}
+		log.Debug("ID: ", id)
+
+		path := fmt.Sprintf("%s/proxies/%s", basePathKibanaFleet, id)
+		return deleteFleetItem(c, path)
+	}
+}
@@ -0,0 +1,44 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetProxies() {
+
+	// Create new proxy
+	proxy, err := s.API.KibanaFleet.Proxies.Create(&Proxy{
+		Name: "test-proxy",
+		URL:  "http://proxy.local:3128",
+	})
+	if err != nil && err.(APIError).Code == 404 {
+		s.T().Log("Fleet proxies API not available")
+		return
+	}
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), proxy)
+	id := proxy.ID
+
+	// Get proxy
+	proxy, err = s.API.KibanaFleet.Proxies.Get(id)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), proxy)
+
+	// List proxies
+	proxyListResponse, err := s.API.KibanaFleet.Proxies.List()
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), proxyListResponse.Items)
+
+	// Update proxy
+	proxy.URL = "http://proxy.local:8080"
+	proxy, err = s.API.KibanaFleet.Proxies.Update(proxy)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "http://proxy.local:8080", proxy.URL)
+
+	// Delete proxy
+	err = s.API.KibanaFleet.Proxies.Delete(id)
+	assert.NoError(s.T(), err)
+	proxy, err = s.API.KibanaFleet.Proxies.Get(id)
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), proxy)
+}
@@ -0,0 +1,157 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// FleetServerHost is the fleet server URLs where agents connect
+type FleetServerHost struct {
+	ID              string   `json:"id,omitempty"`
+	Name            string   `json:"name"`
+	HostURLs        []string `json:"host_urls"`
+	IsDefault       bool     `json:"is_default"`
+	ProxyID         *string  `json:"proxy_id,omitempty"`
+	IsPreconfigured bool     `json:"is_preconfigured,omitempty"`
+}
+
+// FleetServerHosts is list of FleetServerHost object
+type FleetServerHosts []FleetServerHost
+
+// FleetServerHostListResponse is the result when list fleet server hosts
+type FleetServerHostListResponse struct {
+	FleetPage
+	Items FleetServerHosts `json:"items"`
+}
+
+// KibanaFleetServerHostList permit to list fleet server hosts
+type KibanaFleetServerHostList func() (*FleetServerHostListResponse, error)
+
+// KibanaFleetServerHostGet permit to get fleet server host
+type KibanaFleetServerHostGet func(id string) (*FleetServerHost, error)
+
+// KibanaFleetServerHostCreate permit to create fleet server host
+type KibanaFleetServerHostCreate func(fleetServerHost *FleetServerHost) (*FleetServerHost, error)
+
+// KibanaFleetServerHostUpdate permit to update fleet server host
+type KibanaFleetServerHostUpdate func(fleetServerHost *FleetServerHost) (*FleetServerHost, error)
+
+// KibanaFleetServerHostDelete permit to delete fleet server host
+type KibanaFleetServerHostDelete func(id string) error
+
+// String permit to return FleetServerHost object as JSON string
+func (o *FleetServerHost) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// newKibanaFleetServerHostListFunc permit to list fleet server hosts
+func newKibanaFleetServerHostListFunc(c *resty.Client) KibanaFleetServerHostList {
+	return func() (*FleetServerHostListResponse, error) {
+
+		fleetServerHostListResponse := &FleetServerHostListResponse{}
+		path := fmt.Sprintf("%s/fleet_server_hosts", basePathKibanaFleet)
+		err := listFleetItems(c, path, nil, fleetServerHostListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("FleetServerHosts: ", fleetServerHostListResponse.Items)
+
+		return fleetServerHostListResponse, nil
+	}
+}
+
+// newKibanaFleetServerHostGetFunc permit to get fleet server host with it ID
+func newKibanaFleetServerHostGetFunc(c *resty.Client) KibanaFleetServerHostGet {
+	return func(id string) (*FleetServerHost, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide fleet server host ID")
+		}
+		log.Debug("ID: ", id)
+
+		fleetServerHost := &FleetServerHost{}
+		path := fmt.Sprintf("%s/fleet_server_hosts/%s", basePathKibanaFleet, id)
+		found, err := getFleetItem(c, path, nil, fleetServerHost)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("FleetServerHost: ", fleetServerHost)
+
+		return fleetServerHost, nil
+	}
+}
+
+// newKibanaFleetServerHostCreateFunc permit to create fleet server host
+func newKibanaFleetServerHostCreateFunc(c *resty.Client) KibanaFleetServerHostCreate {
+	return func(fleetServerHost *FleetServerHost) (*FleetServerHost, error) {
+
+		if fleetServerHost == nil {
+			return nil, NewAPIError(600, "You must provide fleet server host object")
+		}
+		if fleetServerHost.Name == "" || len(fleetServerHost.HostURLs) == 0 {
+			return nil, NewAPIError(600, "You must provide fleet server host name and URLs")
+		}
+		log.Debug("FleetServerHost: ", fleetServerHost)
+
+		request := *fleetServerHost
+		request.IsPreconfigured = false
+		result := &FleetServerHost{}
+		path := fmt.Sprintf("%s/fleet_server_hosts", basePathKibanaFleet)
+		err := sendFleetItem(c, http.MethodPost, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("FleetServerHost: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetServerHostUpdateFunc permit to update fleet server host
+func newKibanaFleetServerHostUpdateFunc(c *resty.Client) KibanaFleetServerHostUpdate {
+	return func(fleetServerHost *FleetServerHost) (*FleetServerHost, error) {
+
+		if fleetServerHost == nil {
+			return nil, NewAPIError(600, "You must provide fleet server host object")
+		}
+		if fleetServerHost.ID == "" {
+			return nil, NewAPIError(600, "You must provide fleet server host ID")
+		}
+		log.Debug("FleetServerHost: ", fleetServerHost)
+
+		request := *fleetServerHost
+		request.ID = ""
+		request.IsPreconfigured = false
+		result := &FleetServerHost{}
+		path := fmt.Sprintf("%s/fleet_server_hosts/%s", basePathKibanaFleet, fleetServerHost.ID)
+		err := sendFleetItem(c, http.MethodPut, path, nil, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("FleetServerHost: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaFleetServerHostDeleteFunc permit to delete fleet server host with it ID
+func newKibanaFleetServerHostDeleteFunc(c *resty.Client) KibanaFleetServerHostDelete {
+	return func(id string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide fleet server host ID")
+		}
+		log.Debug("ID: ", id)
+
+		path := fmt.Sprintf("%s/fleet_server_hosts/%s", basePathKibanaFleet, id)
+		return deleteFleetItem(c, path)
+	}
+}
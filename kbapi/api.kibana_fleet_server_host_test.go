@@ -0,0 +1,44 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaFleetServerHosts() {
+
+	// Create new fleet server host
+	fleetServerHost, err := s.API.KibanaFleet.FleetServerHosts.Create(&FleetServerHost{
+		Name:     "test-fleet-server-host",
+		HostURLs: []string{"https://localhost:8220"},
+	})
+	if err != nil && err.(APIError).Code == 404 {
+		s.T().Log("Fleet server hosts API not available")
+		return
+	}
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), fleetServerHost)
+	id := fleetServerHost.ID
+
+	// Get fleet server host
+	fleetServerHost, err = s.API.KibanaFleet.FleetServerHosts.Get(id)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), fleetServerHost)
+
+	// List fleet server hosts
+	fleetServerHostListResponse, err := s.API.KibanaFleet.FleetServerHosts.List()
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), fleetServerHostListResponse.Items)
+
+	// Update fleet server host
+	fleetServerHost.HostURLs = []string{"https://localhost:8221"}
+	fleetServerHost, err = s.API.KibanaFleet.FleetServerHosts.Update(fleetServerHost)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"https://localhost:8221"}, fleetServerHost.HostURLs)
+
+	// Delete fleet server host
+	err = s.API.KibanaFleet.FleetServerHosts.Delete(id)
+	assert.NoError(s.T(), err)
+	fleetServerHost, err = s.API.KibanaFleet.FleetServerHosts.Get(id)
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), fleetServerHost)
+}
@@ -0,0 +1,55 @@
+package kbapi
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKibanaFleetLogRedaction(t *testing.T) {
+
+	// Fake fleet API that return secrets
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		switch {
+		case strings.HasPrefix(r.Path, "/api/fleet/enrollment_api_keys"):
+			return http.StatusOK, `{"item": {"id": "key1", "api_key": "secret-enrollment-key", "active": true}, "items": [{"id": "key1", "api_key": "secret-enrollment-key"}], "page": 1, "perPage": 20, "total": 1}`
+		case strings.HasPrefix(r.Path, "/api/fleet/outputs"):
+			return http.StatusOK, `{"item": {"id": "output1", "name": "test", "type": "elasticsearch", "ssl": {"key": "secret-ssl-key"}, "secrets": {"ssl": {"key": "secret-ssl-key"}}}}`
+		default:
+			return http.StatusNotFound, `{}`
+		}
+	})
+	api := kibana.API
+
+	// Capture debug logs
+	var logs bytes.Buffer
+	logrus.SetOutput(&logs)
+	defer logrus.SetOutput(os.Stderr)
+	defer logrus.SetLevel(logrus.GetLevel())
+	logrus.SetLevel(logrus.DebugLevel)
+
+	enrollmentAPIKey, err := api.KibanaFleet.EnrollmentAPIKeys.Get("key1")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret-enrollment-key", enrollmentAPIKey.APIKey)
+	enrollmentAPIKeys, err := api.KibanaFleet.EnrollmentAPIKeys.List(nil)
+	assert.NoError(t, err)
+	assert.Len(t, enrollmentAPIKeys.Items, 1)
+	enrollmentAPIKey, err = api.KibanaFleet.EnrollmentAPIKeys.Create("test", "policy1")
+	assert.NoError(t, err)
+	assert.NotNil(t, enrollmentAPIKey)
+
+	output, err := api.KibanaFleet.Outputs.Get("output1")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret-ssl-key", output.SSL.Key)
+	_, err = api.KibanaFleet.Outputs.Update(output)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, logs.String())
+	assert.NotContains(t, logs.String(), "secret-enrollment-key")
+	assert.NotContains(t, logs.String(), "secret-ssl-key")
+}
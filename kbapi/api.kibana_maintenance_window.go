@@ -0,0 +1,712 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaMaintenanceWindow         = "/api/maintenance_window"                     // Base URL to access on Kibana maintenance windows API, since 9.1
+	basePathKibanaMaintenanceWindowInternal = "/internal/alerting/rules/maintenance_window" // Base URL to access on Kibana maintenance windows internal API, used before 9.1
+)
+
+// MaintenanceWindowFrequency is the recurrence frequency of maintenance window, like RRULE FREQ
+type MaintenanceWindowFrequency int
+
+// Recurrence frequencies of maintenance window
+const (
+	MaintenanceWindowFrequencyYearly MaintenanceWindowFrequency = iota
+	MaintenanceWindowFrequencyMonthly
+	MaintenanceWindowFrequencyWeekly
+	MaintenanceWindowFrequencyDaily
+	MaintenanceWindowFrequencyHourly
+	MaintenanceWindowFrequencyMinutely
+	MaintenanceWindowFrequencySecondly
+)
+
+// weekDayRegexp is the RRULE BYDAY format, like MO or +1MO or -1FR
+var weekDayRegexp = regexp.MustCompile(`^[+-]?[1-5]?(MO|TU|WE|TH|FR|SA|SU)$`)
+
+// maintenanceWindowDurationUnits is the duration units of public API, from the largest
+var maintenanceWindowDurationUnits = []struct {
+	unit         string
+	milliseconds int64
+}{
+	{unit: "d", milliseconds: 24 * 60 * 60 * 1000},
+	{unit: "h", milliseconds: 60 * 60 * 1000},
+	{unit: "m", milliseconds: 60 * 1000},
+	{unit: "s", milliseconds: 1000},
+}
+
+// maintenanceWindowFrequencyUnits is the recurrence units of public API, by frequency
+var maintenanceWindowFrequencyUnits = map[MaintenanceWindowFrequency]string{
+	MaintenanceWindowFrequencyYearly:  "y",
+	MaintenanceWindowFrequencyMonthly: "M",
+	MaintenanceWindowFrequencyWeekly:  "w",
+	MaintenanceWindowFrequencyDaily:   "d",
+}
+
+// maintenanceWindowIntervalRegexp is the duration and recurrence format of public API, like 2h or 1w
+var maintenanceWindowIntervalRegexp = regexp.MustCompile(`^(\d+)([a-zA-Z])$`)
+
+// MaintenanceWindow is the maintenance window object
+type MaintenanceWindow struct {
+	ID             string                   `json:"id,omitempty"`
+	Title          string                   `json:"title"`
+	Enabled        *bool                    `json:"enabled,omitempty"`
+	Duration       int64                    `json:"duration"`
+	RRule          *MaintenanceWindowRRule  `json:"r_rule"`
+	CategoryIDs    []string                 `json:"category_ids,omitempty"`
+	ScopedQuery    map[string]interface{}   `json:"scoped_query,omitempty"`
+	Status         string                   `json:"status,omitempty"`
+	ExpirationDate string                   `json:"expiration_date,omitempty"`
+	Events         []MaintenanceWindowEvent `json:"events,omitempty"`
+	EventStartTime string                   `json:"event_start_time,omitempty"`
+	EventEndTime   string                   `json:"event_end_time,omitempty"`
+	CreatedBy      string                   `json:"created_by,omitempty"`
+	UpdatedBy      string                   `json:"updated_by,omitempty"`
+	CreatedAt      string                   `json:"created_at,omitempty"`
+	UpdatedAt      string                   `json:"updated_at,omitempty"`
+}
+
+// MaintenanceWindowRRule is the schedule of maintenance window, with the RRULE (RFC 5545) parameters.
+// Without frequency, the maintenance window occur only once at start date.
+type MaintenanceWindowRRule struct {
+	DTStart    string                      `json:"dtstart"`
+	TZID       string                      `json:"tzid"`
+	Freq       *MaintenanceWindowFrequency `json:"freq,omitempty"`
+	Interval   int                         `json:"interval,omitempty"`
+	Until      string                      `json:"until,omitempty"`
+	Count      int                         `json:"count,omitempty"`
+	ByWeekDay  []string                    `json:"byweekday,omitempty"`
+	ByMonthDay []int                       `json:"bymonthday,omitempty"`
+	ByMonth    []int                       `json:"bymonth,omitempty"`
+	WKST       string                      `json:"wkst,omitempty"`
+}
+
+// MaintenanceWindowEvent is one occurrence of maintenance window
+type MaintenanceWindowEvent struct {
+	GTE string `json:"gte"`
+	LTE string `json:"lte"`
+}
+
+// MaintenanceWindows is list of MaintenanceWindow object
+type MaintenanceWindows []MaintenanceWindow
+
+// MaintenanceWindowFindResponse is the result when find maintenance windows
+type MaintenanceWindowFindResponse struct {
+	Page    int                `json:"page"`
+	PerPage int                `json:"per_page"`
+	Total   int                `json:"total"`
+	Data    MaintenanceWindows `json:"data"`
+}
+
+// maintenanceWindowRequest is the maintenance window attributes that can be written
+type maintenanceWindowRequest struct {
+	Title       string                  `json:"title,omitempty"`
+	Enabled     *bool                   `json:"enabled,omitempty"`
+	Duration    int64                   `json:"duration,omitempty"`
+	RRule       *MaintenanceWindowRRule `json:"r_rule,omitempty"`
+	CategoryIDs []string                `json:"category_ids,omitempty"`
+	ScopedQuery map[string]interface{}  `json:"scoped_query,omitempty"`
+}
+
+// maintenanceWindowPublic is the maintenance window object of public API
+type maintenanceWindowPublic struct {
+	ID        string                           `json:"id,omitempty"`
+	Title     string                           `json:"title,omitempty"`
+	Enabled   *bool                            `json:"enabled,omitempty"`
+	Status    string                           `json:"status,omitempty"`
+	Schedule  *maintenanceWindowPublicSchedule `json:"schedule,omitempty"`
+	Scope     *maintenanceWindowPublicScope    `json:"scope,omitempty"`
+	CreatedBy string                           `json:"created_by,omitempty"`
+	UpdatedBy string                           `json:"updated_by,omitempty"`
+	CreatedAt string                           `json:"created_at,omitempty"`
+	UpdatedAt string                           `json:"updated_at,omitempty"`
+}
+
+// maintenanceWindowPublicSchedule is the schedule of maintenance window on public API
+type maintenanceWindowPublicSchedule struct {
+	Custom maintenanceWindowPublicCustomSchedule `json:"custom"`
+}
+
+// maintenanceWindowPublicCustomSchedule is the start, duration and recurrence of maintenance window on public API
+type maintenanceWindowPublicCustomSchedule struct {
+	Start     string                            `json:"start"`
+	Duration  string                            `json:"duration"`
+	Timezone  string                            `json:"timezone,omitempty"`
+	Recurring *maintenanceWindowPublicRecurring `json:"recurring,omitempty"`
+}
+
+// maintenanceWindowPublicRecurring is the recurrence of maintenance window on public API
+type maintenanceWindowPublicRecurring struct {
+	End         string   `json:"end,omitempty"`
+	Every       string   `json:"every,omitempty"`
+	Occurrences int      `json:"occurrences,omitempty"`
+	OnWeekDay   []string `json:"onWeekDay,omitempty"`
+	OnMonthDay  []int    `json:"onMonthDay,omitempty"`
+	OnMonth     []int    `json:"onMonth,omitempty"`
+}
+
+// maintenanceWindowPublicScope is the alerts affected by maintenance window on public API
+type maintenanceWindowPublicScope struct {
+	Alerting struct {
+		Query struct {
+			KQL string `json:"kql"`
+		} `json:"query"`
+	} `json:"alerting"`
+}
+
+// KibanaMaintenanceWindowCreate permit to create maintenance window
+type KibanaMaintenanceWindowCreate func(maintenanceWindow *MaintenanceWindow, kibanaSpace string) (*MaintenanceWindow, error)
+
+// KibanaMaintenanceWindowGet permit to get maintenance window
+type KibanaMaintenanceWindowGet func(id string, kibanaSpace string) (*MaintenanceWindow, error)
+
+// KibanaMaintenanceWindowUpdate permit to update maintenance window
+type KibanaMaintenanceWindowUpdate func(maintenanceWindow *MaintenanceWindow, kibanaSpace string) (*MaintenanceWindow, error)
+
+// KibanaMaintenanceWindowDelete permit to delete maintenance window
+type KibanaMaintenanceWindowDelete func(id string, kibanaSpace string) error
+
+// KibanaMaintenanceWindowFind permit to find maintenance windows
+type KibanaMaintenanceWindowFind func(kibanaSpace string) (*MaintenanceWindowFindResponse, error)
+
+// KibanaMaintenanceWindowArchive permit to archive maintenance window
+type KibanaMaintenanceWindowArchive func(id string, kibanaSpace string) (*MaintenanceWindow, error)
+
+// KibanaMaintenanceWindowUnarchive permit to unarchive maintenance window
+type KibanaMaintenanceWindowUnarchive func(id string, kibanaSpace string) (*MaintenanceWindow, error)
+
+// KibanaMaintenanceWindowFinish permit to finish the running occurrence of maintenance window.
+// There are no public API for it, so it always use the internal API, that is not available on serverless.
+type KibanaMaintenanceWindowFinish func(id string, kibanaSpace string) (*MaintenanceWindow, error)
+
+// String permit to return MaintenanceWindow object as JSON string
+func (o *MaintenanceWindow) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// Validate check the maintenance window before send it to Kibana
+func (o *MaintenanceWindow) Validate() error {
+	if o.Title == "" {
+		return NewAPIError(600, "You must provide maintenance window title")
+	}
+	if o.Duration <= 0 {
+		return NewAPIError(600, "Maintenance window duration must be positive, in milliseconds")
+	}
+	if o.RRule == nil {
+		return NewAPIError(600, "You must provide maintenance window schedule")
+	}
+
+	return o.RRule.Validate()
+}
+
+// Validate check the recurrence parameters of maintenance window schedule
+func (o *MaintenanceWindowRRule) Validate() error {
+	var errors []string
+
+	dtStart, err := time.Parse(time.RFC3339, o.DTStart)
+	if err != nil {
+		errors = append(errors, fmt.Sprintf("dtstart '%s' must be RFC3339 date", o.DTStart))
+	}
+	if o.TZID == "" {
+		errors = append(errors, "tzid must be provided")
+	}
+
+	if o.Freq == nil {
+		if o.Interval != 0 || o.Until != "" || o.Count != 0 || len(o.ByWeekDay) > 0 || len(o.ByMonthDay) > 0 || len(o.ByMonth) > 0 {
+			errors = append(errors, "freq must be provided to use recurrence parameters")
+		}
+	} else if *o.Freq < MaintenanceWindowFrequencyYearly || *o.Freq > MaintenanceWindowFrequencySecondly {
+		errors = append(errors, fmt.Sprintf("freq %d is not valid frequency", *o.Freq))
+	}
+
+	if o.Interval < 0 {
+		errors = append(errors, "interval must be positive")
+	}
+	if o.Count < 0 {
+		errors = append(errors, "count must be positive")
+	}
+	if o.Until != "" {
+		if o.Count != 0 {
+			errors = append(errors, "until and count can't be used together")
+		}
+		until, err := time.Parse(time.RFC3339, o.Until)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("until '%s' must be RFC3339 date", o.Until))
+		} else if !dtStart.IsZero() && until.Before(dtStart) {
+			errors = append(errors, "until must be after dtstart")
+		}
+	}
+	for _, weekDay := range o.ByWeekDay {
+		if !weekDayRegexp.MatchString(weekDay) {
+			errors = append(errors, fmt.Sprintf("byweekday '%s' must be like MO, +1MO or -1FR", weekDay))
+		}
+	}
+	for _, monthDay := range o.ByMonthDay {
+		if monthDay == 0 || monthDay < -31 || monthDay > 31 {
+			errors = append(errors, fmt.Sprintf("bymonthday %d must be between 1 and 31 or -31 and -1", monthDay))
+		}
+	}
+	for _, month := range o.ByMonth {
+		if month < 1 || month > 12 {
+			errors = append(errors, fmt.Sprintf("bymonth %d must be between 1 and 12", month))
+		}
+	}
+
+	if len(errors) > 0 {
+		return NewAPIError(600, "Maintenance window schedule is not valid: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+// toRequest return the attributes of maintenance window that can be written
+func (o *MaintenanceWindow) toRequest() *maintenanceWindowRequest {
+	return &maintenanceWindowRequest{
+		Title:       o.Title,
+		Enabled:     o.Enabled,
+		Duration:    o.Duration,
+		RRule:       o.RRule,
+		CategoryIDs: o.CategoryIDs,
+		ScopedQuery: o.ScopedQuery,
+	}
+}
+
+// toPublicRequest return the attributes of maintenance window that can be written with public API
+func (o *MaintenanceWindow) toPublicRequest() (*maintenanceWindowPublic, error) {
+	if len(o.CategoryIDs) > 0 {
+		return nil, NewAPIError(600, "Maintenance window category IDs are not supported by public API")
+	}
+
+	duration := ""
+	for _, durationUnit := range maintenanceWindowDurationUnits {
+		if o.Duration%durationUnit.milliseconds == 0 {
+			duration = fmt.Sprintf("%d%s", o.Duration/durationUnit.milliseconds, durationUnit.unit)
+			break
+		}
+	}
+	if duration == "" {
+		return nil, NewAPIError(600, "Maintenance window duration must be in seconds with public API")
+	}
+	request := &maintenanceWindowPublic{
+		Title:   o.Title,
+		Enabled: o.Enabled,
+		Schedule: &maintenanceWindowPublicSchedule{
+			Custom: maintenanceWindowPublicCustomSchedule{
+				Start:    o.RRule.DTStart,
+				Duration: duration,
+				Timezone: o.RRule.TZID,
+			},
+		},
+	}
+
+	if o.RRule.Freq != nil {
+		unit, ok := maintenanceWindowFrequencyUnits[*o.RRule.Freq]
+		if !ok {
+			return nil, NewAPIError(600, "Maintenance window frequency %d is not supported by public API", *o.RRule.Freq)
+		}
+		interval := o.RRule.Interval
+		if interval == 0 {
+			interval = 1
+		}
+		request.Schedule.Custom.Recurring = &maintenanceWindowPublicRecurring{
+			End:         o.RRule.Until,
+			Every:       fmt.Sprintf("%d%s", interval, unit),
+			Occurrences: o.RRule.Count,
+			OnWeekDay:   o.RRule.ByWeekDay,
+			OnMonthDay:  o.RRule.ByMonthDay,
+			OnMonth:     o.RRule.ByMonth,
+		}
+	}
+
+	if o.ScopedQuery != nil {
+		if filters, ok := o.ScopedQuery["filters"].([]interface{}); ok && len(filters) > 0 {
+			return nil, NewAPIError(600, "Maintenance window scoped query filters are not supported by public API, use kql")
+		}
+		kql, _ := o.ScopedQuery["kql"].(string)
+		request.Scope = &maintenanceWindowPublicScope{}
+		request.Scope.Alerting.Query.KQL = kql
+	}
+
+	return request, nil
+}
+
+// toMaintenanceWindow return the maintenance window read from public API
+func (o *maintenanceWindowPublic) toMaintenanceWindow() *MaintenanceWindow {
+	maintenanceWindow := &MaintenanceWindow{
+		ID:        o.ID,
+		Title:     o.Title,
+		Enabled:   o.Enabled,
+		Status:    o.Status,
+		CreatedBy: o.CreatedBy,
+		UpdatedBy: o.UpdatedBy,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+
+	if o.Schedule != nil {
+		schedule := o.Schedule.Custom
+		maintenanceWindow.RRule = &MaintenanceWindowRRule{
+			DTStart: schedule.Start,
+			TZID:    schedule.Timezone,
+		}
+		if match := maintenanceWindowIntervalRegexp.FindStringSubmatch(schedule.Duration); match != nil {
+			value, _ := strconv.ParseInt(match[1], 10, 64)
+			for _, durationUnit := range maintenanceWindowDurationUnits {
+				if durationUnit.unit == match[2] {
+					maintenanceWindow.Duration = value * durationUnit.milliseconds
+				}
+			}
+		}
+		if recurring := schedule.Recurring; recurring != nil {
+			maintenanceWindow.RRule.Until = recurring.End
+			maintenanceWindow.RRule.Count = recurring.Occurrences
+			maintenanceWindow.RRule.ByWeekDay = recurring.OnWeekDay
+			maintenanceWindow.RRule.ByMonthDay = recurring.OnMonthDay
+			maintenanceWindow.RRule.ByMonth = recurring.OnMonth
+			if match := maintenanceWindowIntervalRegexp.FindStringSubmatch(recurring.Every); match != nil {
+				maintenanceWindow.RRule.Interval, _ = strconv.Atoi(match[1])
+				for freq, unit := range maintenanceWindowFrequencyUnits {
+					if unit == match[2] {
+						freq := freq
+						maintenanceWindow.RRule.Freq = &freq
+					}
+				}
+			}
+		}
+	}
+
+	if o.Scope != nil {
+		maintenanceWindow.ScopedQuery = map[string]interface{}{
+			"kql": o.Scope.Alerting.Query.KQL,
+		}
+	}
+
+	return maintenanceWindow
+}
+
+// maintenanceWindowUsePublicAPI return true when Kibana has the public maintenance window API.
+// Kibana from 8.8 to 9.0 has only the internal API, and older Kibana has not maintenance window.
+// The error is returned when the version can't be read, because the API can't be selected.
+func maintenanceWindowUsePublicAPI(versionCache *kibanaVersionCache) (bool, error) {
+	version, err := versionCache.get()
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case version.AtLeast(9, 1, 0):
+		return true, nil
+	case version.AtLeast(8, 8, 0):
+		return false, nil
+	default:
+		return false, NewUnsupportedVersionError("Maintenance window API", version)
+	}
+}
+
+// newMaintenanceWindowRequest return request and base path to call maintenance window API
+func newMaintenanceWindowRequest(c *resty.Client, public bool) (*resty.Request, string) {
+	if public {
+		return c.R(), basePathKibanaMaintenanceWindow
+	}
+	return newInternalRequest(c), basePathKibanaMaintenanceWindowInternal
+}
+
+// newKibanaMaintenanceWindowCreateFunc permit to create maintenance window
+func newKibanaMaintenanceWindowCreateFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowCreate {
+	return func(maintenanceWindow *MaintenanceWindow, kibanaSpace string) (*MaintenanceWindow, error) {
+
+		if maintenanceWindow == nil {
+			return nil, NewAPIError(600, "You must provide maintenance window object")
+		}
+		if err := maintenanceWindow.Validate(); err != nil {
+			return nil, err
+		}
+		log.Debug("MaintenanceWindow: ", maintenanceWindow)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return nil, err
+		}
+		var request interface{}
+		if public {
+			if request, err = maintenanceWindow.toPublicRequest(); err != nil {
+				return nil, err
+			}
+		} else {
+			// Enabled state can't be set on creation with internal API
+			internalRequest := maintenanceWindow.toRequest()
+			internalRequest.Enabled = nil
+			request = internalRequest
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		req, basePath := newMaintenanceWindowRequest(c, public)
+		resp, err := req.SetBody(jsonData).Post(spacePath(kibanaSpace, basePath))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalMaintenanceWindow(resp.Body(), public)
+	}
+}
+
+// newKibanaMaintenanceWindowGetFunc permit to get maintenance window with it ID
+func newKibanaMaintenanceWindowGetFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowGet {
+	return func(id string, kibanaSpace string) (*MaintenanceWindow, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide maintenance window ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return nil, err
+		}
+		req, basePath := newMaintenanceWindowRequest(c, public)
+		resp, err := req.Get(spacePath(kibanaSpace, fmt.Sprintf("%s/%s", basePath, id)))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalMaintenanceWindow(resp.Body(), public)
+	}
+}
+
+// newKibanaMaintenanceWindowUpdateFunc permit to update maintenance window
+func newKibanaMaintenanceWindowUpdateFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowUpdate {
+	return func(maintenanceWindow *MaintenanceWindow, kibanaSpace string) (*MaintenanceWindow, error) {
+
+		if maintenanceWindow == nil {
+			return nil, NewAPIError(600, "You must provide maintenance window object")
+		}
+		if maintenanceWindow.ID == "" {
+			return nil, NewAPIError(600, "You must provide maintenance window ID")
+		}
+		if err := maintenanceWindow.Validate(); err != nil {
+			return nil, err
+		}
+		log.Debug("MaintenanceWindow: ", maintenanceWindow)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return nil, err
+		}
+		var request interface{} = maintenanceWindow.toRequest()
+		method := http.MethodPost
+		if public {
+			if request, err = maintenanceWindow.toPublicRequest(); err != nil {
+				return nil, err
+			}
+			method = http.MethodPatch
+		}
+		jsonData, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		req, basePath := newMaintenanceWindowRequest(c, public)
+		resp, err := req.SetBody(jsonData).Execute(method, spacePath(kibanaSpace, fmt.Sprintf("%s/%s", basePath, maintenanceWindow.ID)))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalMaintenanceWindow(resp.Body(), public)
+	}
+}
+
+// newKibanaMaintenanceWindowDeleteFunc permit to delete maintenance window with it ID
+func newKibanaMaintenanceWindowDeleteFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide maintenance window ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return err
+		}
+		req, basePath := newMaintenanceWindowRequest(c, public)
+		resp, err := req.Delete(spacePath(kibanaSpace, fmt.Sprintf("%s/%s", basePath, id)))
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaMaintenanceWindowFindFunc permit to find maintenance windows
+func newKibanaMaintenanceWindowFindFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowFind {
+	return func(kibanaSpace string) (*MaintenanceWindowFindResponse, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return nil, err
+		}
+		req, basePath := newMaintenanceWindowRequest(c, public)
+		resp, err := req.Get(spacePath(kibanaSpace, fmt.Sprintf("%s/_find", basePath)))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		maintenanceWindowFindResponse := &MaintenanceWindowFindResponse{}
+		if public {
+			response := struct {
+				Page               int                       `json:"page"`
+				PerPage            int                       `json:"per_page"`
+				Total              int                       `json:"total"`
+				MaintenanceWindows []maintenanceWindowPublic `json:"maintenanceWindows"`
+			}{}
+			if err = json.Unmarshal(resp.Body(), &response); err != nil {
+				return nil, err
+			}
+			maintenanceWindowFindResponse.Page = response.Page
+			maintenanceWindowFindResponse.PerPage = response.PerPage
+			maintenanceWindowFindResponse.Total = response.Total
+			maintenanceWindowFindResponse.Data = make(MaintenanceWindows, 0, len(response.MaintenanceWindows))
+			for i := range response.MaintenanceWindows {
+				maintenanceWindowFindResponse.Data = append(maintenanceWindowFindResponse.Data, *response.MaintenanceWindows[i].toMaintenanceWindow())
+			}
+		} else if err = json.Unmarshal(resp.Body(), maintenanceWindowFindResponse); err != nil {
+			return nil, err
+		}
+		log.Debug("MaintenanceWindows: ", maintenanceWindowFindResponse.Data)
+
+		return maintenanceWindowFindResponse, nil
+	}
+}
+
+// newKibanaMaintenanceWindowArchiveFunc permit to archive maintenance window
+func newKibanaMaintenanceWindowArchiveFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowArchive {
+	return func(id string, kibanaSpace string) (*MaintenanceWindow, error) {
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return nil, err
+		}
+		if public {
+			return postMaintenanceWindowAction(c, true, id, "_archive", nil, kibanaSpace)
+		}
+		return postMaintenanceWindowAction(c, false, id, "_archive", map[string]interface{}{"archive": true}, kibanaSpace)
+	}
+}
+
+// newKibanaMaintenanceWindowUnarchiveFunc permit to unarchive maintenance window
+func newKibanaMaintenanceWindowUnarchiveFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowUnarchive {
+	return func(id string, kibanaSpace string) (*MaintenanceWindow, error) {
+		public, err := maintenanceWindowUsePublicAPI(versionCache)
+		if err != nil {
+			return nil, err
+		}
+		if public {
+			return postMaintenanceWindowAction(c, true, id, "_unarchive", nil, kibanaSpace)
+		}
+		return postMaintenanceWindowAction(c, false, id, "_archive", map[string]interface{}{"archive": false}, kibanaSpace)
+	}
+}
+
+// newKibanaMaintenanceWindowFinishFunc permit to finish the running occurrence of maintenance window
+func newKibanaMaintenanceWindowFinishFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaMaintenanceWindowFinish {
+	return func(id string, kibanaSpace string) (*MaintenanceWindow, error) {
+		// Only check that Kibana has maintenance window, finish action exist only on internal API
+		if _, err := maintenanceWindowUsePublicAPI(versionCache); err != nil {
+			return nil, err
+		}
+		return postMaintenanceWindowAction(c, false, id, "_finish", nil, kibanaSpace)
+	}
+}
+
+// postMaintenanceWindowAction permit to call action on maintenance window
+func postMaintenanceWindowAction(c *resty.Client, public bool, id string, action string, payload map[string]interface{}, kibanaSpace string) (*MaintenanceWindow, error) {
+
+	if id == "" {
+		return nil, NewAPIError(600, "You must provide maintenance window ID")
+	}
+	log.Debug("ID: ", id)
+	log.Debug("Action: ", action)
+	log.Debug("KibanaSpace: ", kibanaSpace)
+
+	request, basePath := newMaintenanceWindowRequest(c, public)
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		request = request.SetBody(jsonData)
+	}
+	path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/%s", basePath, id, action))
+	resp, err := request.Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return unmarshalMaintenanceWindow(resp.Body(), public)
+}
+
+// unmarshalMaintenanceWindow permit to read maintenance window from response body of public or internal API
+func unmarshalMaintenanceWindow(data []byte, public bool) (*MaintenanceWindow, error) {
+	maintenanceWindow := &MaintenanceWindow{}
+	if public {
+		publicMaintenanceWindow := &maintenanceWindowPublic{}
+		if err := json.Unmarshal(data, publicMaintenanceWindow); err != nil {
+			return nil, err
+		}
+		maintenanceWindow = publicMaintenanceWindow.toMaintenanceWindow()
+	} else if err := json.Unmarshal(data, maintenanceWindow); err != nil {
+		return nil, err
+	}
+	log.Debug("MaintenanceWindow: ", maintenanceWindow)
+
+	return maintenanceWindow, nil
+}
+
+// newInternalRequest return request to call Kibana internal API, used only by Kibana that has not the public API
+func newInternalRequest(c *resty.Client) *resty.Request {
+	return c.R().SetHeader("x-elastic-internal-origin", "Kibana")
+}
@@ -0,0 +1,185 @@
+package kbapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaMaintenanceWindows() {
+
+	// Check recurrence parameters before send them
+	weekly := MaintenanceWindowFrequencyWeekly
+	rRule := &MaintenanceWindowRRule{
+		DTStart:    "2023-01-01T00:00:00Z",
+		TZID:       "UTC",
+		Freq:       &weekly,
+		Until:      "2024-01-01T00:00:00Z",
+		Count:      10,
+		ByWeekDay:  []string{"MO", "XX"},
+		ByMonthDay: []int{0},
+		ByMonth:    []int{13},
+	}
+	err := rRule.Validate()
+	assert.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "until and count")
+	assert.Contains(s.T(), err.Error(), "byweekday 'XX'")
+	assert.Contains(s.T(), err.Error(), "bymonthday 0")
+	assert.Contains(s.T(), err.Error(), "bymonth 13")
+	_, err = s.API.KibanaMaintenanceWindows.Create(&MaintenanceWindow{
+		Title:    "test",
+		Duration: 3600000,
+		RRule:    rRule,
+	}, "default")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// Create new maintenance window
+	maintenanceWindow, err := s.API.KibanaMaintenanceWindows.Create(&MaintenanceWindow{
+		Title:    "test",
+		Duration: 3600000,
+		RRule: &MaintenanceWindowRRule{
+			DTStart:   time.Now().UTC().Format(time.RFC3339),
+			TZID:      "UTC",
+			Freq:      &weekly,
+			Interval:  1,
+			Count:     5,
+			ByWeekDay: []string{"MO", "FR"},
+		},
+	}, "default")
+	if err != nil && err.(APIError).Code == 404 {
+		s.T().Log("Maintenance windows API not available")
+		return
+	}
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), maintenanceWindow)
+	assert.NotEmpty(s.T(), maintenanceWindow.ID)
+	id := maintenanceWindow.ID
+
+	// Get maintenance window
+	maintenanceWindow, err = s.API.KibanaMaintenanceWindows.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), maintenanceWindow)
+	assert.Equal(s.T(), "test", maintenanceWindow.Title)
+	assert.Equal(s.T(), []string{"MO", "FR"}, maintenanceWindow.RRule.ByWeekDay)
+
+	// Find maintenance windows
+	maintenanceWindowFindResponse, err := s.API.KibanaMaintenanceWindows.Find("default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), maintenanceWindowFindResponse.Data)
+
+	// Update maintenance window
+	maintenanceWindow.Title = "test2"
+	maintenanceWindow, err = s.API.KibanaMaintenanceWindows.Update(maintenanceWindow, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test2", maintenanceWindow.Title)
+
+	// Finish maintenance window
+	maintenanceWindow, err = s.API.KibanaMaintenanceWindows.Finish(id, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), maintenanceWindow)
+
+	// Archive and unarchive maintenance window
+	maintenanceWindow, err = s.API.KibanaMaintenanceWindows.Archive(id, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "archived", maintenanceWindow.Status)
+	maintenanceWindow, err = s.API.KibanaMaintenanceWindows.Unarchive(id, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEqual(s.T(), "archived", maintenanceWindow.Status)
+
+	// Delete maintenance window
+	err = s.API.KibanaMaintenanceWindows.Delete(id, "default")
+	assert.NoError(s.T(), err)
+	maintenanceWindow, err = s.API.KibanaMaintenanceWindows.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), maintenanceWindow)
+}
+
+func TestKibanaMaintenanceWindowsAPIVersion(t *testing.T) {
+
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		if strings.HasPrefix(r.Path, basePathKibanaMaintenanceWindowInternal) {
+			return http.StatusOK, `{"id": "mw1", "title": "test", "duration": 5400000, "r_rule": {"dtstart": "2024-01-01T00:00:00Z", "tzid": "UTC", "freq": 2, "interval": 2, "count": 5, "byweekday": ["MO"]}}`
+		}
+		return http.StatusOK, `{"id": "mw1", "title": "test", "enabled": true, "status": "upcoming", "schedule": {"custom": {"start": "2024-01-01T00:00:00Z", "duration": "90m", "timezone": "UTC", "recurring": {"every": "2w", "occurrences": 5, "onWeekDay": ["MO"]}}}, "scope": {"alerting": {"query": {"kql": "host.name: test"}}}}`
+	})
+
+	weekly := MaintenanceWindowFrequencyWeekly
+	maintenanceWindow := &MaintenanceWindow{
+		ID:       "mw1",
+		Title:    "test",
+		Duration: 5400000,
+		RRule: &MaintenanceWindowRRule{
+			DTStart:   "2024-01-01T00:00:00Z",
+			TZID:      "UTC",
+			Freq:      &weekly,
+			Interval:  2,
+			Count:     5,
+			ByWeekDay: []string{"MO"},
+		},
+		ScopedQuery: map[string]interface{}{"kql": "host.name: test"},
+	}
+
+	// API can't be selected when version can't be read
+	_, err := kibana.API.KibanaMaintenanceWindows.Get("mw1", "default")
+	assert.Error(t, err)
+	assert.Empty(t, kibana.Requests)
+
+	// Public API since Kibana 9.1
+	kibana.Version = "9.1.0"
+	kibana.reset()
+	result, err := kibana.API.KibanaMaintenanceWindows.Update(maintenanceWindow, "default")
+	assert.NoError(t, err)
+	request := kibana.lastRequest(t)
+	assert.Equal(t, http.MethodPatch, request.Method)
+	assert.Equal(t, "/api/maintenance_window/mw1", request.Path)
+	assert.Empty(t, request.Header.Get("x-elastic-internal-origin"))
+	body := map[string]interface{}{}
+	request.decodeBody(t, &body)
+	assert.Equal(t, map[string]interface{}{
+		"start":    "2024-01-01T00:00:00Z",
+		"duration": "90m",
+		"timezone": "UTC",
+		"recurring": map[string]interface{}{
+			"every":       "2w",
+			"occurrences": float64(5),
+			"onWeekDay":   []interface{}{"MO"},
+		},
+	}, body["schedule"].(map[string]interface{})["custom"])
+	assert.Equal(t, maintenanceWindow.Duration, result.Duration)
+	assert.Equal(t, maintenanceWindow.RRule, result.RRule)
+	assert.Equal(t, "host.name: test", result.ScopedQuery["kql"])
+	_, err = kibana.API.KibanaMaintenanceWindows.Unarchive("mw1", "default")
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/maintenance_window/mw1/_unarchive", kibana.lastRequest(t).Path)
+	_, err = kibana.API.KibanaMaintenanceWindows.Create(&MaintenanceWindow{Title: "test", Duration: 1500, RRule: maintenanceWindow.RRule}, "default")
+	assert.Error(t, err)
+
+	// Internal API before Kibana 9.1
+	kibana.Version = "8.15.0"
+	kibana.reset()
+	result, err = kibana.API.KibanaMaintenanceWindows.Update(maintenanceWindow, "default")
+	assert.NoError(t, err)
+	request = kibana.lastRequest(t)
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, "/internal/alerting/rules/maintenance_window/mw1", request.Path)
+	assert.Equal(t, "Kibana", request.Header.Get("x-elastic-internal-origin"))
+	assert.Equal(t, maintenanceWindow.RRule, result.RRule)
+	_, err = kibana.API.KibanaMaintenanceWindows.Unarchive("mw1", "default")
+	assert.NoError(t, err)
+	request = kibana.lastRequest(t)
+	assert.Equal(t, "/internal/alerting/rules/maintenance_window/mw1/_archive", request.Path)
+	body = map[string]interface{}{}
+	request.decodeBody(t, &body)
+	assert.Equal(t, map[string]interface{}{"archive": false}, body)
+
+	// Not available before Kibana 8.8
+	kibana.Version = "8.7.0"
+	kibana.reset()
+	_, err = kibana.API.KibanaMaintenanceWindows.Get("mw1", "default")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported by Kibana 8.7.0")
+	assert.Empty(t, kibana.Requests)
+}
@@ -3,22 +3,32 @@ package kbapi
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/go-resty/resty/v2"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	basePathKibanaRoleManagement = "/api/security/role" // Base URL to access on Kibana role management
+	basePathKibanaRoleManagement     = "/api/security/role"  // Base URL to access on Kibana role management
+	basePathKibanaRoleManagementBulk = "/api/security/roles" // Base URL to access on Kibana role management bulk API
+	bulkKibanaRoleConcurrency        = 5                     // Number of parallel calls when bulk API is not available
 )
 
 // KibanaRole is the API role object
 type KibanaRole struct {
 	Name            string                       `json:"name,omitempty"`
+	Description     string                       `json:"description,omitempty"`
 	Metadata        map[string]interface{}       `json:"metadata,omitempty"`
 	TransientMedata *KibanaRoleTransientMetadata `json:"transient_metadata,omitempty"`
 	Elasticsearch   *KibanaRoleElasticsearch     `json:"elasticsearch,omitempty"`
 	Kibana          []KibanaRoleKibana           `json:"kibana,omitempty"`
+
+	// UnknownFields contain the fields returned by Kibana that are not handled by this library.
+	// They are sent back as is when update the role.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // KibanaRoleTransientMetadata is the API TransientMedata object
@@ -28,9 +38,14 @@ type KibanaRoleTransientMetadata struct {
 
 // KibanaRoleElasticsearch is the API Elasticsearch object
 type KibanaRoleElasticsearch struct {
-	Indices []KibanaRoleElasticsearchIndice `json:"indices,omitempty"`
-	Cluster []string                        `json:"cluster,omitempty"`
-	RunAs   []string                        `json:"run_as,omitempty"`
+	Indices       []KibanaRoleElasticsearchIndice        `json:"indices,omitempty"`
+	RemoteIndices []KibanaRoleElasticsearchRemoteIndice  `json:"remote_indices,omitempty"`
+	Cluster       []string                               `json:"cluster,omitempty"`
+	RemoteCluster []KibanaRoleElasticsearchRemoteCluster `json:"remote_cluster,omitempty"`
+	RunAs         []string                               `json:"run_as,omitempty"`
+
+	// UnknownFields contain the fields returned by Kibana that are not handled by this library
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // KibanaRoleKibana is the API Kibana object
@@ -38,19 +53,95 @@ type KibanaRoleKibana struct {
 	Base    []string            `json:"base,omitempty"`
 	Feature map[string][]string `json:"feature,omitempty"`
 	Spaces  []string            `json:"spaces,omitempty"`
+
+	// UnknownFields contain the fields returned by Kibana that are not handled by this library
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // KibanaRoleElasticsearchIndice is the API indice object
 type KibanaRoleElasticsearchIndice struct {
-	Names         []string               `json:"names,omitempty"`
-	Privileges    []string               `json:"privileges,omitempty"`
-	FieldSecurity map[string]interface{} `json:"field_security,omitempty"`
-	Query         interface{}            `json:"query,omitempty"`
+	Names                  []string                 `json:"names,omitempty"`
+	Privileges             []string                 `json:"privileges,omitempty"`
+	FieldSecurity          *KibanaRoleFieldSecurity `json:"field_security,omitempty"`
+	Query                  string                   `json:"query,omitempty"`
+	AllowRestrictedIndices bool                     `json:"allow_restricted_indices,omitempty"`
+
+	// UnknownFields contain the fields returned by Kibana that are not handled by this library
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// KibanaRoleElasticsearchRemoteIndice is the API remote indice object
+type KibanaRoleElasticsearchRemoteIndice struct {
+	Clusters               []string                 `json:"clusters,omitempty"`
+	Names                  []string                 `json:"names,omitempty"`
+	Privileges             []string                 `json:"privileges,omitempty"`
+	FieldSecurity          *KibanaRoleFieldSecurity `json:"field_security,omitempty"`
+	Query                  string                   `json:"query,omitempty"`
+	AllowRestrictedIndices bool                     `json:"allow_restricted_indices,omitempty"`
+
+	// UnknownFields contain the fields returned by Kibana that are not handled by this library
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// KibanaRoleElasticsearchRemoteCluster is the API remote cluster object
+type KibanaRoleElasticsearchRemoteCluster struct {
+	Clusters   []string `json:"clusters,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+
+	// UnknownFields contain the fields returned by Kibana that are not handled by this library
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// KibanaRoleFieldSecurity is the API field security object
+type KibanaRoleFieldSecurity struct {
+	Grant  []string `json:"grant,omitempty"`
+	Except []string `json:"except,omitempty"`
 }
 
 // KibanaRoles is a list of role object
 type KibanaRoles []KibanaRole
 
+// KibanaRolesBulkResponse is the result of bulk create or update roles
+type KibanaRolesBulkResponse struct {
+	Created []string                       `json:"created,omitempty"`
+	Updated []string                       `json:"updated,omitempty"`
+	Noop    []string                       `json:"noop,omitempty"`
+	Errors  map[string]KibanaRoleBulkError `json:"errors,omitempty"`
+}
+
+// KibanaRoleBulkError is the error returned for one role when bulk create or update roles
+type KibanaRoleBulkError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// KibanaRoleQueryParameters is the parameters to query roles
+type KibanaRoleQueryParameters struct {
+	Query   string                 `json:"query,omitempty"`
+	From    int                    `json:"from,omitempty"`
+	Size    int                    `json:"size,omitempty"`
+	Sort    *KibanaRoleQuerySort   `json:"sort,omitempty"`
+	Filters *KibanaRoleQueryFilter `json:"filters,omitempty"`
+}
+
+// KibanaRoleQuerySort is the sort parameters to query roles
+type KibanaRoleQuerySort struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// KibanaRoleQueryFilter is the filter parameters to query roles
+type KibanaRoleQueryFilter struct {
+	ShowReservedRoles *bool `json:"showReservedRoles,omitempty"`
+}
+
+// KibanaRolesQueryResponse is the result of query roles
+type KibanaRolesQueryResponse struct {
+	Roles KibanaRoles `json:"roles"`
+	Count int         `json:"count"`
+	Total int         `json:"total"`
+}
+
 // KibanaRoleManagementGet permit to get role from Kibana
 type KibanaRoleManagementGet func(name string) (*KibanaRole, error)
 
@@ -60,15 +151,129 @@ type KibanaRoleManagementList func() (KibanaRoles, error)
 // KibanaRoleManagementCreateOrUpdate permit to create or update role in Kibana
 type KibanaRoleManagementCreateOrUpdate func(kibanaRole *KibanaRole) (*KibanaRole, error)
 
+// KibanaRoleManagementCreate permit to create role in Kibana. It failed with 409 error if role already exist
+type KibanaRoleManagementCreate func(kibanaRole *KibanaRole) (*KibanaRole, error)
+
 // KibanaRoleManagementDelete permit to delete role in Kibana
 type KibanaRoleManagementDelete func(name string) error
 
+// KibanaRoleManagementBulkCreateOrUpdate permit to create or update many roles in Kibana
+type KibanaRoleManagementBulkCreateOrUpdate func(kibanaRoles KibanaRoles) (*KibanaRolesBulkResponse, error)
+
+// KibanaRoleManagementQuery permit to search roles in Kibana
+type KibanaRoleManagementQuery func(parameters *KibanaRoleQueryParameters) (*KibanaRolesQueryResponse, error)
+
 // String permit to return KibanaRole object as JSON string
 func (k *KibanaRole) String() string {
 	json, _ := json.Marshal(k)
 	return string(json)
 }
 
+// IsReserved return true if the role is a built-in role that can't be modified
+func (k *KibanaRole) IsReserved() bool {
+	if k == nil {
+		return false
+	}
+	reserved, ok := k.Metadata["_reserved"].(bool)
+	return ok && reserved
+}
+
+// WithoutReserved return the roles that are not built-in roles
+func (k KibanaRoles) WithoutReserved() KibanaRoles {
+	kibanaRoles := make(KibanaRoles, 0, len(k))
+	for i := range k {
+		if !k[i].IsReserved() {
+			kibanaRoles = append(kibanaRoles, k[i])
+		}
+	}
+
+	return kibanaRoles
+}
+
+// String permit to return KibanaRolesBulkResponse object as JSON string
+func (k *KibanaRolesBulkResponse) String() string {
+	json, _ := json.Marshal(k)
+	return string(json)
+}
+
+// MarshalJSON permit to add the unknown fields on KibanaRole JSON
+func (k KibanaRole) MarshalJSON() ([]byte, error) {
+	type kibanaRole KibanaRole
+	return marshalWithUnknownFields(kibanaRole(k), k.UnknownFields)
+}
+
+// UnmarshalJSON permit to keep the unknown fields of KibanaRole JSON
+func (k *KibanaRole) UnmarshalJSON(data []byte) (err error) {
+	type kibanaRole KibanaRole
+	k.UnknownFields, err = unmarshalWithUnknownFields(data, (*kibanaRole)(k))
+	return err
+}
+
+// MarshalJSON permit to add the unknown fields on KibanaRoleElasticsearch JSON
+func (k KibanaRoleElasticsearch) MarshalJSON() ([]byte, error) {
+	type kibanaRoleElasticsearch KibanaRoleElasticsearch
+	return marshalWithUnknownFields(kibanaRoleElasticsearch(k), k.UnknownFields)
+}
+
+// UnmarshalJSON permit to keep the unknown fields of KibanaRoleElasticsearch JSON
+func (k *KibanaRoleElasticsearch) UnmarshalJSON(data []byte) (err error) {
+	type kibanaRoleElasticsearch KibanaRoleElasticsearch
+	k.UnknownFields, err = unmarshalWithUnknownFields(data, (*kibanaRoleElasticsearch)(k))
+	return err
+}
+
+// MarshalJSON permit to add the unknown fields on KibanaRoleKibana JSON
+func (k KibanaRoleKibana) MarshalJSON() ([]byte, error) {
+	type kibanaRoleKibana KibanaRoleKibana
+	return marshalWithUnknownFields(kibanaRoleKibana(k), k.UnknownFields)
+}
+
+// UnmarshalJSON permit to keep the unknown fields of KibanaRoleKibana JSON
+func (k *KibanaRoleKibana) UnmarshalJSON(data []byte) (err error) {
+	type kibanaRoleKibana KibanaRoleKibana
+	k.UnknownFields, err = unmarshalWithUnknownFields(data, (*kibanaRoleKibana)(k))
+	return err
+}
+
+// MarshalJSON permit to add the unknown fields on KibanaRoleElasticsearchIndice JSON
+func (k KibanaRoleElasticsearchIndice) MarshalJSON() ([]byte, error) {
+	type kibanaRoleElasticsearchIndice KibanaRoleElasticsearchIndice
+	return marshalWithUnknownFields(kibanaRoleElasticsearchIndice(k), k.UnknownFields)
+}
+
+// UnmarshalJSON permit to keep the unknown fields of KibanaRoleElasticsearchIndice JSON
+func (k *KibanaRoleElasticsearchIndice) UnmarshalJSON(data []byte) (err error) {
+	type kibanaRoleElasticsearchIndice KibanaRoleElasticsearchIndice
+	k.UnknownFields, err = unmarshalWithUnknownFields(data, (*kibanaRoleElasticsearchIndice)(k))
+	return err
+}
+
+// MarshalJSON permit to add the unknown fields on KibanaRoleElasticsearchRemoteIndice JSON
+func (k KibanaRoleElasticsearchRemoteIndice) MarshalJSON() ([]byte, error) {
+	type kibanaRoleElasticsearchRemoteIndice KibanaRoleElasticsearchRemoteIndice
+	return marshalWithUnknownFields(kibanaRoleElasticsearchRemoteIndice(k), k.UnknownFields)
+}
+
+// UnmarshalJSON permit to keep the unknown fields of KibanaRoleElasticsearchRemoteIndice JSON
+func (k *KibanaRoleElasticsearchRemoteIndice) UnmarshalJSON(data []byte) (err error) {
+	type kibanaRoleElasticsearchRemoteIndice KibanaRoleElasticsearchRemoteIndice
+	k.UnknownFields, err = unmarshalWithUnknownFields(data, (*kibanaRoleElasticsearchRemoteIndice)(k))
+	return err
+}
+
+// MarshalJSON permit to add the unknown fields on KibanaRoleElasticsearchRemoteCluster JSON
+func (k KibanaRoleElasticsearchRemoteCluster) MarshalJSON() ([]byte, error) {
+	type kibanaRoleElasticsearchRemoteCluster KibanaRoleElasticsearchRemoteCluster
+	return marshalWithUnknownFields(kibanaRoleElasticsearchRemoteCluster(k), k.UnknownFields)
+}
+
+// UnmarshalJSON permit to keep the unknown fields of KibanaRoleElasticsearchRemoteCluster JSON
+func (k *KibanaRoleElasticsearchRemoteCluster) UnmarshalJSON(data []byte) (err error) {
+	type kibanaRoleElasticsearchRemoteCluster KibanaRoleElasticsearchRemoteCluster
+	k.UnknownFields, err = unmarshalWithUnknownFields(data, (*kibanaRoleElasticsearchRemoteCluster)(k))
+	return err
+}
+
 // newKibanaRoleManagementGetFunc permit to get the kibana role with it name
 func newKibanaRoleManagementGetFunc(c *resty.Client) KibanaRoleManagementGet {
 	return func(name string) (*KibanaRole, error) {
@@ -126,7 +331,7 @@ func newKibanaRoleManagementListFunc(c *resty.Client) KibanaRoleManagementList {
 
 }
 
-// newKibanaRoleManagementGetFunc permit to create or update the kibana role
+// newKibanaRoleManagementCreateOrUpdateFunc permit to create or update the kibana role
 func newKibanaRoleManagementCreateOrUpdateFunc(c *resty.Client) KibanaRoleManagementCreateOrUpdate {
 	return func(kibanaRole *KibanaRole) (*KibanaRole, error) {
 
@@ -134,26 +339,39 @@ func newKibanaRoleManagementCreateOrUpdateFunc(c *resty.Client) KibanaRoleManage
 			return nil, NewAPIError(600, "You must provide kibana role object")
 		}
 		log.Debug("Kibana role: ", kibanaRole)
-		roleName := kibanaRole.Name
 
-		path := fmt.Sprintf("%s/%s", basePathKibanaRoleManagement, roleName)
-		kibanaRole.Name = ""
-		jsonData, err := json.Marshal(kibanaRole)
-		log.Debugf("Payload: %s", jsonData)
-		if err != nil {
+		if err := putKibanaRole(c, kibanaRole, false); err != nil {
 			return nil, err
 		}
-		resp, err := c.R().SetBody(jsonData).Put(path)
+
+		// Retrive the object to return it
+		kibanaRole, err := newKibanaRoleManagementGetFunc(c)(kibanaRole.Name)
 		if err != nil {
 			return nil, err
 		}
-		log.Debug("Response: ", resp)
-		if resp.StatusCode() >= 300 {
-			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+
+		log.Debug("KibanaRole: ", kibanaRole)
+
+		return kibanaRole, nil
+	}
+
+}
+
+// newKibanaRoleManagementCreateFunc permit to create the kibana role only if it not already exist
+func newKibanaRoleManagementCreateFunc(c *resty.Client) KibanaRoleManagementCreate {
+	return func(kibanaRole *KibanaRole) (*KibanaRole, error) {
+
+		if kibanaRole == nil {
+			return nil, NewAPIError(600, "You must provide kibana role object")
+		}
+		log.Debug("Kibana role: ", kibanaRole)
+
+		if err := putKibanaRole(c, kibanaRole, true); err != nil {
+			return nil, err
 		}
 
 		// Retrive the object to return it
-		kibanaRole, err = newKibanaRoleManagementGetFunc(c)(roleName)
+		kibanaRole, err := newKibanaRoleManagementGetFunc(c)(kibanaRole.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -165,6 +383,55 @@ func newKibanaRoleManagementCreateOrUpdateFunc(c *resty.Client) KibanaRoleManage
 
 }
 
+// putKibanaRole permit to write the kibana role. When createOnly is true, Kibana refuse to overwrite existing role
+func putKibanaRole(c *resty.Client, kibanaRole *KibanaRole, createOnly bool) error {
+
+	if kibanaRole.Name == "" {
+		return NewAPIError(600, "You must provide kibana role name")
+	}
+
+	path := fmt.Sprintf("%s/%s", basePathKibanaRoleManagement, kibanaRole.Name)
+
+	// The role name is provided on path
+	payload := *kibanaRole
+	payload.Name = ""
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Payload: %s", jsonData)
+
+	request := c.R()
+	if createOnly {
+		request = request.SetQueryString("createOnly=true")
+	}
+	resp, err := request.SetBody(jsonData).Put(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return newKibanaRoleWriteError(resp, kibanaRole.Name)
+	}
+
+	return nil
+}
+
+// newKibanaRoleWriteError return the error when Kibana refuse to write or delete role.
+// Elasticsearch refuse to modify the built-in roles, this error is returned with code 600.
+func newKibanaRoleWriteError(resp *resty.Response, name string) error {
+	if resp.StatusCode() == 400 && isKibanaRoleReservedReason(string(resp.Body())) {
+		return NewAPIError(600, "Kibana role %s is reserved, it can't be modified or deleted", name)
+	}
+
+	return NewAPIError(resp.StatusCode(), resp.Status())
+}
+
+// isKibanaRoleReservedReason return true when Elasticsearch error is about built-in role, like "role [superuser] is reserved and cannot be modified"
+func isKibanaRoleReservedReason(reason string) bool {
+	return strings.Contains(strings.ToLower(reason), "is reserved")
+}
+
 // newKibanaRoleManagementDeleteFunc permit to delete kibana role with it name
 func newKibanaRoleManagementDeleteFunc(c *resty.Client) KibanaRoleManagementDelete {
 	return func(name string) error {
@@ -181,10 +448,173 @@ func newKibanaRoleManagementDeleteFunc(c *resty.Client) KibanaRoleManagementDele
 		}
 		log.Debug("Response: ", resp)
 		if resp.StatusCode() >= 300 {
-			return NewAPIError(resp.StatusCode(), resp.Status())
+			return newKibanaRoleWriteError(resp, name)
 		}
 
 		return nil
 	}
 
 }
+
+// newKibanaRoleManagementBulkCreateOrUpdateFunc permit to create or update many kibana roles with one call.
+// Reserved roles refused by Elasticsearch are returned as error with reserved_role type.
+// When Kibana not provide the bulk API, it fallback on parallel calls per role.
+func newKibanaRoleManagementBulkCreateOrUpdateFunc(c *resty.Client) KibanaRoleManagementBulkCreateOrUpdate {
+	return func(kibanaRoles KibanaRoles) (*KibanaRolesBulkResponse, error) {
+
+		if len(kibanaRoles) == 0 {
+			return nil, NewAPIError(600, "You must provide one or more kibana role object")
+		}
+		for _, kibanaRole := range kibanaRoles {
+			if kibanaRole.Name == "" {
+				return nil, NewAPIError(600, "You must provide kibana role name")
+			}
+		}
+		log.Debug("Kibana roles: ", kibanaRoles)
+
+		kibanaRolesBulkResponse, err := postKibanaRoles(c, kibanaRoles)
+		if err != nil {
+			if apiError, ok := err.(APIError); !ok || apiError.Code != 404 {
+				return nil, err
+			}
+			log.Debug("Bulk API not available, fallback on role API")
+
+			// The existing roles are needed to know if role is created or updated
+			currentKibanaRoles, err := newKibanaRoleManagementListFunc(c)()
+			if err != nil {
+				return nil, err
+			}
+			existingRoles := make(map[string]*KibanaRole, len(currentKibanaRoles))
+			for i := range currentKibanaRoles {
+				existingRoles[currentKibanaRoles[i].Name] = &currentKibanaRoles[i]
+			}
+			kibanaRolesBulkResponse = bulkPutKibanaRoles(c, kibanaRoles, existingRoles)
+		}
+
+		for name, bulkError := range kibanaRolesBulkResponse.Errors {
+			if isKibanaRoleReservedReason(bulkError.Reason) {
+				kibanaRolesBulkResponse.Errors[name] = KibanaRoleBulkError{
+					Type:   "reserved_role",
+					Reason: fmt.Sprintf("Kibana role %s is reserved", name),
+				}
+			}
+		}
+		log.Debug("KibanaRolesBulkResponse: ", kibanaRolesBulkResponse)
+
+		return kibanaRolesBulkResponse, nil
+	}
+}
+
+// postKibanaRoles permit to create or update many kibana roles with the bulk API
+func postKibanaRoles(c *resty.Client, kibanaRoles KibanaRoles) (*KibanaRolesBulkResponse, error) {
+
+	// The role name is provided as key
+	payload := make(map[string]KibanaRole, len(kibanaRoles))
+	for _, kibanaRole := range kibanaRoles {
+		name := kibanaRole.Name
+		kibanaRole.Name = ""
+		payload[name] = kibanaRole
+	}
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"roles": payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Payload: %s", jsonData)
+
+	resp, err := c.R().SetBody(jsonData).Post(basePathKibanaRoleManagementBulk)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	kibanaRolesBulkResponse := &KibanaRolesBulkResponse{}
+	err = json.Unmarshal(resp.Body(), kibanaRolesBulkResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return kibanaRolesBulkResponse, nil
+}
+
+// bulkPutKibanaRoles permit to create or update many kibana roles with parallel calls.
+// The existing roles are used to know if role is created or updated.
+func bulkPutKibanaRoles(c *resty.Client, kibanaRoles KibanaRoles, existingRoles map[string]*KibanaRole) *KibanaRolesBulkResponse {
+
+	kibanaRolesBulkResponse := &KibanaRolesBulkResponse{
+		Errors: make(map[string]KibanaRoleBulkError),
+	}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, bulkKibanaRoleConcurrency)
+
+	for i := range kibanaRoles {
+		wg.Add(1)
+		go func(kibanaRole *KibanaRole) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			err := putKibanaRole(c, kibanaRole, false)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch {
+			case err != nil:
+				kibanaRolesBulkResponse.Errors[kibanaRole.Name] = KibanaRoleBulkError{
+					Type:   "api_error",
+					Reason: err.Error(),
+				}
+			case existingRoles[kibanaRole.Name] != nil:
+				kibanaRolesBulkResponse.Updated = append(kibanaRolesBulkResponse.Updated, kibanaRole.Name)
+			default:
+				kibanaRolesBulkResponse.Created = append(kibanaRolesBulkResponse.Created, kibanaRole.Name)
+			}
+		}(&kibanaRoles[i])
+	}
+	wg.Wait()
+
+	sort.Strings(kibanaRolesBulkResponse.Created)
+	sort.Strings(kibanaRolesBulkResponse.Updated)
+	if len(kibanaRolesBulkResponse.Errors) == 0 {
+		kibanaRolesBulkResponse.Errors = nil
+	}
+
+	return kibanaRolesBulkResponse
+}
+
+// newKibanaRoleManagementQueryFunc permit to search kibana roles
+func newKibanaRoleManagementQueryFunc(c *resty.Client) KibanaRoleManagementQuery {
+	return func(parameters *KibanaRoleQueryParameters) (*KibanaRolesQueryResponse, error) {
+
+		if parameters == nil {
+			parameters = &KibanaRoleQueryParameters{}
+		}
+		log.Debug("Parameters: ", parameters)
+
+		jsonData, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, err
+		}
+		path := fmt.Sprintf("%s/_query", basePathKibanaRoleManagement)
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		kibanaRolesQueryResponse := &KibanaRolesQueryResponse{}
+		err = json.Unmarshal(resp.Body(), kibanaRolesQueryResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("KibanaRolesQueryResponse: ", kibanaRolesQueryResponse)
+
+		return kibanaRolesQueryResponse, nil
+	}
+}
@@ -1,6 +1,10 @@
 package kbapi
 
 import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,6 +22,11 @@ func (s *KBAPITestSuite) TestKibanaRoleManagement() {
 					Privileges: []string{
 						"read",
 					},
+					FieldSecurity: &KibanaRoleFieldSecurity{
+						Grant:  []string{"*"},
+						Except: []string{"secret"},
+					},
+					Query: `{"match_all": {}}`,
 				},
 			},
 		},
@@ -33,6 +42,12 @@ func (s *KBAPITestSuite) TestKibanaRoleManagement() {
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), kibanaRole)
 	assert.Equal(s.T(), "test", kibanaRole.Name)
+	assert.Equal(s.T(), []string{"secret"}, kibanaRole.Elasticsearch.Indices[0].FieldSecurity.Except)
+
+	// Create role only if not exist
+	_, err = s.API.KibanaRoleManagement.Create(kibanaRole)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), 409, err.(APIError).Code)
 
 	// Get role
 	kibanaRole, err = s.API.KibanaRoleManagement.Get("test")
@@ -52,4 +67,227 @@ func (s *KBAPITestSuite) TestKibanaRoleManagement() {
 	assert.NoError(s.T(), err)
 	assert.Nil(s.T(), kibanaRole)
 
+	// Bulk create or update roles
+	kibanaRoles = KibanaRoles{
+		{
+			Name: "test-bulk1",
+			Kibana: []KibanaRoleKibana{
+				{
+					Base:   []string{"read"},
+					Spaces: []string{"default"},
+				},
+			},
+		},
+		{
+			Name: "test-bulk2",
+			Elasticsearch: &KibanaRoleElasticsearch{
+				Cluster: []string{"monitor"},
+			},
+		},
+	}
+	kibanaRolesBulkResponse, err := s.API.KibanaRoleManagement.BulkCreateOrUpdate(kibanaRoles)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), kibanaRolesBulkResponse)
+	assert.Empty(s.T(), kibanaRolesBulkResponse.Errors)
+	assert.ElementsMatch(s.T(), []string{"test-bulk1", "test-bulk2"}, kibanaRolesBulkResponse.Created)
+
+	kibanaRolesBulkResponse, err = s.API.KibanaRoleManagement.BulkCreateOrUpdate(kibanaRoles)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), kibanaRolesBulkResponse.Created)
+
+	// Query roles, the API is available since Kibana 8.15
+	kibanaRolesQueryResponse, err := s.API.KibanaRoleManagement.Query(&KibanaRoleQueryParameters{
+		Query: "test-bulk*",
+		Size:  10,
+		Sort: &KibanaRoleQuerySort{
+			Field:     "name",
+			Direction: "asc",
+		},
+	})
+	if err != nil && err.(APIError).Code == 404 {
+		s.T().Log("Query roles API not available")
+	} else {
+		assert.NoError(s.T(), err)
+		assert.NotEmpty(s.T(), kibanaRolesQueryResponse.Roles)
+	}
+
+	err = s.API.KibanaRoleManagement.Delete("test-bulk1")
+	assert.NoError(s.T(), err)
+	err = s.API.KibanaRoleManagement.Delete("test-bulk2")
+	assert.NoError(s.T(), err)
+
+	// Reserved roles can't be modified or deleted
+	kibanaRoles, err = s.API.KibanaRoleManagement.List()
+	assert.NoError(s.T(), err)
+	assert.Less(s.T(), len(kibanaRoles.WithoutReserved()), len(kibanaRoles))
+	kibanaRole, err = s.API.KibanaRoleManagement.Get("kibana_admin")
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), kibanaRole.IsReserved())
+	_, err = s.API.KibanaRoleManagement.CreateOrUpdate(kibanaRole)
+	assert.Error(s.T(), err)
+	err = s.API.KibanaRoleManagement.Delete("kibana_admin")
+	assert.Error(s.T(), err)
+
+	// Compare roles
+	currentKibanaRole := &KibanaRole{
+		Name: "test",
+		Metadata: map[string]interface{}{
+			"_reserved": false,
+			"version":   float64(1),
+		},
+		Elasticsearch: &KibanaRoleElasticsearch{
+			Cluster: []string{"monitor", "all"},
+			RunAs:   []string{},
+			Indices: []KibanaRoleElasticsearchIndice{
+				{
+					Names:      []string{"logs-*", "metrics-*"},
+					Privileges: []string{"read", "view_index_metadata"},
+				},
+			},
+		},
+		Kibana: []KibanaRoleKibana{
+			{
+				Base:   []string{},
+				Spaces: []string{"testacc", "default"},
+				Feature: map[string][]string{
+					"discover": {"read", "all"},
+				},
+			},
+		},
+	}
+	desiredKibanaRole := &KibanaRole{
+		Name: "test",
+		Metadata: map[string]interface{}{
+			"version": 1,
+		},
+		Elasticsearch: &KibanaRoleElasticsearch{
+			Cluster: []string{"all", "monitor"},
+			Indices: []KibanaRoleElasticsearchIndice{
+				{
+					Names:      []string{"metrics-*", "logs-*"},
+					Privileges: []string{"view_index_metadata", "read"},
+				},
+			},
+		},
+		Kibana: []KibanaRoleKibana{
+			{
+				Spaces: []string{"default", "testacc"},
+				Feature: map[string][]string{
+					"discover": {"all", "read"},
+				},
+			},
+		},
+	}
+	diff := CompareKibanaRole(currentKibanaRole, desiredKibanaRole)
+	assert.False(s.T(), diff.HasChanges())
+
+	desiredKibanaRole.Elasticsearch.Indices[0].Privileges = []string{"read"}
+	desiredKibanaRole.Kibana = append(desiredKibanaRole.Kibana, KibanaRoleKibana{
+		Base:   []string{"read"},
+		Spaces: []string{"test"},
+	})
+	diff = CompareKibanaRole(currentKibanaRole, desiredKibanaRole)
+	assert.True(s.T(), diff.HasChanges())
+	assert.Equal(s.T(), 2, len(diff))
+	assert.Equal(s.T(), "elasticsearch.indices[logs-*,metrics-*]", diff[0].Path)
+	assert.Equal(s.T(), "kibana[test]", diff[1].Path)
+
+	// Many indices with the same names are all compared
+	currentKibanaRole.Elasticsearch.Indices = []KibanaRoleElasticsearchIndice{
+		{
+			Names:      []string{"logs-*"},
+			Privileges: []string{"read"},
+			Query:      `{"term": {"team": "a"}}`,
+		},
+		{
+			Names:      []string{"logs-*"},
+			Privileges: []string{"read", "write"},
+		},
+	}
+	desiredKibanaRole.Elasticsearch.Indices = []KibanaRoleElasticsearchIndice{
+		{
+			Names:      []string{"logs-*"},
+			Privileges: []string{"write", "read"},
+		},
+		{
+			Names:      []string{"logs-*"},
+			Privileges: []string{"read"},
+			Query:      `{"term": {"team": "a"}}`,
+		},
+	}
+	desiredKibanaRole.Kibana = currentKibanaRole.Kibana
+	diff = CompareKibanaRole(currentKibanaRole, desiredKibanaRole)
+	assert.False(s.T(), diff.HasChanges())
+
+	desiredKibanaRole.Elasticsearch.Indices[1].Query = `{"term": {"team": "b"}}`
+	diff = CompareKibanaRole(currentKibanaRole, desiredKibanaRole)
+	assert.True(s.T(), diff.HasChanges())
+	for _, change := range diff {
+		assert.Contains(s.T(), change.Path, "elasticsearch.indices[logs-*]")
+	}
+
+	desiredKibanaRole.Elasticsearch.Indices = desiredKibanaRole.Elasticsearch.Indices[:1]
+	diff = CompareKibanaRole(currentKibanaRole, desiredKibanaRole)
+	assert.True(s.T(), diff.HasChanges())
+}
+
+func TestKibanaRoleManagementReserved(t *testing.T) {
+
+	// Elasticsearch refuse to modify built-in roles
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		if r.Path == basePathKibanaRoleManagementBulk {
+			return http.StatusOK, `{"created": ["test"], "errors": {"kibana_admin": {"type": "illegal_argument_exception", "reason": "role [kibana_admin] is reserved and cannot be modified"}}}`
+		}
+		return http.StatusBadRequest, `{"statusCode": 400, "error": "Bad Request", "message": "role [kibana_admin] is reserved and cannot be modified"}`
+	})
+
+	// Role is written without reading it before
+	_, err := kibana.API.KibanaRoleManagement.CreateOrUpdate(&KibanaRole{Name: "kibana_admin"})
+	assert.Error(t, err)
+	assert.Equal(t, 600, err.(APIError).Code)
+	assert.Len(t, kibana.Requests, 1)
+	assert.Equal(t, http.MethodPut, kibana.lastRequest(t).Method)
+
+	kibana.reset()
+	err = kibana.API.KibanaRoleManagement.Delete("kibana_admin")
+	assert.Error(t, err)
+	assert.Equal(t, 600, err.(APIError).Code)
+	assert.Len(t, kibana.Requests, 1)
+
+	// Bulk write roles without listing them before
+	kibana.reset()
+	kibanaRolesBulkResponse, err := kibana.API.KibanaRoleManagement.BulkCreateOrUpdate(KibanaRoles{
+		{Name: "test"},
+		{Name: "kibana_admin"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, kibana.Requests, 1)
+	assert.Equal(t, []string{"test"}, kibanaRolesBulkResponse.Created)
+	assert.Equal(t, "reserved_role", kibanaRolesBulkResponse.Errors["kibana_admin"].Type)
+}
+
+func TestKibanaRoleUnknownFields(t *testing.T) {
+
+	// Fields not handled by this library are kept on all role levels
+	data := []byte(`{
+		"name": "test",
+		"new_field": 1,
+		"elasticsearch": {
+			"cluster": ["monitor"],
+			"new_field": 2,
+			"indices": [{"names": ["logs-*"], "privileges": ["read"], "new_field": 3}],
+			"remote_indices": [{"clusters": ["remote"], "names": ["logs-*"], "privileges": ["read"], "new_field": 4}],
+			"remote_cluster": [{"clusters": ["remote"], "privileges": ["monitor_enrich"], "new_field": 5}]
+		},
+		"kibana": [{"base": ["all"], "spaces": ["*"], "new_field": 6}]
+	}`)
+	kibanaRole := &KibanaRole{}
+	err := json.Unmarshal(data, kibanaRole)
+	assert.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`4`), kibanaRole.Elasticsearch.RemoteIndices[0].UnknownFields["new_field"])
+	assert.Equal(t, json.RawMessage(`5`), kibanaRole.Elasticsearch.RemoteCluster[0].UnknownFields["new_field"])
+
+	result, err := json.Marshal(kibanaRole)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(result))
 }
@@ -0,0 +1,337 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	savedQueryObjectType = "query" // Saved object type used to store saved queries
+)
+
+// SavedQuery is the query, filters and time range saved by user to reuse them on Discover, dashboards and timelines
+type SavedQuery struct {
+	ID          string                   `json:"id,omitempty"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Query       SavedQueryQuery          `json:"query"`
+	Filters     []map[string]interface{} `json:"filters,omitempty"`
+	Timefilter  *SavedQueryTimefilter    `json:"timefilter,omitempty"`
+	Version     string                   `json:"version,omitempty"`
+}
+
+// SavedQueries is list of SavedQuery object
+type SavedQueries []SavedQuery
+
+// SavedQueryQuery is the query of saved query. Query is string for kuery and lucene languages.
+type SavedQueryQuery struct {
+	Query    interface{} `json:"query"`
+	Language string      `json:"language"`
+}
+
+// SavedQueryTimefilter is the time range of saved query
+type SavedQueryTimefilter struct {
+	From            string                     `json:"from"`
+	To              string                     `json:"to"`
+	RefreshInterval *SavedQueryRefreshInterval `json:"refreshInterval,omitempty"`
+}
+
+// SavedQueryRefreshInterval is the refresh interval of saved query time range
+type SavedQueryRefreshInterval struct {
+	Pause bool  `json:"pause"`
+	Value int64 `json:"value"`
+}
+
+// SavedQueryFindResponse is the result when find saved queries
+type SavedQueryFindResponse struct {
+	Page    int          `json:"page"`
+	PerPage int          `json:"per_page"`
+	Total   int          `json:"total"`
+	Data    SavedQueries `json:"data"`
+}
+
+// savedQueryAttributes is the saved query as stored on saved object
+type savedQueryAttributes struct {
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Query       SavedQueryQuery          `json:"query"`
+	Filters     []map[string]interface{} `json:"filters,omitempty"`
+	Timefilter  *SavedQueryTimefilter    `json:"timefilter,omitempty"`
+}
+
+// savedQueryUpdateAttributes is the saved query attributes sent on update.
+// Saved object update merge attributes, so filters and time filter are always sent to be removed.
+type savedQueryUpdateAttributes struct {
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Query       SavedQueryQuery          `json:"query"`
+	Filters     []map[string]interface{} `json:"filters"`
+	Timefilter  *SavedQueryTimefilter    `json:"timefilter"`
+}
+
+// savedQueryUpdate is the saved object sent to update saved query
+type savedQueryUpdate struct {
+	Version    string                     `json:"version,omitempty"`
+	Attributes savedQueryUpdateAttributes `json:"attributes"`
+}
+
+// savedQuerySavedObject is the saved object that store saved query
+type savedQuerySavedObject struct {
+	ID         string               `json:"id,omitempty"`
+	Version    string               `json:"version,omitempty"`
+	Attributes savedQueryAttributes `json:"attributes"`
+}
+
+// KibanaSavedQueryCreate permit to create saved query. The ID is generated by Kibana when not provided.
+type KibanaSavedQueryCreate func(savedQuery *SavedQuery, overwrite bool, kibanaSpace string) (*SavedQuery, error)
+
+// KibanaSavedQueryGet permit to get saved query
+type KibanaSavedQueryGet func(id string, kibanaSpace string) (*SavedQuery, error)
+
+// KibanaSavedQueryUpdate permit to update saved query. Filters and time filter not set are removed.
+type KibanaSavedQueryUpdate func(savedQuery *SavedQuery, kibanaSpace string) (*SavedQuery, error)
+
+// KibanaSavedQueryDelete permit to delete saved query
+type KibanaSavedQueryDelete func(id string, kibanaSpace string) error
+
+// KibanaSavedQueryFind permit to find saved queries by title. When search is empty, all saved queries are returned.
+type KibanaSavedQueryFind func(search string, page int, perPage int, kibanaSpace string) (*SavedQueryFindResponse, error)
+
+// String permit to return SavedQuery object as JSON string
+func (o *SavedQuery) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// toSavedObject return the saved query as stored on saved object
+func (o *SavedQuery) toSavedObject() *savedQuerySavedObject {
+	return &savedQuerySavedObject{
+		Attributes: savedQueryAttributes{
+			Title:       o.Title,
+			Description: o.Description,
+			Query:       o.Query,
+			Filters:     o.Filters,
+			Timefilter:  o.Timefilter,
+		},
+	}
+}
+
+// toSavedQuery return the saved query stored on saved object
+func (o *savedQuerySavedObject) toSavedQuery() *SavedQuery {
+	return &SavedQuery{
+		ID:          o.ID,
+		Title:       o.Attributes.Title,
+		Description: o.Attributes.Description,
+		Query:       o.Attributes.Query,
+		Filters:     o.Attributes.Filters,
+		Timefilter:  o.Attributes.Timefilter,
+		Version:     o.Version,
+	}
+}
+
+// newKibanaSavedQueryCreateFunc permit to create saved query
+func newKibanaSavedQueryCreateFunc(c *resty.Client) KibanaSavedQueryCreate {
+	return func(savedQuery *SavedQuery, overwrite bool, kibanaSpace string) (*SavedQuery, error) {
+
+		if savedQuery == nil {
+			return nil, NewAPIError(600, "You must provide saved query object")
+		}
+		if savedQuery.Title == "" {
+			return nil, NewAPIError(600, "You must provide saved query title")
+		}
+		log.Debug("SavedQuery: ", savedQuery)
+		log.Debug("Overwrite: ", overwrite)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(savedQuery.toSavedObject())
+		if err != nil {
+			return nil, err
+		}
+		path := fmt.Sprintf("%s/%s", basePathKibanaSavedObject, savedQueryObjectType)
+		if savedQuery.ID != "" {
+			path = fmt.Sprintf("%s/%s", path, savedQuery.ID)
+		}
+		resp, err := c.R().
+			SetQueryString(fmt.Sprintf("overwrite=%t", overwrite)).
+			SetBody(jsonData).
+			Post(spacePath(kibanaSpace, path))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalSavedQuery(resp.Body())
+	}
+}
+
+// newKibanaSavedQueryGetFunc permit to get saved query with it ID
+func newKibanaSavedQueryGetFunc(c *resty.Client) KibanaSavedQueryGet {
+	return func(id string, kibanaSpace string) (*SavedQuery, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide saved query ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/%s", basePathKibanaSavedObject, savedQueryObjectType, id))
+		resp, err := c.R().Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			if resp.StatusCode() == 404 {
+				return nil, nil
+			}
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalSavedQuery(resp.Body())
+	}
+}
+
+// newKibanaSavedQueryUpdateFunc permit to update saved query
+func newKibanaSavedQueryUpdateFunc(c *resty.Client) KibanaSavedQueryUpdate {
+	return func(savedQuery *SavedQuery, kibanaSpace string) (*SavedQuery, error) {
+
+		if savedQuery == nil {
+			return nil, NewAPIError(600, "You must provide saved query object")
+		}
+		if savedQuery.ID == "" {
+			return nil, NewAPIError(600, "You must provide saved query ID")
+		}
+		log.Debug("SavedQuery: ", savedQuery)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// Version permit to detect conflict with concurrent update
+		filters := savedQuery.Filters
+		if filters == nil {
+			filters = []map[string]interface{}{}
+		}
+		jsonData, err := json.Marshal(&savedQueryUpdate{
+			Version: savedQuery.Version,
+			Attributes: savedQueryUpdateAttributes{
+				Title:       savedQuery.Title,
+				Description: savedQuery.Description,
+				Query:       savedQuery.Query,
+				Filters:     filters,
+				Timefilter:  savedQuery.Timefilter,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/%s", basePathKibanaSavedObject, savedQueryObjectType, savedQuery.ID))
+		resp, err := c.R().SetBody(jsonData).Put(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return unmarshalSavedQuery(resp.Body())
+	}
+}
+
+// newKibanaSavedQueryDeleteFunc permit to delete saved query with it ID
+func newKibanaSavedQueryDeleteFunc(c *resty.Client) KibanaSavedQueryDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide saved query ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/%s/%s", basePathKibanaSavedObject, savedQueryObjectType, id))
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaSavedQueryFindFunc permit to find saved queries
+func newKibanaSavedQueryFindFunc(c *resty.Client) KibanaSavedQueryFind {
+	return func(search string, page int, perPage int, kibanaSpace string) (*SavedQueryFindResponse, error) {
+
+		log.Debug("Search: ", search)
+		log.Debug("Page: ", page)
+		log.Debug("PerPage: ", perPage)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := map[string]string{
+			"type": savedQueryObjectType,
+		}
+		if search != "" {
+			queryParams["search"] = search
+			queryParams["search_fields"] = "title"
+		}
+		if page != 0 {
+			queryParams["page"] = strconv.Itoa(page)
+		}
+		if perPage != 0 {
+			queryParams["per_page"] = strconv.Itoa(perPage)
+		}
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_find", basePathKibanaSavedObject))
+		resp, err := c.R().SetQueryParams(queryParams).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		response := struct {
+			Page         int                     `json:"page"`
+			PerPage      int                     `json:"per_page"`
+			Total        int                     `json:"total"`
+			SavedObjects []savedQuerySavedObject `json:"saved_objects"`
+		}{}
+		err = json.Unmarshal(resp.Body(), &response)
+		if err != nil {
+			return nil, err
+		}
+		savedQueryFindResponse := &SavedQueryFindResponse{
+			Page:    response.Page,
+			PerPage: response.PerPage,
+			Total:   response.Total,
+			Data:    make(SavedQueries, 0, len(response.SavedObjects)),
+		}
+		for i := range response.SavedObjects {
+			savedQueryFindResponse.Data = append(savedQueryFindResponse.Data, *response.SavedObjects[i].toSavedQuery())
+		}
+		log.Debug("SavedQueries: ", savedQueryFindResponse.Data)
+
+		return savedQueryFindResponse, nil
+	}
+}
+
+// unmarshalSavedQuery permit to read saved query from saved object response body
+func unmarshalSavedQuery(data []byte) (*SavedQuery, error) {
+	savedObject := &savedQuerySavedObject{}
+	if err := json.Unmarshal(data, savedObject); err != nil {
+		return nil, err
+	}
+	savedQuery := savedObject.toSavedQuery()
+	log.Debug("SavedQuery: ", savedQuery)
+
+	return savedQuery, nil
+}
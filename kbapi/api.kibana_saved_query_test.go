@@ -0,0 +1,81 @@
+package kbapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaSavedQueries() {
+
+	// Create new saved query
+	savedQuery, err := s.API.KibanaSavedQueries.Create(&SavedQuery{
+		ID:          "test-saved-query",
+		Title:       "test",
+		Description: "test saved query",
+		Query: SavedQueryQuery{
+			Query:    "event.action:test",
+			Language: "kuery",
+		},
+		Timefilter: &SavedQueryTimefilter{
+			From: "now-15m",
+			To:   "now",
+		},
+	}, true, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), savedQuery)
+	assert.Equal(s.T(), "test-saved-query", savedQuery.ID)
+
+	// Get saved query
+	savedQuery, err = s.API.KibanaSavedQueries.Get("test-saved-query", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), savedQuery)
+	assert.Equal(s.T(), "event.action:test", savedQuery.Query.Query)
+	assert.Equal(s.T(), "now-15m", savedQuery.Timefilter.From)
+
+	// Update saved query
+	savedQuery.Description = "test saved query 2"
+	savedQuery, err = s.API.KibanaSavedQueries.Update(savedQuery, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test saved query 2", savedQuery.Description)
+
+	// Find saved queries
+	savedQueryFindResponse, err := s.API.KibanaSavedQueries.Find("test", 0, 0, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), savedQueryFindResponse.Data)
+
+	// Delete saved query
+	err = s.API.KibanaSavedQueries.Delete("test-saved-query", "default")
+	assert.NoError(s.T(), err)
+	savedQuery, err = s.API.KibanaSavedQueries.Get("test-saved-query", "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), savedQuery)
+}
+
+func TestKibanaSavedQueriesUpdateRemove(t *testing.T) {
+
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		return http.StatusOK, `{"id": "test-saved-query", "type": "query", "version": "v2", "attributes": {"title": "test", "description": "", "query": {"query": "", "language": "kuery"}}}`
+	})
+
+	// Filters and time filter are sent empty, so they are removed
+	_, err := kibana.API.KibanaSavedQueries.Update(&SavedQuery{
+		ID:      "test-saved-query",
+		Version: "v1",
+		Title:   "test",
+		Query: SavedQueryQuery{
+			Language: "kuery",
+		},
+	}, "default")
+	assert.NoError(t, err)
+	body := struct {
+		Version    string                 `json:"version"`
+		Attributes map[string]interface{} `json:"attributes"`
+	}{}
+	kibana.lastRequest(t).decodeBody(t, &body)
+	assert.Equal(t, "v1", body.Version)
+	assert.Equal(t, []interface{}{}, body.Attributes["filters"])
+	assert.Contains(t, body.Attributes, "timefilter")
+	assert.Nil(t, body.Attributes["timefilter"])
+}
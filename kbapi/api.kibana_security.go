@@ -0,0 +1,216 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaSecurity = "/api/security" // Base URL to access on Kibana security API
+)
+
+// KibanaPrivilegeActions is the map of privilege name with the list of actions it grant.
+// When privileges are retrieved without actions, the action list is empty.
+type KibanaPrivilegeActions map[string][]string
+
+// KibanaPrivileges is the catalog of privileges that can be granted on Kibana roles
+type KibanaPrivileges struct {
+	Global   KibanaPrivilegeActions            `json:"global"`
+	Space    KibanaPrivilegeActions            `json:"space"`
+	Features map[string]KibanaPrivilegeActions `json:"features"`
+	Reserved KibanaPrivilegeActions            `json:"reserved"`
+}
+
+// KibanaSessionInvalidateParameter is the parameters to invalidate user sessions.
+// Match is "all" to invalidate all sessions or "query" to invalidate the sessions that match the query.
+type KibanaSessionInvalidateParameter struct {
+	Match string                        `json:"match"`
+	Query *KibanaSessionInvalidateQuery `json:"query,omitempty"`
+}
+
+// KibanaSessionInvalidateQuery is the query to select the user sessions to invalidate
+type KibanaSessionInvalidateQuery struct {
+	Provider *KibanaSessionProvider `json:"provider"`
+	Username string                 `json:"username,omitempty"`
+}
+
+// KibanaSessionProvider is the authentication provider of user sessions
+type KibanaSessionProvider struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// KibanaSecurityPrivileges permit to get the privileges catalog from Kibana
+type KibanaSecurityPrivileges func(includeActions bool) (*KibanaPrivileges, error)
+
+// KibanaSecurityInvalidateSessions permit to invalidate user sessions and return the number of invalidated sessions
+type KibanaSecurityInvalidateSessions func(parameter *KibanaSessionInvalidateParameter) (int, error)
+
+// UnmarshalJSON permit to read privileges returned as list of names or as map of names with their actions
+func (k *KibanaPrivilegeActions) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		privileges := make(KibanaPrivilegeActions, len(names))
+		for _, name := range names {
+			privileges[name] = []string{}
+		}
+		*k = privileges
+		return nil
+	}
+
+	privileges := make(map[string][]string)
+	if err := json.Unmarshal(data, &privileges); err != nil {
+		return err
+	}
+	*k = privileges
+
+	return nil
+}
+
+// Names return the sorted list of privilege names
+func (k KibanaPrivilegeActions) Names() []string {
+	names := make([]string, 0, len(k))
+	for name := range k {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Has return true if the privilege exist
+func (k KibanaPrivilegeActions) Has(name string) bool {
+	_, ok := k[name]
+	return ok
+}
+
+// String permit to return KibanaPrivileges object as JSON string
+func (k *KibanaPrivileges) String() string {
+	json, _ := json.Marshal(k)
+	return string(json)
+}
+
+// String permit to return KibanaSessionInvalidateParameter object as JSON string
+func (k *KibanaSessionInvalidateParameter) String() string {
+	json, _ := json.Marshal(k)
+	return string(json)
+}
+
+// ValidateKibanaRole check that the base and feature privileges used on Kibana role exist.
+// Base privileges are checked against global privileges when the role is granted on all spaces, else against space privileges.
+func (k *KibanaPrivileges) ValidateKibanaRole(kibanaRoles []KibanaRoleKibana) error {
+	var errors []string
+
+	for i, kibanaRole := range kibanaRoles {
+		basePrivileges := k.Space
+		basePrivilegesType := "space"
+		for _, space := range kibanaRole.Spaces {
+			if space == "*" {
+				basePrivileges = k.Global
+				basePrivilegesType = "global"
+				break
+			}
+		}
+
+		for _, base := range kibanaRole.Base {
+			if !basePrivileges.Has(base) {
+				errors = append(errors, fmt.Sprintf("kibana[%d]: base privilege '%s' not found in %s privileges %v", i, base, basePrivilegesType, basePrivileges.Names()))
+			}
+		}
+
+		for feature, privileges := range kibanaRole.Feature {
+			featurePrivileges, ok := k.Features[feature]
+			if !ok {
+				errors = append(errors, fmt.Sprintf("kibana[%d]: feature '%s' not found", i, feature))
+				continue
+			}
+			for _, privilege := range privileges {
+				if !featurePrivileges.Has(privilege) {
+					errors = append(errors, fmt.Sprintf("kibana[%d]: privilege '%s' not found in feature '%s' privileges %v", i, privilege, feature, featurePrivileges.Names()))
+				}
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		sort.Strings(errors)
+		return NewAPIError(600, strings.Join(errors, "\n"))
+	}
+
+	return nil
+}
+
+// newKibanaSecurityPrivilegesFunc permit to get the privileges catalog
+func newKibanaSecurityPrivilegesFunc(c *resty.Client) KibanaSecurityPrivileges {
+	return func(includeActions bool) (*KibanaPrivileges, error) {
+
+		log.Debug("IncludeActions: ", includeActions)
+
+		path := fmt.Sprintf("%s/privileges", basePathKibanaSecurity)
+		resp, err := c.R().SetQueryString(fmt.Sprintf("includeActions=%t", includeActions)).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		kibanaPrivileges := &KibanaPrivileges{}
+		err = json.Unmarshal(resp.Body(), kibanaPrivileges)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("KibanaPrivileges: ", kibanaPrivileges)
+
+		return kibanaPrivileges, nil
+	}
+}
+
+// newKibanaSecurityInvalidateSessionsFunc permit to invalidate user sessions
+func newKibanaSecurityInvalidateSessionsFunc(c *resty.Client) KibanaSecurityInvalidateSessions {
+	return func(parameter *KibanaSessionInvalidateParameter) (int, error) {
+
+		if parameter == nil {
+			return 0, NewAPIError(600, "You must provide parameter to invalidate sessions")
+		}
+		switch parameter.Match {
+		case "all":
+		case "query":
+			if parameter.Query == nil || parameter.Query.Provider == nil || parameter.Query.Provider.Type == "" {
+				return 0, NewAPIError(600, "You must provide the query provider type when match is 'query'")
+			}
+		default:
+			return 0, NewAPIError(600, "Match must be 'all' or 'query', not '%s'", parameter.Match)
+		}
+		log.Debug("Parameter: ", parameter)
+
+		jsonData, err := json.Marshal(parameter)
+		if err != nil {
+			return 0, err
+		}
+		path := fmt.Sprintf("%s/session/_invalidate", basePathKibanaSecurity)
+		resp, err := c.R().SetBody(jsonData).Post(path)
+		if err != nil {
+			return 0, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return 0, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		data := struct {
+			Total int `json:"total"`
+		}{}
+		err = json.Unmarshal(resp.Body(), &data)
+		if err != nil {
+			return 0, err
+		}
+		log.Debug("Invalidated sessions: ", data.Total)
+
+		return data.Total, nil
+	}
+}
@@ -0,0 +1,66 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaSecurity() {
+
+	// Get privileges
+	kibanaPrivileges, err := s.API.KibanaSecurity.Privileges(false)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), kibanaPrivileges)
+	assert.True(s.T(), kibanaPrivileges.Global.Has("all"))
+	assert.True(s.T(), kibanaPrivileges.Space.Has("read"))
+	assert.NotEmpty(s.T(), kibanaPrivileges.Features)
+
+	// Get privileges with actions
+	kibanaPrivileges, err = s.API.KibanaSecurity.Privileges(true)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), kibanaPrivileges)
+	assert.NotEmpty(s.T(), kibanaPrivileges.Global["all"])
+
+	// Validate role
+	err = kibanaPrivileges.ValidateKibanaRole([]KibanaRoleKibana{
+		{
+			Base:   []string{"read"},
+			Spaces: []string{"default"},
+		},
+		{
+			Feature: map[string][]string{
+				"discover": {"all"},
+			},
+			Spaces: []string{"testacc"},
+		},
+	})
+	assert.NoError(s.T(), err)
+
+	err = kibanaPrivileges.ValidateKibanaRole([]KibanaRoleKibana{
+		{
+			Feature: map[string][]string{
+				"discover":    {"fake"},
+				"fakeFeature": {"all"},
+			},
+			Spaces: []string{"*"},
+		},
+	})
+	assert.Error(s.T(), err)
+
+	// Invalidate sessions
+	nbSessions, err := s.API.KibanaSecurity.InvalidateSessions(&KibanaSessionInvalidateParameter{
+		Match: "query",
+		Query: &KibanaSessionInvalidateQuery{
+			Provider: &KibanaSessionProvider{
+				Type: "basic",
+			},
+			Username: "fake-user",
+		},
+	})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, nbSessions)
+
+	_, err = s.API.KibanaSecurity.InvalidateSessions(&KibanaSessionInvalidateParameter{
+		Match: "fake",
+	})
+	assert.Error(s.T(), err)
+}
@@ -0,0 +1,271 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaSettings       = "/api/kibana/settings"        // Base URL to access on Kibana advanced settings of space
+	basePathKibanaGlobalSettings = "/api/kibana/global_settings" // Base URL to access on Kibana advanced settings shared by all spaces
+)
+
+// Keys of common advanced settings
+const (
+	SettingDateFormatTZ           = "dateFormat:tz"
+	SettingDateFormat             = "dateFormat"
+	SettingDefaultIndex           = "defaultIndex"
+	SettingDefaultRoute           = "defaultRoute"
+	SettingDarkMode               = "theme:darkMode"
+	SettingTimepickerTimeDefaults = "timepicker:timeDefaults"
+	SettingQueryLanguage          = "search:queryLanguage"
+	SettingMetaFields             = "metaFields"
+)
+
+// AdvancedSetting is the advanced setting value set by user
+type AdvancedSetting struct {
+	UserValue    interface{} `json:"userValue"`
+	IsOverridden bool        `json:"isOverridden,omitempty"`
+}
+
+// AdvancedSettings is the advanced settings set by user, by key. Settings with default value are not returned by Kibana.
+type AdvancedSettings map[string]AdvancedSetting
+
+// KibanaSettingsGet permit to get advanced settings of space
+type KibanaSettingsGet func(kibanaSpace string) (AdvancedSettings, error)
+
+// KibanaSettingsSet permit to set many advanced settings of space. A nil value reset the setting to it default value.
+type KibanaSettingsSet func(changes map[string]interface{}, kibanaSpace string) (AdvancedSettings, error)
+
+// KibanaSettingsDelete permit to reset advanced settings of space to their default value
+type KibanaSettingsDelete func(keys []string, kibanaSpace string) error
+
+// KibanaSettingsGetGlobal permit to get advanced settings shared by all spaces
+type KibanaSettingsGetGlobal func() (AdvancedSettings, error)
+
+// KibanaSettingsSetGlobal permit to set many advanced settings shared by all spaces. A nil value reset the setting to it default value.
+type KibanaSettingsSetGlobal func(changes map[string]interface{}) (AdvancedSettings, error)
+
+// KibanaSettingsDeleteGlobal permit to reset advanced settings shared by all spaces to their default value
+type KibanaSettingsDeleteGlobal func(keys []string) error
+
+// String permit to return AdvancedSettings object as JSON string
+func (o AdvancedSettings) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// Get return the value of setting, or nil if setting has default value
+func (o AdvancedSettings) Get(key string) interface{} {
+	if setting, ok := o[key]; ok {
+		return setting.UserValue
+	}
+	return nil
+}
+
+// GetString return the value of string setting, or empty string if setting has default value
+func (o AdvancedSettings) GetString(key string) string {
+	value, _ := o.Get(key).(string)
+	return value
+}
+
+// GetBool return the value of boolean setting, or false if setting has default value
+func (o AdvancedSettings) GetBool(key string) bool {
+	value, _ := o.Get(key).(bool)
+	return value
+}
+
+// DateFormatTZ return the timezone used to display dates
+func (o AdvancedSettings) DateFormatTZ() string {
+	return o.GetString(SettingDateFormatTZ)
+}
+
+// DefaultIndex return the ID of default data view
+func (o AdvancedSettings) DefaultIndex() string {
+	return o.GetString(SettingDefaultIndex)
+}
+
+// DefaultRoute return the route opened on Kibana home
+func (o AdvancedSettings) DefaultRoute() string {
+	return o.GetString(SettingDefaultRoute)
+}
+
+// DarkMode return true when dark mode is enabled.
+// Recent Kibana use 'enabled', 'disabled' and 'system' instead of boolean.
+func (o AdvancedSettings) DarkMode() bool {
+	if value, ok := o.Get(SettingDarkMode).(string); ok {
+		return value == "enabled"
+	}
+	return o.GetBool(SettingDarkMode)
+}
+
+// Diff return the changes to apply to get the desired settings. A nil desired value reset the setting.
+// The settings overridden on kibana.yml can't be changed, so they are ignored.
+func (o AdvancedSettings) Diff(desired map[string]interface{}) map[string]interface{} {
+	changes := map[string]interface{}{}
+	for key, desiredValue := range desired {
+		actual, isSet := o[key]
+		if isSet && actual.IsOverridden {
+			log.Debugf("Setting %s is overridden, skip it", key)
+			continue
+		}
+		if desiredValue == nil {
+			if isSet {
+				changes[key] = nil
+			}
+			continue
+		}
+		if !isSet || !reflect.DeepEqual(normalizeSettingValue(desiredValue), actual.UserValue) {
+			changes[key] = desiredValue
+		}
+	}
+
+	return changes
+}
+
+// normalizeSettingValue return the value as decoded from Kibana JSON response, to compare it with actual value
+func normalizeSettingValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalizedValue interface{}
+	if err = json.Unmarshal(data, &normalizedValue); err != nil {
+		return value
+	}
+
+	return normalizedValue
+}
+
+// newKibanaSettingsGetFunc permit to get advanced settings of space
+func newKibanaSettingsGetFunc(c *resty.Client) KibanaSettingsGet {
+	return func(kibanaSpace string) (AdvancedSettings, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return getAdvancedSettings(c, spacePath(kibanaSpace, basePathKibanaSettings))
+	}
+}
+
+// newKibanaSettingsSetFunc permit to set advanced settings of space
+func newKibanaSettingsSetFunc(c *resty.Client) KibanaSettingsSet {
+	return func(changes map[string]interface{}, kibanaSpace string) (AdvancedSettings, error) {
+
+		if len(changes) == 0 {
+			return nil, NewAPIError(600, "You must provide at least one setting to change")
+		}
+		log.Debug("Changes: ", changes)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return setAdvancedSettings(c, spacePath(kibanaSpace, basePathKibanaSettings), changes)
+	}
+}
+
+// newKibanaSettingsDeleteFunc permit to reset advanced settings of space
+func newKibanaSettingsDeleteFunc(c *resty.Client) KibanaSettingsDelete {
+	return func(keys []string, kibanaSpace string) error {
+
+		if len(keys) == 0 {
+			return NewAPIError(600, "You must provide at least one setting to delete")
+		}
+		log.Debug("Keys: ", keys)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		_, err := setAdvancedSettings(c, spacePath(kibanaSpace, basePathKibanaSettings), resetSettingChanges(keys))
+		return err
+	}
+}
+
+// newKibanaSettingsGetGlobalFunc permit to get global advanced settings
+func newKibanaSettingsGetGlobalFunc(c *resty.Client) KibanaSettingsGetGlobal {
+	return func() (AdvancedSettings, error) {
+		return getAdvancedSettings(c, basePathKibanaGlobalSettings)
+	}
+}
+
+// newKibanaSettingsSetGlobalFunc permit to set global advanced settings
+func newKibanaSettingsSetGlobalFunc(c *resty.Client) KibanaSettingsSetGlobal {
+	return func(changes map[string]interface{}) (AdvancedSettings, error) {
+
+		if len(changes) == 0 {
+			return nil, NewAPIError(600, "You must provide at least one setting to change")
+		}
+		log.Debug("Changes: ", changes)
+
+		return setAdvancedSettings(c, basePathKibanaGlobalSettings, changes)
+	}
+}
+
+// newKibanaSettingsDeleteGlobalFunc permit to reset global advanced settings
+func newKibanaSettingsDeleteGlobalFunc(c *resty.Client) KibanaSettingsDeleteGlobal {
+	return func(keys []string) error {
+
+		if len(keys) == 0 {
+			return NewAPIError(600, "You must provide at least one setting to delete")
+		}
+		log.Debug("Keys: ", keys)
+
+		_, err := setAdvancedSettings(c, basePathKibanaGlobalSettings, resetSettingChanges(keys))
+		return err
+	}
+}
+
+// resetSettingChanges return the changes that reset settings to their default value
+func resetSettingChanges(keys []string) map[string]interface{} {
+	changes := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		changes[key] = nil
+	}
+
+	return changes
+}
+
+// getAdvancedSettings permit to read advanced settings
+func getAdvancedSettings(c *resty.Client, path string) (AdvancedSettings, error) {
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return unmarshalAdvancedSettings(resp.Body())
+}
+
+// setAdvancedSettings permit to change advanced settings and read the settings returned
+func setAdvancedSettings(c *resty.Client, path string, changes map[string]interface{}) (AdvancedSettings, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"changes": changes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.R().SetBody(jsonData).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return unmarshalAdvancedSettings(resp.Body())
+}
+
+// unmarshalAdvancedSettings permit to read the settings field from response body
+func unmarshalAdvancedSettings(data []byte) (AdvancedSettings, error) {
+	response := struct {
+		Settings AdvancedSettings `json:"settings"`
+	}{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	log.Debug("AdvancedSettings: ", response.Settings)
+
+	return response.Settings, nil
+}
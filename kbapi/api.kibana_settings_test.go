@@ -0,0 +1,58 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaSettings() {
+
+	// Set settings
+	settings, err := s.API.KibanaSettings.Set(map[string]interface{}{
+		SettingDateFormatTZ: "Europe/Paris",
+		SettingDefaultRoute: "/app/discover",
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Europe/Paris", settings.DateFormatTZ())
+	assert.Equal(s.T(), "/app/discover", settings.DefaultRoute())
+
+	// Get settings
+	settings, err = s.API.KibanaSettings.Get("default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Europe/Paris", settings.DateFormatTZ())
+	assert.False(s.T(), settings.DarkMode())
+
+	// Diff settings
+	changes := settings.Diff(map[string]interface{}{
+		SettingDateFormatTZ: "Europe/Paris",
+		SettingDefaultRoute: nil,
+		SettingMetaFields:   []string{"_source", "_id"},
+	})
+	assert.Equal(s.T(), map[string]interface{}{
+		SettingDefaultRoute: nil,
+		SettingMetaFields:   []string{"_source", "_id"},
+	}, changes)
+	settings, err = s.API.KibanaSettings.Set(changes, "default")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), settings.Diff(map[string]interface{}{
+		SettingDateFormatTZ: "Europe/Paris",
+		SettingDefaultRoute: nil,
+		SettingMetaFields:   []string{"_source", "_id"},
+	}))
+
+	// Delete settings
+	err = s.API.KibanaSettings.Delete([]string{SettingDateFormatTZ, SettingMetaFields}, "default")
+	assert.NoError(s.T(), err)
+	settings, err = s.API.KibanaSettings.Get("default")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), settings.DateFormatTZ())
+	_, err = s.API.KibanaSettings.Set(nil, "default")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// Global settings
+	_, err = s.API.KibanaSettings.GetGlobal()
+	if err != nil && err.(APIError).Code == 404 {
+		s.T().Log("Global settings API not available")
+		return
+	}
+	assert.NoError(s.T(), err)
+}
@@ -2,13 +2,21 @@ package kbapi
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	basePathKibanaShortenURL = "/api/short_url" // Base URL to access on Kibana shorten URL
+	basePathKibanaShortenURL       = "/api/short_url"   // Base URL to access on Kibana shorten URL
+	basePathKibanaLegacyShortenURL = "/api/shorten_url" // Base URL to access on Kibana shorten URL before 7.16
+)
+
+const (
+	// LegacyShortURLLocatorID is the locator that redirect to the URL provided on params.url
+	LegacyShortURLLocatorID = "LEGACY_SHORT_URL_LOCATOR"
 )
 
 // ShortenURL is the shorten URL object
@@ -19,15 +27,61 @@ type ShortenURL struct {
 	HumanReadableSlug bool           `json:"humanReadableSlug,omitempty"`
 }
 
-// ShortenURLResponse is the shorten URL object response
+// ShortenURLResponse is the shorten URL object response. Dates are epoch in milliseconds.
 type ShortenURLResponse struct {
-	ID      string      `json:"id"`
-	Locator *ShortenURL `json:"locator"`
+	ID          string `json:"id"`
+	Slug        string `json:"slug,omitempty"`
+	AccessCount int64  `json:"accessCount"`
+	AccessDate  int64  `json:"accessDate,omitempty"`
+	CreateDate  int64  `json:"createDate,omitempty"`
+
+	// Locator is the locator ID and params of the shorten URL, like on create.
+	// Use LocatorState to get the locator version.
+	Locator *ShortenURL `json:"-"`
+
+	LocatorState *ShortenURLLocator `json:"locator"`
 }
 
-// KibanaShortenURLCreate permit to create new shorten URL
+// ShortenURLLocator is the locator state saved by shorten URL
+type ShortenURLLocator struct {
+	ID      string         `json:"id"`
+	Version string         `json:"version,omitempty"`
+	State   map[string]any `json:"state"`
+}
+
+// UnmarshalJSON fill Locator from the locator state returned by Kibana
+func (o *ShortenURLResponse) UnmarshalJSON(data []byte) error {
+	type shortenURLResponse ShortenURLResponse
+	response := &shortenURLResponse{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return err
+	}
+	*o = ShortenURLResponse(*response)
+	if o.LocatorState != nil {
+		o.Locator = &ShortenURL{
+			LocatorId: o.LocatorState.ID,
+			Params:    o.LocatorState.State,
+			Slug:      o.Slug,
+		}
+	}
+
+	return nil
+}
+
+// KibanaShortenURLCreate permit to create new shorten URL.
+// Before Kibana 7.16, only the legacy locator is supported.
+// The shorten URL API is selected from the Kibana version, so an error is returned when the version can't be read.
 type KibanaShortenURLCreate func(shortenURL *ShortenURL) (*ShortenURLResponse, error)
 
+// KibanaShortenURLGet permit to get shorten URL by its ID
+type KibanaShortenURLGet func(id string) (*ShortenURLResponse, error)
+
+// KibanaShortenURLResolve permit to get shorten URL by its slug
+type KibanaShortenURLResolve func(slug string) (*ShortenURLResponse, error)
+
+// KibanaShortenURLDelete permit to delete shorten URL by its ID
+type KibanaShortenURLDelete func(id string) error
+
 // String permit to return ShortenURL object as JSON string
 func (o *ShortenURL) String() string {
 	json, _ := json.Marshal(o)
@@ -40,8 +94,21 @@ func (o *ShortenURLResponse) String() string {
 	return string(json)
 }
 
+// CreatedAt return the date when shorten URL is created
+func (o *ShortenURLResponse) CreatedAt() time.Time {
+	return time.UnixMilli(o.CreateDate)
+}
+
+// LastAccessedAt return the last date when shorten URL is used, or zero time if it never used
+func (o *ShortenURLResponse) LastAccessedAt() time.Time {
+	if o.AccessCount == 0 || o.AccessDate == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(o.AccessDate)
+}
+
 // newKibanaShortenURLCreateFunc permit to create new shorten URL
-func newKibanaShortenURLCreateFunc(c *resty.Client) KibanaShortenURLCreate {
+func newKibanaShortenURLCreateFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaShortenURLCreate {
 	return func(shortenURL *ShortenURL) (*ShortenURLResponse, error) {
 
 		if shortenURL == nil {
@@ -49,6 +116,14 @@ func newKibanaShortenURLCreateFunc(c *resty.Client) KibanaShortenURLCreate {
 		}
 		log.Debug("Shorten URL: ", shortenURL)
 
+		version, err := versionCache.get()
+		if err != nil {
+			return nil, err
+		}
+		if version.LessThan(7, 16, 0) {
+			return createLegacyShortenURL(c, shortenURL, version)
+		}
+
 		jsonData, err := json.Marshal(shortenURL)
 		if err != nil {
 			return nil, err
@@ -76,3 +151,140 @@ func newKibanaShortenURLCreateFunc(c *resty.Client) KibanaShortenURLCreate {
 		return shortenURLResponse, nil
 	}
 }
+
+// newKibanaShortenURLGetFunc permit to get shorten URL by its ID
+func newKibanaShortenURLGetFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaShortenURLGet {
+	return func(id string) (*ShortenURLResponse, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide shorten URL ID")
+		}
+		log.Debug("ID: ", id)
+
+		version, err := versionCache.get()
+		if err != nil {
+			return nil, err
+		}
+		if version.LessThan(7, 16, 0) {
+			return nil, NewUnsupportedVersionError("Get shorten URL", version)
+		}
+
+		return getShortenURL(c, fmt.Sprintf("%s/%s", basePathKibanaShortenURL, id))
+	}
+}
+
+// newKibanaShortenURLResolveFunc permit to get shorten URL by its slug
+func newKibanaShortenURLResolveFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaShortenURLResolve {
+	return func(slug string) (*ShortenURLResponse, error) {
+
+		if slug == "" {
+			return nil, NewAPIError(600, "You must provide shorten URL slug")
+		}
+		log.Debug("Slug: ", slug)
+
+		version, err := versionCache.get()
+		if err != nil {
+			return nil, err
+		}
+		if version.LessThan(7, 16, 0) {
+			return nil, NewUnsupportedVersionError("Resolve shorten URL", version)
+		}
+
+		return getShortenURL(c, fmt.Sprintf("%s/_slug/%s", basePathKibanaShortenURL, slug))
+	}
+}
+
+// newKibanaShortenURLDeleteFunc permit to delete shorten URL by its ID
+func newKibanaShortenURLDeleteFunc(c *resty.Client, versionCache *kibanaVersionCache) KibanaShortenURLDelete {
+	return func(id string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide shorten URL ID")
+		}
+		log.Debug("ID: ", id)
+
+		version, err := versionCache.get()
+		if err != nil {
+			return err
+		}
+		if version.LessThan(7, 16, 0) {
+			return NewUnsupportedVersionError("Delete shorten URL", version)
+		}
+
+		path := fmt.Sprintf("%s/%s", basePathKibanaShortenURL, id)
+		resp, err := c.R().Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// getShortenURL permit to read shorten URL. It return nil when shorten URL not exist.
+func getShortenURL(c *resty.Client, path string) (*ShortenURLResponse, error) {
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	shortenURLResponse := &ShortenURLResponse{}
+	err = json.Unmarshal(resp.Body(), shortenURLResponse)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("ShortenURLResponse: ", shortenURLResponse)
+
+	return shortenURLResponse, nil
+}
+
+// createLegacyShortenURL permit to create shorten URL with the API used before Kibana 7.16
+func createLegacyShortenURL(c *resty.Client, shortenURL *ShortenURL, version *KibanaVersion) (*ShortenURLResponse, error) {
+	url, isString := shortenURL.Params["url"].(string)
+	if shortenURL.LocatorId != LegacyShortURLLocatorID || !isString {
+		return nil, NewUnsupportedVersionError(fmt.Sprintf("Shorten URL with locator %s", shortenURL.LocatorId), version)
+	}
+
+	jsonData, err := json.Marshal(map[string]string{
+		"url": url,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.R().SetBody(jsonData).Post(basePathKibanaLegacyShortenURL)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	response := struct {
+		URLID string `json:"urlId"`
+	}{}
+	err = json.Unmarshal(resp.Body(), &response)
+	if err != nil {
+		return nil, err
+	}
+	shortenURLResponse := &ShortenURLResponse{
+		ID:      response.URLID,
+		Locator: shortenURL,
+		LocatorState: &ShortenURLLocator{
+			ID:    shortenURL.LocatorId,
+			State: shortenURL.Params,
+		},
+	}
+	log.Debug("ShortenURLResponse: ", shortenURLResponse)
+
+	return shortenURLResponse, nil
+}
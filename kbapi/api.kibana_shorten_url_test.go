@@ -1,6 +1,10 @@
 package kbapi
 
 import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -8,13 +12,124 @@ func (s *KBAPITestSuite) TestKibanaShortenURL() {
 
 	// Create new shorten URL
 	shortenURL := &ShortenURL{
-		LocatorId: "LEGACY_SHORT_URL_LOCATOR",
+		LocatorId: LegacyShortURLLocatorID,
 		Params: map[string]any{
 			"url": "/app/kibana#/dashboard?_g=()&_a=(description:'',filters:!(),fullScreenMode:!f,options:(hidePanelTitles:!f,useMargins:!t),panels:!((embeddableConfig:(),gridData:(h:15,i:'1',w:24,x:0,y:0),id:'8f4d0c00-4c86-11e8-b3d7-01146121b73d',panelIndex:'1',type:visualization,version:'7.0.0-alpha1')),query:(language:lucene,query:''),timeRestore:!f,title:'New%20Dashboard',viewMode:edit)",
 		},
+		Slug: "test-shorten-url",
 	}
 	shortenURLResponse, err := s.API.KibanaShortenURL.Create(shortenURL)
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), shortenURLResponse)
 	assert.NotEmpty(s.T(), shortenURLResponse.ID)
+	assert.Equal(s.T(), "test-shorten-url", shortenURLResponse.Slug)
+	assert.False(s.T(), shortenURLResponse.CreatedAt().IsZero())
+	if assert.NotNil(s.T(), shortenURLResponse.LocatorState) {
+		assert.Equal(s.T(), LegacyShortURLLocatorID, shortenURLResponse.LocatorState.ID)
+		assert.Equal(s.T(), shortenURL.Params["url"], shortenURLResponse.LocatorState.State["url"])
+	}
+
+	// Get shorten URL
+	shortenURLResponse, err = s.API.KibanaShortenURL.Get(shortenURLResponse.ID)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), shortenURLResponse)
+	assert.Equal(s.T(), "test-shorten-url", shortenURLResponse.Slug)
+
+	// Resolve shorten URL
+	resolvedShortenURL, err := s.API.KibanaShortenURL.Resolve("test-shorten-url")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), resolvedShortenURL)
+	assert.Equal(s.T(), shortenURLResponse.ID, resolvedShortenURL.ID)
+	assert.GreaterOrEqual(s.T(), resolvedShortenURL.AccessCount, shortenURLResponse.AccessCount)
+
+	// Delete shorten URL
+	err = s.API.KibanaShortenURL.Delete(shortenURLResponse.ID)
+	assert.NoError(s.T(), err)
+	shortenURLResponse, err = s.API.KibanaShortenURL.Get(shortenURLResponse.ID)
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), shortenURLResponse)
+
+	// Resolve shorten URL that not exist
+	resolvedShortenURL, err = s.API.KibanaShortenURL.Resolve("test-shorten-url")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), resolvedShortenURL)
+
+	// Create shorten URL from dashboard locator params
+	shortenURL, err = NewShortenURL(&DashboardLocatorParams{
+		DashboardID: "edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b",
+		LocatorCommonParams: LocatorCommonParams{
+			TimeRange: &LocatorTimeRange{
+				From: "now-7d",
+				To:   "now",
+			},
+			Filters: []LocatorFilter{
+				NewPhraseFilter("service.name", "api"),
+			},
+		},
+		ViewMode: DashboardViewModeView,
+	})
+	assert.NoError(s.T(), err)
+	shortenURLResponse, err = s.API.KibanaShortenURL.Create(shortenURL)
+	assert.NoError(s.T(), err)
+	if assert.NotNil(s.T(), shortenURLResponse) && assert.NotNil(s.T(), shortenURLResponse.Locator) {
+		assert.Equal(s.T(), LocatorDashboard, shortenURLResponse.Locator.LocatorId)
+		assert.Equal(s.T(), "edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b", shortenURLResponse.Locator.Params["dashboardId"])
+		err = s.API.KibanaShortenURL.Delete(shortenURLResponse.ID)
+		assert.NoError(s.T(), err)
+	}
+}
+
+func TestKibanaShortenURLVersion(t *testing.T) {
+
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		return http.StatusOK, `{"id": "abc", "slug": "test", "accessCount": 0, "locator": {"id": "LEGACY_SHORT_URL_LOCATOR", "state": {"url": "/app/test"}}}`
+	})
+	shortenURL := &ShortenURL{
+		LocatorId: LegacyShortURLLocatorID,
+		Params: map[string]any{
+			"url": "/app/test",
+		},
+	}
+
+	// API can't be selected when version can't be read
+	_, err := kibana.API.KibanaShortenURL.Create(shortenURL)
+	assert.Error(t, err)
+	_, err = kibana.API.KibanaShortenURL.Get("abc")
+	assert.Error(t, err)
+	assert.Empty(t, kibana.Requests)
+
+	// Shorten URL API since Kibana 7.16
+	kibana.Version = "8.12.0"
+	kibana.reset()
+	_, err = kibana.API.KibanaShortenURL.Create(shortenURL)
+	assert.NoError(t, err)
+	assert.Equal(t, basePathKibanaShortenURL, kibana.lastRequest(t).Path)
+}
+
+func TestKibanaShortenURLResponseLocator(t *testing.T) {
+
+	shortenURLResponse := &ShortenURLResponse{}
+	err := json.Unmarshal([]byte(`{"id": "abc", "slug": "test", "accessCount": 0, "locator": {"id": "LEGACY_SHORT_URL_LOCATOR", "version": "8.12.0", "state": {"url": "/app/test"}}}`), shortenURLResponse)
+	assert.NoError(t, err)
+	if assert.NotNil(t, shortenURLResponse.LocatorState) {
+		assert.Equal(t, LegacyShortURLLocatorID, shortenURLResponse.LocatorState.ID)
+		assert.Equal(t, "8.12.0", shortenURLResponse.LocatorState.Version)
+		assert.Equal(t, "/app/test", shortenURLResponse.LocatorState.State["url"])
+	}
+
+	// Locator keep the shorten URL type
+	assert.Equal(t, &ShortenURL{
+		LocatorId: LegacyShortURLLocatorID,
+		Params: map[string]any{
+			"url": "/app/test",
+		},
+		Slug: "test",
+	}, shortenURLResponse.Locator)
+
+	// No locator
+	shortenURLResponse = &ShortenURLResponse{}
+	err = json.Unmarshal([]byte(`{"id": "abc", "accessCount": 0}`), shortenURLResponse)
+	assert.NoError(t, err)
+	assert.Nil(t, shortenURLResponse.Locator)
+	assert.Nil(t, shortenURLResponse.LocatorState)
 }
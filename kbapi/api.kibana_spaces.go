@@ -272,3 +272,12 @@ func newKibanaSpaceUpdateFunc(c *resty.Client) KibanaSpaceUpdate {
 	}
 
 }
+
+// spacePath return the path to call API on Kibana space
+func spacePath(kibanaSpace string, path string) string {
+	if kibanaSpace == "" || kibanaSpace == "default" {
+		return path
+	}
+
+	return fmt.Sprintf("/s/%s%s", kibanaSpace, path)
+}
@@ -1,7 +1,11 @@
 package kbapi
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	log "github.com/sirupsen/logrus"
@@ -11,12 +15,233 @@ const (
 	basePathKibanaStatus = "/api/status" // Base URL to access on Kibana status
 )
 
+// Levels of Kibana status
+const (
+	KibanaStatusLevelAvailable   = "available"
+	KibanaStatusLevelDegraded    = "degraded"
+	KibanaStatusLevelUnavailable = "unavailable"
+	KibanaStatusLevelCritical    = "critical"
+)
+
 // KibanaStatus is the map of string that contain the API status
 type KibanaStatus map[string]interface{}
 
+// KibanaStatusDetails is the Kibana status, normalized from 7.x and 8.x formats
+type KibanaStatusDetails struct {
+	Name    string                         `json:"name"`
+	UUID    string                         `json:"uuid"`
+	Version KibanaStatusVersion            `json:"version"`
+	Overall KibanaServiceStatus            `json:"overall"`
+	Core    map[string]KibanaServiceStatus `json:"core,omitempty"`
+	Plugins map[string]KibanaServiceStatus `json:"plugins,omitempty"`
+	Metrics *KibanaStatusMetrics           `json:"metrics,omitempty"`
+}
+
+// KibanaStatusVersion is the version and build of Kibana
+type KibanaStatusVersion struct {
+	Number        string `json:"number"`
+	BuildHash     string `json:"build_hash,omitempty"`
+	BuildNumber   int64  `json:"build_number,omitempty"`
+	BuildSnapshot bool   `json:"build_snapshot"`
+	BuildFlavor   string `json:"build_flavor,omitempty"`
+	BuildDate     string `json:"build_date,omitempty"`
+}
+
+// KibanaServiceStatus is the status of Kibana, core service or plugin.
+// Level is available, degraded, unavailable or critical. The 7.x state green, yellow and red are converted on level.
+type KibanaServiceStatus struct {
+	Level   string                 `json:"level"`
+	Summary string                 `json:"summary,omitempty"`
+	Meta    map[string]interface{} `json:"meta,omitempty"`
+}
+
+// KibanaStatusMetrics is the metrics of Kibana process
+type KibanaStatusMetrics struct {
+	LastUpdated                string                 `json:"last_updated"`
+	CollectionIntervalInMillis int64                  `json:"collection_interval_in_millis"`
+	OS                         map[string]interface{} `json:"os,omitempty"`
+	Process                    map[string]interface{} `json:"process,omitempty"`
+	ResponseTimes              struct {
+		AvgInMillis float64 `json:"avg_in_millis"`
+		MaxInMillis float64 `json:"max_in_millis"`
+	} `json:"response_times"`
+	ConcurrentConnections int64 `json:"concurrent_connections"`
+	Requests              struct {
+		Disconnects int64 `json:"disconnects"`
+		Total       int64 `json:"total"`
+	} `json:"requests"`
+}
+
+// KibanaStatusOptions contain optional parameters to get Kibana status
+type KibanaStatusOptions struct {
+	// V7Format ask the 7.x format, with state and statuses list
+	V7Format bool
+	// V8Format ask the 8.x format, with level and core and plugins maps
+	V8Format bool
+}
+
+// KibanaStatusWaitOptions contain optional parameters to wait that Kibana is ready
+type KibanaStatusWaitOptions struct {
+	// MinimumLevel is the worst overall level accepted. Default to available.
+	MinimumLevel string
+	// Timeout is the maximum duration to wait. Default to no timeout, only the context can stop the wait.
+	Timeout time.Duration
+	// InitialInterval is the duration between the two first tries. Default to 1s.
+	InitialInterval time.Duration
+	// MaxInterval is the maximum duration between two tries. Default to 30s.
+	MaxInterval time.Duration
+	// Multiplier is applied on interval after each try. Default to 2.
+	Multiplier float64
+	// OnProgress is called after each try, with the status or the error returned
+	OnProgress func(attempt int, status *KibanaStatusDetails, err error)
+}
+
+// KibanaStatusNotReadyError is the error returned when Kibana is not ready before the end of wait
+type KibanaStatusNotReadyError struct {
+	Attempts   int
+	LastStatus *KibanaStatusDetails
+	LastError  error
+	Err        error
+}
+
+// kibanaStatusResponse is the status as returned by Kibana 7.x or 8.x
+type kibanaStatusResponse struct {
+	Name    string               `json:"name"`
+	UUID    string               `json:"uuid"`
+	Version KibanaStatusVersion  `json:"version"`
+	Metrics *KibanaStatusMetrics `json:"metrics"`
+	Status  struct {
+		Overall struct {
+			KibanaServiceStatus
+			State    string `json:"state"`
+			Title    string `json:"title"`
+			Nickname string `json:"nickname"`
+		} `json:"overall"`
+		Core     map[string]KibanaServiceStatus `json:"core"`
+		Plugins  map[string]KibanaServiceStatus `json:"plugins"`
+		Statuses []struct {
+			ID      string `json:"id"`
+			State   string `json:"state"`
+			Message string `json:"message"`
+		} `json:"statuses"`
+	} `json:"status"`
+}
+
 // KibanaStatusGet permit to get the current status of Kibana
 type KibanaStatusGet func() (KibanaStatus, error)
 
+// KibanaStatusGetDetails permit to get the current status of Kibana as typed object
+type KibanaStatusGetDetails func(options *KibanaStatusOptions) (*KibanaStatusDetails, error)
+
+// KibanaStatusWaitUntilReady permit to wait that Kibana overall status reach the minimum level
+type KibanaStatusWaitUntilReady func(ctx context.Context, options *KibanaStatusWaitOptions) (*KibanaStatusDetails, error)
+
+// Error return error message, with the last status observed
+func (e *KibanaStatusNotReadyError) Error() string {
+	if e.LastStatus != nil {
+		return fmt.Sprintf("Kibana is not ready after %d attempts (%s), last status is %s: %s", e.Attempts, e.Err, e.LastStatus.Overall.Level, e.LastStatus.Overall.Summary)
+	}
+	return fmt.Sprintf("Kibana is not ready after %d attempts (%s), last error is: %s", e.Attempts, e.Err, e.LastError)
+}
+
+// Unwrap return the context error that stop the wait
+func (e *KibanaStatusNotReadyError) Unwrap() error {
+	return e.Err
+}
+
+// String permit to return KibanaStatusDetails object as JSON string
+func (o *KibanaStatusDetails) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// IsAvailable return true when Kibana overall status is available
+func (o *KibanaStatusDetails) IsAvailable() bool {
+	return o.Overall.Level == KibanaStatusLevelAvailable
+}
+
+// HasLevel return true when Kibana overall status is the same or better than the minimum level
+func (o *KibanaStatusDetails) HasLevel(minimumLevel string) bool {
+	return kibanaStatusLevelRank(o.Overall.Level) <= kibanaStatusLevelRank(minimumLevel)
+}
+
+// kibanaStatusLevelRank return the rank of level, from the best to the worst
+func kibanaStatusLevelRank(level string) int {
+	switch level {
+	case KibanaStatusLevelAvailable:
+		return 0
+	case KibanaStatusLevelDegraded:
+		return 1
+	case KibanaStatusLevelUnavailable:
+		return 2
+	case KibanaStatusLevelCritical:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// toDetails return the status normalized
+func (o *kibanaStatusResponse) toDetails() *KibanaStatusDetails {
+	details := &KibanaStatusDetails{
+		Name:    o.Name,
+		UUID:    o.UUID,
+		Version: o.Version,
+		Overall: o.Status.Overall.KibanaServiceStatus,
+		Core:    o.Status.Core,
+		Plugins: o.Status.Plugins,
+		Metrics: o.Metrics,
+	}
+
+	// 7.x format
+	if o.Status.Overall.State != "" {
+		details.Overall.Level = levelFromState(o.Status.Overall.State)
+		details.Overall.Summary = o.Status.Overall.Title
+		if o.Status.Overall.Nickname != "" {
+			details.Overall.Summary = o.Status.Overall.Nickname
+		}
+	}
+	for _, status := range o.Status.Statuses {
+		// ID look like core:elasticsearch@7.17.0 or plugin:taskManager@7.17.0
+		kind, name, found := strings.Cut(strings.Split(status.ID, "@")[0], ":")
+		if !found {
+			continue
+		}
+		serviceStatus := KibanaServiceStatus{
+			Level:   levelFromState(status.State),
+			Summary: status.Message,
+		}
+		switch kind {
+		case "core":
+			if details.Core == nil {
+				details.Core = map[string]KibanaServiceStatus{}
+			}
+			details.Core[name] = serviceStatus
+		case "plugin":
+			if details.Plugins == nil {
+				details.Plugins = map[string]KibanaServiceStatus{}
+			}
+			details.Plugins[name] = serviceStatus
+		}
+	}
+
+	return details
+}
+
+// levelFromState convert the 7.x state on 8.x level
+func levelFromState(state string) string {
+	switch state {
+	case "green":
+		return KibanaStatusLevelAvailable
+	case "yellow":
+		return KibanaStatusLevelDegraded
+	case "red":
+		return KibanaStatusLevelUnavailable
+	default:
+		return state
+	}
+}
+
 // newKibanaStatusGetFunc permit to get the kibana status and some usefull information
 func newKibanaStatusGetFunc(c *resty.Client) KibanaStatusGet {
 	return func() (KibanaStatus, error) {
@@ -41,3 +266,118 @@ func newKibanaStatusGetFunc(c *resty.Client) KibanaStatusGet {
 		return kibanaStatus, nil
 	}
 }
+
+// newKibanaStatusGetDetailsFunc permit to get the kibana status as typed object
+func newKibanaStatusGetDetailsFunc(c *resty.Client) KibanaStatusGetDetails {
+	return func(options *KibanaStatusOptions) (*KibanaStatusDetails, error) {
+		return getStatusDetails(context.Background(), c, options)
+	}
+}
+
+// getStatusDetails permit to get the kibana status as typed object. The call is stopped when context is done.
+func getStatusDetails(ctx context.Context, c *resty.Client, options *KibanaStatusOptions) (*KibanaStatusDetails, error) {
+
+	log.Debug("Options: ", options)
+
+	queryParams := map[string]string{}
+	if options != nil {
+		if options.V7Format && options.V8Format {
+			return nil, NewAPIError(600, "You can't ask v7format and v8format together")
+		}
+		if options.V7Format {
+			queryParams["v7format"] = "true"
+		}
+		if options.V8Format {
+			queryParams["v8format"] = "true"
+		}
+	}
+
+	resp, err := c.R().SetContext(ctx).SetQueryParams(queryParams).Get(basePathKibanaStatus)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	// Kibana return service unavailable with the status when it is not ready
+	if resp.StatusCode() >= 300 && resp.StatusCode() != 503 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	kibanaStatusResponse := &kibanaStatusResponse{}
+	err = json.Unmarshal(resp.Body(), kibanaStatusResponse)
+	if err != nil {
+		if resp.StatusCode() == 503 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		return nil, err
+	}
+	kibanaStatusDetails := kibanaStatusResponse.toDetails()
+	log.Debug("KibanaStatusDetails: ", kibanaStatusDetails)
+
+	return kibanaStatusDetails, nil
+}
+
+// newKibanaStatusWaitUntilReadyFunc permit to wait that Kibana is ready, with exponential backoff between tries
+func newKibanaStatusWaitUntilReadyFunc(c *resty.Client) KibanaStatusWaitUntilReady {
+	return func(ctx context.Context, options *KibanaStatusWaitOptions) (*KibanaStatusDetails, error) {
+
+		if options == nil {
+			options = &KibanaStatusWaitOptions{}
+		}
+		minimumLevel := options.MinimumLevel
+		if minimumLevel == "" {
+			minimumLevel = KibanaStatusLevelAvailable
+		}
+		if kibanaStatusLevelRank(minimumLevel) > kibanaStatusLevelRank(KibanaStatusLevelCritical) {
+			return nil, NewAPIError(600, "Minimum level '%s' is not valid level", minimumLevel)
+		}
+		interval := options.InitialInterval
+		if interval <= 0 {
+			interval = 1 * time.Second
+		}
+		maxInterval := options.MaxInterval
+		if maxInterval <= 0 {
+			maxInterval = 30 * time.Second
+		}
+		multiplier := options.Multiplier
+		if multiplier < 1 {
+			multiplier = 2
+		}
+		if options.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+			defer cancel()
+		}
+		log.Debug("MinimumLevel: ", minimumLevel)
+		log.Debug("Timeout: ", options.Timeout)
+
+		notReadyError := &KibanaStatusNotReadyError{}
+		for {
+			notReadyError.Attempts++
+			// The context stop also the call in progress, when Kibana accept connection but not answer
+			status, err := getStatusDetails(ctx, c, nil)
+			if err == nil {
+				notReadyError.LastStatus = status
+			}
+			notReadyError.LastError = err
+			if options.OnProgress != nil {
+				options.OnProgress(notReadyError.Attempts, status, err)
+			}
+			if err == nil && status.HasLevel(minimumLevel) {
+				return status, nil
+			}
+			log.Debugf("Kibana is not ready, wait %s before next try", interval)
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				notReadyError.Err = ctx.Err()
+				return notReadyError.LastStatus, notReadyError
+			case <-timer.C:
+			}
+			interval = time.Duration(float64(interval) * multiplier)
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}
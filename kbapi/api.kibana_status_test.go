@@ -1,6 +1,9 @@
 package kbapi
 
 import (
+	"context"
+	"encoding/json"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,4 +13,35 @@ func (s *KBAPITestSuite) TestKibanaStatus() {
 	kibanaStatus, err := s.API.KibanaStatus.Get()
 	assert.NoError(s.T(), err)
 	assert.NotEmpty(s.T(), kibanaStatus)
+
+	// Get typed status
+	kibanaStatusDetails, err := s.API.KibanaStatus.GetDetails(nil)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), kibanaStatusDetails)
+	assert.NotEmpty(s.T(), kibanaStatusDetails.Version.Number)
+	assert.True(s.T(), kibanaStatusDetails.IsAvailable())
+	assert.Contains(s.T(), kibanaStatusDetails.Core, "elasticsearch")
+	assert.NotEmpty(s.T(), kibanaStatusDetails.Plugins)
+
+	kibanaStatusDetails, err = s.API.KibanaStatus.GetDetails(&KibanaStatusOptions{V8Format: true})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), KibanaStatusLevelAvailable, kibanaStatusDetails.Overall.Level)
+	_, err = s.API.KibanaStatus.GetDetails(&KibanaStatusOptions{V7Format: true, V8Format: true})
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// Wait Kibana is ready
+	kibanaStatusDetails, err = s.API.KibanaStatus.WaitUntilReady(context.Background(), &KibanaStatusWaitOptions{
+		MinimumLevel: KibanaStatusLevelDegraded,
+	})
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), kibanaStatusDetails.HasLevel(KibanaStatusLevelDegraded))
+
+	// Normalize 7.x format
+	kibanaStatusResponse := &kibanaStatusResponse{}
+	err = json.Unmarshal([]byte(`{"name":"kb","version":{"number":"7.17.0"},"status":{"overall":{"state":"yellow","title":"Yellow"},"statuses":[{"id":"core:elasticsearch@7.17.0","state":"green","message":"Elasticsearch is available"},{"id":"plugin:taskManager@7.17.0","state":"yellow","message":"Task Manager is degraded"}]}}`), kibanaStatusResponse)
+	assert.NoError(s.T(), err)
+	kibanaStatusDetails = kibanaStatusResponse.toDetails()
+	assert.Equal(s.T(), KibanaStatusLevelDegraded, kibanaStatusDetails.Overall.Level)
+	assert.Equal(s.T(), KibanaStatusLevelAvailable, kibanaStatusDetails.Core["elasticsearch"].Level)
+	assert.Equal(s.T(), "Task Manager is degraded", kibanaStatusDetails.Plugins["taskManager"].Summary)
 }
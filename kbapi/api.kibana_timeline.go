@@ -0,0 +1,652 @@
+package kbapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaTimeline    = "/api/timeline"     // Base URL to access on Kibana timeline API
+	basePathKibanaTimelines   = "/api/timelines"    // Base URL to list Kibana timelines
+	basePathKibanaPinnedEvent = "/api/pinned_event" // Base URL to pin event on Kibana timeline
+)
+
+// Types of timeline
+const (
+	TimelineTypeDefault  = "default"
+	TimelineTypeTemplate = "template"
+)
+
+// Status of timeline
+const (
+	TimelineStatusActive    = "active"
+	TimelineStatusDraft     = "draft"
+	TimelineStatusImmutable = "immutable"
+)
+
+// timelineReadOnlyFields is the fields computed by Kibana, that can't be written
+var timelineReadOnlyFields = []string{"savedObjectId", "version", "created", "createdBy", "updated", "updatedBy", "noteIds", "notes", "pinnedEventIds", "pinnedEventsSaveObject", "eventIdToNoteIds"}
+
+// Timeline is the security timeline, or timeline template, used by analysts to investigate events
+type Timeline struct {
+	SavedObjectID           string                   `json:"savedObjectId,omitempty"`
+	Version                 string                   `json:"version,omitempty"`
+	Title                   string                   `json:"title"`
+	Description             string                   `json:"description,omitempty"`
+	TimelineType            string                   `json:"timelineType,omitempty"`
+	TemplateTimelineID      string                   `json:"templateTimelineId,omitempty"`
+	TemplateTimelineVersion int                      `json:"templateTimelineVersion,omitempty"`
+	Status                  string                   `json:"status,omitempty"`
+	Columns                 []TimelineColumn         `json:"columns,omitempty"`
+	DataProviders           []map[string]interface{} `json:"dataProviders,omitempty"`
+	DataViewID              string                   `json:"dataViewId,omitempty"`
+	IndexNames              []string                 `json:"indexNames,omitempty"`
+	KqlMode                 string                   `json:"kqlMode,omitempty"`
+	KqlQuery                map[string]interface{}   `json:"kqlQuery,omitempty"`
+	Filters                 []map[string]interface{} `json:"filters,omitempty"`
+	DateRange               *TimelineDateRange       `json:"dateRange,omitempty"`
+	EqlOptions              map[string]interface{}   `json:"eqlOptions,omitempty"`
+	EventType               string                   `json:"eventType,omitempty"`
+	SavedQueryID            string                   `json:"savedQueryId,omitempty"`
+	Sort                    interface{}              `json:"sort,omitempty"`
+	ExcludedRowRendererIDs  []string                 `json:"excludedRowRendererIds,omitempty"`
+	Favorite                []TimelineFavorite       `json:"favorite,omitempty"`
+	Created                 int64                    `json:"created,omitempty"`
+	CreatedBy               string                   `json:"createdBy,omitempty"`
+	Updated                 int64                    `json:"updated,omitempty"`
+	UpdatedBy               string                   `json:"updatedBy,omitempty"`
+	NoteIDs                 []string                 `json:"noteIds,omitempty"`
+	Notes                   []map[string]interface{} `json:"notes,omitempty"`
+	PinnedEventIDs          []string                 `json:"pinnedEventIds,omitempty"`
+	PinnedEventsSaveObject  []TimelinePinnedEvent    `json:"pinnedEventsSaveObject,omitempty"`
+	EventIDToNoteIDs        []map[string]interface{} `json:"eventIdToNoteIds,omitempty"`
+}
+
+// Timelines is list of Timeline object
+type Timelines []Timeline
+
+// TimelineColumn is the column displayed on timeline
+type TimelineColumn struct {
+	ColumnHeaderType string `json:"columnHeaderType,omitempty"`
+	ID               string `json:"id"`
+	Category         string `json:"category,omitempty"`
+	Type             string `json:"type,omitempty"`
+	InitialWidth     int    `json:"initialWidth,omitempty"`
+}
+
+// TimelineDateRange is the time range of timeline. Start and end can be date string or epoch.
+type TimelineDateRange struct {
+	Start interface{} `json:"start"`
+	End   interface{} `json:"end"`
+}
+
+// TimelineFavorite is the user that put timeline on favorite
+type TimelineFavorite struct {
+	FullName     string `json:"fullName,omitempty"`
+	UserName     string `json:"userName,omitempty"`
+	FavoriteDate int64  `json:"favoriteDate,omitempty"`
+}
+
+// TimelineFavoriteResponse is the result when put timeline on favorite
+type TimelineFavoriteResponse struct {
+	SavedObjectID           string             `json:"savedObjectId"`
+	Version                 string             `json:"version"`
+	TimelineType            string             `json:"timelineType,omitempty"`
+	TemplateTimelineID      string             `json:"templateTimelineId,omitempty"`
+	TemplateTimelineVersion int                `json:"templateTimelineVersion,omitempty"`
+	Favorite                []TimelineFavorite `json:"favorite"`
+}
+
+// TimelinePinnedEvent is the event pinned on timeline
+type TimelinePinnedEvent struct {
+	PinnedEventID string `json:"pinnedEventId"`
+	EventID       string `json:"eventId"`
+	TimelineID    string `json:"timelineId"`
+	Version       string `json:"version,omitempty"`
+	Created       int64  `json:"created,omitempty"`
+	CreatedBy     string `json:"createdBy,omitempty"`
+	Updated       int64  `json:"updated,omitempty"`
+	UpdatedBy     string `json:"updatedBy,omitempty"`
+}
+
+// TimelineListParameters contain optional parameters to list timelines
+type TimelineListParameters struct {
+	TimelineType     string
+	Status           string
+	Search           string
+	PageIndex        int
+	PageSize         int
+	SortField        string
+	SortOrder        string
+	OnlyUserFavorite bool
+}
+
+// TimelineListResponse is the result when list timelines
+type TimelineListResponse struct {
+	Timeline                     Timelines `json:"timeline"`
+	TotalCount                   int       `json:"totalCount"`
+	DefaultTimelineCount         int       `json:"defaultTimelineCount"`
+	TemplateTimelineCount        int       `json:"templateTimelineCount"`
+	FavoriteCount                int       `json:"favoriteCount"`
+	ElasticTemplateTimelineCount int       `json:"elasticTemplateTimelineCount"`
+	CustomTemplateTimelineCount  int       `json:"customTemplateTimelineCount"`
+}
+
+// TimelineImportResponse is the result of timelines import or prebuilt templates install
+type TimelineImportResponse struct {
+	Success            bool                     `json:"success"`
+	SuccessCount       int                      `json:"success_count"`
+	TimelinesInstalled int                      `json:"timelines_installed"`
+	TimelinesUpdated   int                      `json:"timelines_updated"`
+	Errors             []map[string]interface{} `json:"errors"`
+}
+
+// KibanaTimelineGet permit to get timeline with it saved object ID
+type KibanaTimelineGet func(id string, kibanaSpace string) (*Timeline, error)
+
+// KibanaTimelineGetTemplate permit to get timeline template with it template timeline ID
+type KibanaTimelineGetTemplate func(templateTimelineID string, kibanaSpace string) (*Timeline, error)
+
+// KibanaTimelineList permit to list timelines and timeline templates
+type KibanaTimelineList func(parameters *TimelineListParameters, kibanaSpace string) (*TimelineListResponse, error)
+
+// KibanaTimelineCreate permit to create timeline or timeline template
+type KibanaTimelineCreate func(timeline *Timeline, kibanaSpace string) (*Timeline, error)
+
+// KibanaTimelinePatch permit to update timeline. The timeline must have saved object ID and version.
+type KibanaTimelinePatch func(timeline *Timeline, kibanaSpace string) (*Timeline, error)
+
+// KibanaTimelineDelete permit to delete timelines with their saved object IDs
+type KibanaTimelineDelete func(ids []string, kibanaSpace string) error
+
+// KibanaTimelineExport permit to export timelines as NDJSON
+type KibanaTimelineExport func(ids []string, kibanaSpace string) ([]byte, error)
+
+// KibanaTimelineImport permit to import timelines from NDJSON
+type KibanaTimelineImport func(data []byte, kibanaSpace string) (*TimelineImportResponse, error)
+
+// KibanaTimelineInstallPrebuiltTemplates permit to install and update the prebuilt timeline templates
+type KibanaTimelineInstallPrebuiltTemplates func(kibanaSpace string) (*TimelineImportResponse, error)
+
+// KibanaTimelineFavorite permit to add or remove timeline from favorites of current user
+type KibanaTimelineFavorite func(timeline *Timeline, kibanaSpace string) (*TimelineFavoriteResponse, error)
+
+// KibanaTimelinePinEvent permit to pin event on timeline
+type KibanaTimelinePinEvent func(timelineID string, eventID string, kibanaSpace string) (*TimelinePinnedEvent, error)
+
+// KibanaTimelineUnpinEvent permit to unpin event from timeline
+type KibanaTimelineUnpinEvent func(timelineID string, eventID string, pinnedEventID string, kibanaSpace string) error
+
+// String permit to return Timeline object as JSON string
+func (o *Timeline) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// toRequest return the timeline fields that can be written
+func (o *Timeline) toRequest() (map[string]interface{}, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{}
+	if err = json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	for _, field := range timelineReadOnlyFields {
+		delete(request, field)
+	}
+
+	return request, nil
+}
+
+// newKibanaTimelineGetFunc permit to get timeline with it ID
+func newKibanaTimelineGetFunc(c *resty.Client) KibanaTimelineGet {
+	return func(id string, kibanaSpace string) (*Timeline, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide timeline ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return getTimeline(c, "id", id, kibanaSpace)
+	}
+}
+
+// newKibanaTimelineGetTemplateFunc permit to get timeline template with it template timeline ID
+func newKibanaTimelineGetTemplateFunc(c *resty.Client) KibanaTimelineGetTemplate {
+	return func(templateTimelineID string, kibanaSpace string) (*Timeline, error) {
+
+		if templateTimelineID == "" {
+			return nil, NewAPIError(600, "You must provide template timeline ID")
+		}
+		log.Debug("TemplateTimelineID: ", templateTimelineID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return getTimeline(c, "template_timeline_id", templateTimelineID, kibanaSpace)
+	}
+}
+
+// newKibanaTimelineListFunc permit to list timelines
+func newKibanaTimelineListFunc(c *resty.Client) KibanaTimelineList {
+	return func(parameters *TimelineListParameters, kibanaSpace string) (*TimelineListResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := map[string]string{}
+		if parameters != nil {
+			if parameters.TimelineType != "" {
+				queryParams["timeline_type"] = parameters.TimelineType
+			}
+			if parameters.Status != "" {
+				queryParams["status"] = parameters.Status
+			}
+			if parameters.Search != "" {
+				queryParams["search"] = parameters.Search
+			}
+			if parameters.PageIndex != 0 {
+				queryParams["page_index"] = strconv.Itoa(parameters.PageIndex)
+			}
+			if parameters.PageSize != 0 {
+				queryParams["page_size"] = strconv.Itoa(parameters.PageSize)
+			}
+			if parameters.SortField != "" {
+				queryParams["sort_field"] = parameters.SortField
+			}
+			if parameters.SortOrder != "" {
+				queryParams["sort_order"] = parameters.SortOrder
+			}
+			if parameters.OnlyUserFavorite {
+				queryParams["only_user_favorite"] = "true"
+			}
+		}
+
+		path := spacePath(kibanaSpace, basePathKibanaTimelines)
+		resp, err := c.R().SetQueryParams(queryParams).Get(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		timelineListResponse := &TimelineListResponse{}
+		err = json.Unmarshal(resp.Body(), timelineListResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Timelines: ", timelineListResponse.Timeline)
+
+		return timelineListResponse, nil
+	}
+}
+
+// newKibanaTimelineCreateFunc permit to create timeline
+func newKibanaTimelineCreateFunc(c *resty.Client) KibanaTimelineCreate {
+	return func(timeline *Timeline, kibanaSpace string) (*Timeline, error) {
+
+		if timeline == nil {
+			return nil, NewAPIError(600, "You must provide timeline object")
+		}
+		log.Debug("Timeline: ", timeline)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request, err := timeline.toRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		return persistTimeline(c, http.MethodPost, map[string]interface{}{
+			"timeline": request,
+		}, kibanaSpace)
+	}
+}
+
+// newKibanaTimelinePatchFunc permit to update timeline
+func newKibanaTimelinePatchFunc(c *resty.Client) KibanaTimelinePatch {
+	return func(timeline *Timeline, kibanaSpace string) (*Timeline, error) {
+
+		if timeline == nil {
+			return nil, NewAPIError(600, "You must provide timeline object")
+		}
+		if timeline.SavedObjectID == "" || timeline.Version == "" {
+			return nil, NewAPIError(600, "You must provide timeline ID and version")
+		}
+		log.Debug("Timeline: ", timeline)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		request, err := timeline.toRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		return persistTimeline(c, http.MethodPatch, map[string]interface{}{
+			"timelineId": timeline.SavedObjectID,
+			"version":    timeline.Version,
+			"timeline":   request,
+		}, kibanaSpace)
+	}
+}
+
+// newKibanaTimelineDeleteFunc permit to delete timelines
+func newKibanaTimelineDeleteFunc(c *resty.Client) KibanaTimelineDelete {
+	return func(ids []string, kibanaSpace string) error {
+
+		if len(ids) == 0 {
+			return NewAPIError(600, "You must provide at least one timeline ID")
+		}
+		log.Debug("IDs: ", ids)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"savedObjectIds": ids,
+		})
+		if err != nil {
+			return err
+		}
+		path := spacePath(kibanaSpace, basePathKibanaTimeline)
+		resp, err := c.R().SetBody(jsonData).Delete(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaTimelineExportFunc permit to export timelines
+func newKibanaTimelineExportFunc(c *resty.Client) KibanaTimelineExport {
+	return func(ids []string, kibanaSpace string) ([]byte, error) {
+
+		if len(ids) == 0 {
+			return nil, NewAPIError(600, "You must provide at least one timeline ID")
+		}
+		log.Debug("IDs: ", ids)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"ids": ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_export", basePathKibanaTimeline))
+		resp, err := c.R().
+			SetQueryParam("file_name", "timelines_export.ndjson").
+			SetBody(jsonData).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return resp.Body(), nil
+	}
+}
+
+// newKibanaTimelineImportFunc permit to import timelines
+func newKibanaTimelineImportFunc(c *resty.Client) KibanaTimelineImport {
+	return func(data []byte, kibanaSpace string) (*TimelineImportResponse, error) {
+
+		if len(data) == 0 {
+			return nil, NewAPIError(600, "You must provide data parameters")
+		}
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_import", basePathKibanaTimeline))
+		resp, err := c.R().
+			SetFileReader("file", "timelines.ndjson", bytes.NewReader(data)).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		timelineImportResponse := &TimelineImportResponse{}
+		err = json.Unmarshal(resp.Body(), timelineImportResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ImportResponse: ", timelineImportResponse)
+
+		return timelineImportResponse, nil
+	}
+}
+
+// newKibanaTimelineInstallPrebuiltTemplatesFunc permit to install the prebuilt timeline templates
+func newKibanaTimelineInstallPrebuiltTemplatesFunc(c *resty.Client) KibanaTimelineInstallPrebuiltTemplates {
+	return func(kibanaSpace string) (*TimelineImportResponse, error) {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_prepackaged", basePathKibanaTimeline))
+		resp, err := c.R().Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		timelineImportResponse := &TimelineImportResponse{}
+		err = json.Unmarshal(resp.Body(), timelineImportResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ImportResponse: ", timelineImportResponse)
+
+		return timelineImportResponse, nil
+	}
+}
+
+// newKibanaTimelineFavoriteFunc permit to toggle timeline on favorites
+func newKibanaTimelineFavoriteFunc(c *resty.Client) KibanaTimelineFavorite {
+	return func(timeline *Timeline, kibanaSpace string) (*TimelineFavoriteResponse, error) {
+
+		if timeline == nil || (timeline.SavedObjectID == "" && timeline.TemplateTimelineID == "") {
+			return nil, NewAPIError(600, "You must provide timeline with ID or template timeline ID")
+		}
+		log.Debug("Timeline: ", timeline)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		payload := map[string]interface{}{
+			"timelineId":              nil,
+			"templateTimelineId":      nil,
+			"templateTimelineVersion": nil,
+			"timelineType":            timeline.TimelineType,
+		}
+		if timeline.SavedObjectID != "" {
+			payload["timelineId"] = timeline.SavedObjectID
+		}
+		if timeline.TemplateTimelineID != "" {
+			payload["templateTimelineId"] = timeline.TemplateTimelineID
+			payload["templateTimelineVersion"] = timeline.TemplateTimelineVersion
+		}
+		if timeline.TimelineType == "" {
+			payload["timelineType"] = TimelineTypeDefault
+		}
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_favorite", basePathKibanaTimeline))
+		resp, err := c.R().SetBody(jsonData).Patch(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		timelineFavoriteResponse := &TimelineFavoriteResponse{}
+		err = unmarshalTimelineResponse(resp.Body(), timelineFavoriteResponse, "persistFavorite")
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("FavoriteResponse: ", timelineFavoriteResponse)
+
+		return timelineFavoriteResponse, nil
+	}
+}
+
+// newKibanaTimelinePinEventFunc permit to pin event on timeline
+func newKibanaTimelinePinEventFunc(c *resty.Client) KibanaTimelinePinEvent {
+	return func(timelineID string, eventID string, kibanaSpace string) (*TimelinePinnedEvent, error) {
+
+		if timelineID == "" || eventID == "" {
+			return nil, NewAPIError(600, "You must provide timeline ID and event ID")
+		}
+		log.Debug("TimelineID: ", timelineID)
+		log.Debug("EventID: ", eventID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		pinnedEvent := &TimelinePinnedEvent{}
+		err := persistPinnedEvent(c, map[string]interface{}{
+			"timelineId": timelineID,
+			"eventId":    eventID,
+		}, pinnedEvent, kibanaSpace)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("PinnedEvent: ", pinnedEvent)
+
+		return pinnedEvent, nil
+	}
+}
+
+// newKibanaTimelineUnpinEventFunc permit to unpin event from timeline
+func newKibanaTimelineUnpinEventFunc(c *resty.Client) KibanaTimelineUnpinEvent {
+	return func(timelineID string, eventID string, pinnedEventID string, kibanaSpace string) error {
+
+		if timelineID == "" || eventID == "" || pinnedEventID == "" {
+			return NewAPIError(600, "You must provide timeline ID, event ID and pinned event ID")
+		}
+		log.Debug("TimelineID: ", timelineID)
+		log.Debug("EventID: ", eventID)
+		log.Debug("PinnedEventID: ", pinnedEventID)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		return persistPinnedEvent(c, map[string]interface{}{
+			"timelineId":    timelineID,
+			"eventId":       eventID,
+			"pinnedEventId": pinnedEventID,
+		}, nil, kibanaSpace)
+	}
+}
+
+// getTimeline permit to get timeline with it ID or template timeline ID
+func getTimeline(c *resty.Client, key string, value string, kibanaSpace string) (*Timeline, error) {
+	path := spacePath(kibanaSpace, basePathKibanaTimeline)
+	resp, err := c.R().SetQueryParam(key, value).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	timeline := &Timeline{}
+	err = unmarshalTimelineResponse(resp.Body(), timeline, "getOneTimeline")
+	if err != nil {
+		return nil, err
+	}
+	// Kibana return empty object when timeline not exist
+	if timeline.SavedObjectID == "" {
+		return nil, nil
+	}
+	log.Debug("Timeline: ", timeline)
+
+	return timeline, nil
+}
+
+// persistTimeline permit to create or update timeline
+func persistTimeline(c *resty.Client, method string, payload map[string]interface{}, kibanaSpace string) (*Timeline, error) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	path := spacePath(kibanaSpace, basePathKibanaTimeline)
+	resp, err := c.R().SetBody(jsonData).Execute(method, path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	timeline := &Timeline{}
+	err = unmarshalTimelineResponse(resp.Body(), timeline, "persistTimeline", "timeline")
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Timeline: ", timeline)
+
+	return timeline, nil
+}
+
+// persistPinnedEvent permit to pin or unpin event on timeline
+func persistPinnedEvent(c *resty.Client, payload map[string]interface{}, pinnedEvent *TimelinePinnedEvent, kibanaSpace string) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	path := spacePath(kibanaSpace, basePathKibanaPinnedEvent)
+	resp, err := c.R().SetBody(jsonData).Patch(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	if pinnedEvent != nil {
+		return unmarshalTimelineResponse(resp.Body(), pinnedEvent, "persistPinnedEventOnTimeline")
+	}
+
+	return nil
+}
+
+// unmarshalTimelineResponse permit to read object from timeline API response.
+// Some Kibana versions wrap the object on data field, under the keys provided.
+func unmarshalTimelineResponse(data []byte, object interface{}, keys ...string) error {
+	response := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+	body, isWrapped := response["data"]
+	if !isWrapped {
+		return json.Unmarshal(data, object)
+	}
+	for _, key := range keys {
+		response = map[string]json.RawMessage{}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return err
+		}
+		body = response[key]
+	}
+	if len(body) == 0 || string(body) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(body, object)
+}
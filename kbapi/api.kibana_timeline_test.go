@@ -0,0 +1,94 @@
+package kbapi
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaTimelines() {
+
+	// Create new timeline
+	timeline, err := s.API.KibanaTimelines.Create(&Timeline{
+		Title:        "test",
+		Description:  "test timeline",
+		TimelineType: TimelineTypeDefault,
+		Status:       TimelineStatusActive,
+		Columns: []TimelineColumn{
+			{
+				ColumnHeaderType: "not-filtered",
+				ID:               "@timestamp",
+			},
+		},
+		KqlMode: "filter",
+		DateRange: &TimelineDateRange{
+			Start: "now-24h",
+			End:   "now",
+		},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), timeline)
+	assert.NotEmpty(s.T(), timeline.SavedObjectID)
+	assert.NotEmpty(s.T(), timeline.Version)
+	id := timeline.SavedObjectID
+
+	// Get timeline
+	timeline, err = s.API.KibanaTimelines.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), timeline)
+	assert.Equal(s.T(), "test", timeline.Title)
+
+	// Patch timeline
+	timeline.Description = "test timeline 2"
+	timeline, err = s.API.KibanaTimelines.Patch(timeline, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test timeline 2", timeline.Description)
+	_, err = s.API.KibanaTimelines.Patch(&Timeline{Title: "test"}, "default")
+	assert.Equal(s.T(), 600, err.(APIError).Code)
+
+	// List timelines
+	timelineListResponse, err := s.API.KibanaTimelines.List(&TimelineListParameters{
+		TimelineType: TimelineTypeDefault,
+		Search:       "test",
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), timelineListResponse.Timeline)
+
+	// Favorite timeline
+	timelineFavoriteResponse, err := s.API.KibanaTimelines.Favorite(timeline, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), id, timelineFavoriteResponse.SavedObjectID)
+	assert.NotEmpty(s.T(), timelineFavoriteResponse.Favorite)
+
+	// Pin and unpin event
+	pinnedEvent, err := s.API.KibanaTimelines.PinEvent(id, "test-event", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), pinnedEvent)
+	assert.NotEmpty(s.T(), pinnedEvent.PinnedEventID)
+	err = s.API.KibanaTimelines.UnpinEvent(id, "test-event", pinnedEvent.PinnedEventID, "default")
+	assert.NoError(s.T(), err)
+
+	// Export and import timeline
+	data, err := s.API.KibanaTimelines.Export([]string{id}, "default")
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), string(data), id)
+
+	// Delete timeline
+	err = s.API.KibanaTimelines.Delete([]string{id}, "default")
+	assert.NoError(s.T(), err)
+	timeline, err = s.API.KibanaTimelines.Get(id, "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), timeline)
+
+	timelineImportResponse, err := s.API.KibanaTimelines.Import(data, "default")
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), timelineImportResponse.Success)
+	timelineListResponse, err = s.API.KibanaTimelines.List(&TimelineListParameters{
+		Search: "test",
+	}, "default")
+	assert.NoError(s.T(), err)
+	for _, importedTimeline := range timelineListResponse.Timeline {
+		if importedTimeline.Title == "test" {
+			err = s.API.KibanaTimelines.Delete([]string{importedTimeline.SavedObjectID}, "default")
+			assert.NoError(s.T(), err)
+		}
+	}
+}
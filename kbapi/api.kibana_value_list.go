@@ -0,0 +1,343 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaValueList = "/api/lists" // Base URL to access on Kibana value lists API
+)
+
+// Types of value list
+const (
+	ValueListTypeKeyword = "keyword"
+	ValueListTypeText    = "text"
+	ValueListTypeIP      = "ip"
+	ValueListTypeIPRange = "ip_range"
+	ValueListTypeLong    = "long"
+	ValueListTypeDate    = "date"
+)
+
+// ValueList is the list of values, used by exception list items and indicator match rules
+type ValueList struct {
+	ID           string                 `json:"id,omitempty"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Type         string                 `json:"type"`
+	Serializer   string                 `json:"serializer,omitempty"`
+	Deserializer string                 `json:"deserializer,omitempty"`
+	Meta         map[string]interface{} `json:"meta,omitempty"`
+	Version      int                    `json:"version,omitempty"`
+	Immutable    bool                   `json:"immutable,omitempty"`
+	TieBreakerID string                 `json:"tie_breaker_id,omitempty"`
+	CreatedAt    string                 `json:"created_at,omitempty"`
+	CreatedBy    string                 `json:"created_by,omitempty"`
+	UpdatedAt    string                 `json:"updated_at,omitempty"`
+	UpdatedBy    string                 `json:"updated_by,omitempty"`
+}
+
+// ValueLists is list of ValueList object
+type ValueLists []ValueList
+
+// valueListRequest is the value list fields that can be written
+type valueListRequest struct {
+	ID           string                 `json:"id,omitempty"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Type         string                 `json:"type,omitempty"`
+	Serializer   string                 `json:"serializer,omitempty"`
+	Deserializer string                 `json:"deserializer,omitempty"`
+	Meta         map[string]interface{} `json:"meta,omitempty"`
+	Version      int                    `json:"version,omitempty"`
+}
+
+// ValueListFindParameters contain optional parameters to find value lists
+type ValueListFindParameters struct {
+	Filter    string
+	Page      int
+	PerPage   int
+	SortField string
+	SortOrder string
+	Cursor    string
+}
+
+// ValueListFindResponse is the result when find value lists
+type ValueListFindResponse struct {
+	Page    int        `json:"page"`
+	PerPage int        `json:"per_page"`
+	Total   int        `json:"total"`
+	Cursor  string     `json:"cursor"`
+	Data    ValueLists `json:"data"`
+}
+
+// KibanaValueListCreateIndex permit to create the indices used to store value lists, if not already exist
+type KibanaValueListCreateIndex func(kibanaSpace string) error
+
+// KibanaValueListCreate permit to create value list
+type KibanaValueListCreate func(list *ValueList, kibanaSpace string) (*ValueList, error)
+
+// KibanaValueListGet permit to get value list
+type KibanaValueListGet func(id string, kibanaSpace string) (*ValueList, error)
+
+// KibanaValueListUpdate permit to update value list name, description and meta
+type KibanaValueListUpdate func(list *ValueList, kibanaSpace string) (*ValueList, error)
+
+// KibanaValueListDelete permit to delete value list and all it items
+type KibanaValueListDelete func(id string, kibanaSpace string) error
+
+// KibanaValueListFind permit to find value lists
+type KibanaValueListFind func(parameters *ValueListFindParameters, kibanaSpace string) (*ValueListFindResponse, error)
+
+// KibanaValueListImportItems permit to import items on value list from file, one value per line
+type KibanaValueListImportItems func(id string, fileName string, reader io.Reader, kibanaSpace string) (*ValueList, error)
+
+// KibanaValueListExportItems permit to export items of value list, one value per line
+type KibanaValueListExportItems func(id string, kibanaSpace string) ([]byte, error)
+
+// String permit to return ValueList object as JSON string
+func (o *ValueList) String() string {
+	json, _ := json.Marshal(o)
+	return string(json)
+}
+
+// toRequest return the value list fields that can be written
+func (o *ValueList) toRequest() *valueListRequest {
+	return &valueListRequest{
+		ID:           o.ID,
+		Name:         o.Name,
+		Description:  o.Description,
+		Type:         o.Type,
+		Serializer:   o.Serializer,
+		Deserializer: o.Deserializer,
+		Meta:         o.Meta,
+		Version:      o.Version,
+	}
+}
+
+// newKibanaValueListCreateIndexFunc permit to create the value lists indices
+func newKibanaValueListCreateIndexFunc(c *resty.Client) KibanaValueListCreateIndex {
+	return func(kibanaSpace string) error {
+
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/index", basePathKibanaValueList))
+		resp, err := c.R().Post(path)
+		if err != nil {
+			return err
+		}
+		log.Debug("Response: ", resp)
+		// Kibana return conflict when indices already exist
+		if resp.StatusCode() >= 300 && resp.StatusCode() != 409 {
+			return NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return nil
+	}
+}
+
+// newKibanaValueListCreateFunc permit to create value list
+func newKibanaValueListCreateFunc(c *resty.Client) KibanaValueListCreate {
+	return func(list *ValueList, kibanaSpace string) (*ValueList, error) {
+
+		if list == nil {
+			return nil, NewAPIError(600, "You must provide value list object")
+		}
+		if list.Name == "" || list.Type == "" {
+			return nil, NewAPIError(600, "You must provide value list name and type")
+		}
+		log.Debug("ValueList: ", list)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		result := &ValueList{}
+		path := spacePath(kibanaSpace, basePathKibanaValueList)
+		err := sendListsObject(c, http.MethodPost, path, list.toRequest(), result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ValueList: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaValueListGetFunc permit to get value list with it ID
+func newKibanaValueListGetFunc(c *resty.Client) KibanaValueListGet {
+	return func(id string, kibanaSpace string) (*ValueList, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide value list ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		list := &ValueList{}
+		path := spacePath(kibanaSpace, basePathKibanaValueList)
+		found, err := getListsObject(c, path, listsQueryParams("id", id, ""), list)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		log.Debug("ValueList: ", list)
+
+		return list, nil
+	}
+}
+
+// newKibanaValueListUpdateFunc permit to update value list
+func newKibanaValueListUpdateFunc(c *resty.Client) KibanaValueListUpdate {
+	return func(list *ValueList, kibanaSpace string) (*ValueList, error) {
+
+		if list == nil {
+			return nil, NewAPIError(600, "You must provide value list object")
+		}
+		if list.ID == "" {
+			return nil, NewAPIError(600, "You must provide value list ID")
+		}
+		log.Debug("ValueList: ", list)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		// Type and serializers can't be changed
+		request := list.toRequest()
+		request.Type = ""
+		request.Serializer = ""
+		request.Deserializer = ""
+		result := &ValueList{}
+		path := spacePath(kibanaSpace, basePathKibanaValueList)
+		err := sendListsObject(c, http.MethodPut, path, request, result)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ValueList: ", result)
+
+		return result, nil
+	}
+}
+
+// newKibanaValueListDeleteFunc permit to delete value list with it ID
+func newKibanaValueListDeleteFunc(c *resty.Client) KibanaValueListDelete {
+	return func(id string, kibanaSpace string) error {
+
+		if id == "" {
+			return NewAPIError(600, "You must provide value list ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, basePathKibanaValueList)
+		return deleteListsObject(c, path, listsQueryParams("id", id, ""))
+	}
+}
+
+// newKibanaValueListFindFunc permit to find value lists
+func newKibanaValueListFindFunc(c *resty.Client) KibanaValueListFind {
+	return func(parameters *ValueListFindParameters, kibanaSpace string) (*ValueListFindResponse, error) {
+
+		log.Debug("Parameters: ", parameters)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		queryParams := map[string]string{}
+		if parameters != nil {
+			if parameters.Filter != "" {
+				queryParams["filter"] = parameters.Filter
+			}
+			if parameters.Page != 0 {
+				queryParams["page"] = strconv.Itoa(parameters.Page)
+			}
+			if parameters.PerPage != 0 {
+				queryParams["per_page"] = strconv.Itoa(parameters.PerPage)
+			}
+			if parameters.SortField != "" {
+				queryParams["sort_field"] = parameters.SortField
+			}
+			if parameters.SortOrder != "" {
+				queryParams["sort_order"] = parameters.SortOrder
+			}
+			if parameters.Cursor != "" {
+				queryParams["cursor"] = parameters.Cursor
+			}
+		}
+
+		valueListFindResponse := &ValueListFindResponse{}
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/_find", basePathKibanaValueList))
+		err := findListsObjects(c, path, queryParams, valueListFindResponse)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ValueLists: ", valueListFindResponse.Data)
+
+		return valueListFindResponse, nil
+	}
+}
+
+// newKibanaValueListImportItemsFunc permit to import items on value list
+func newKibanaValueListImportItemsFunc(c *resty.Client) KibanaValueListImportItems {
+	return func(id string, fileName string, reader io.Reader, kibanaSpace string) (*ValueList, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide value list ID")
+		}
+		if reader == nil {
+			return nil, NewAPIError(600, "You must provide reader parameter")
+		}
+		if fileName == "" {
+			fileName = "list.txt"
+		}
+		log.Debug("ID: ", id)
+		log.Debug("FileName: ", fileName)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items/_import", basePathKibanaValueList))
+		resp, err := c.R().
+			SetQueryParam("list_id", id).
+			SetFileReader("file", fileName, reader).
+			Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+		list := &ValueList{}
+		err = json.Unmarshal(resp.Body(), list)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("ValueList: ", list)
+
+		return list, nil
+	}
+}
+
+// newKibanaValueListExportItemsFunc permit to export items of value list
+func newKibanaValueListExportItemsFunc(c *resty.Client) KibanaValueListExportItems {
+	return func(id string, kibanaSpace string) ([]byte, error) {
+
+		if id == "" {
+			return nil, NewAPIError(600, "You must provide value list ID")
+		}
+		log.Debug("ID: ", id)
+		log.Debug("KibanaSpace: ", kibanaSpace)
+
+		path := spacePath(kibanaSpace, fmt.Sprintf("%s/items/_export", basePathKibanaValueList))
+		resp, err := c.R().SetQueryParam("list_id", id).Post(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Response: ", resp)
+		if resp.StatusCode() >= 300 {
+			return nil, NewAPIError(resp.StatusCode(), resp.Status())
+		}
+
+		return resp.Body(), nil
+	}
+}
@@ -0,0 +1,57 @@
+package kbapi
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *KBAPITestSuite) TestKibanaValueLists() {
+
+	// Create value lists indices
+	err := s.API.KibanaValueLists.CreateIndex("default")
+	assert.NoError(s.T(), err)
+
+	// Create new value list
+	list, err := s.API.KibanaValueLists.Create(&ValueList{
+		ID:          "test-value-list",
+		Name:        "test",
+		Description: "test value list",
+		Type:        ValueListTypeKeyword,
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), list)
+	assert.Equal(s.T(), "test-value-list", list.ID)
+
+	// Get value list
+	list, err = s.API.KibanaValueLists.Get("test-value-list", "default")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), list)
+	assert.Equal(s.T(), ValueListTypeKeyword, list.Type)
+
+	// Update value list
+	list.Description = "test value list 2"
+	list, err = s.API.KibanaValueLists.Update(list, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test value list 2", list.Description)
+
+	// Find value lists
+	valueListFindResponse, err := s.API.KibanaValueLists.Find(nil, "default")
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), valueListFindResponse.Data)
+
+	// Import and export items
+	_, err = s.API.KibanaValueLists.ImportItems("test-value-list", "hosts.txt", strings.NewReader("host1\nhost2\n"), "default")
+	assert.NoError(s.T(), err)
+	data, err := s.API.KibanaValueLists.ExportItems("test-value-list", "default")
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), string(data), "host1")
+	assert.Contains(s.T(), string(data), "host2")
+
+	// Delete value list
+	err = s.API.KibanaValueLists.Delete("test-value-list", "default")
+	assert.NoError(s.T(), err)
+	list, err = s.API.KibanaValueLists.Get("test-value-list", "default")
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), list)
+}
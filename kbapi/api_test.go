@@ -1,6 +1,7 @@
 package kbapi
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -42,19 +43,13 @@ func (s *KBAPITestSuite) SetupSuite() {
 	s.API = New(restyClient)
 
 	// Wait kb is online
-	isOnline := false
-	nbTry := 0
-	for isOnline == false {
-		_, err := s.API.KibanaSpaces.List()
-		if err == nil {
-			isOnline = true
-		} else {
-			time.Sleep(5 * time.Second)
-			if nbTry == 10 {
-				panic(fmt.Sprintf("We wait 50s that Kibana start: %s", err))
-			}
-			nbTry++
-		}
+	_, err := s.API.KibanaStatus.WaitUntilReady(context.Background(), &KibanaStatusWaitOptions{
+		Timeout:         2 * time.Minute,
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     5 * time.Second,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("We wait that Kibana start: %s", err))
 	}
 
 	// Create kibana space
@@ -62,7 +57,7 @@ func (s *KBAPITestSuite) SetupSuite() {
 		ID:   "testacc",
 		Name: "testacc",
 	}
-	_, err := s.API.KibanaSpaces.Create(space)
+	_, err = s.API.KibanaSpaces.Create(space)
 	if err != nil {
 		if err.(APIError).Code != 409 {
 			panic(err)
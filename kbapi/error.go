@@ -22,3 +22,13 @@ func NewAPIError(code int, message string, params ...interface{}) APIError {
 		Message: fmt.Sprintf(message, params...),
 	}
 }
+
+// NewUnsupportedVersionError create new API error when the feature is not available on Kibana version
+func NewUnsupportedVersionError(feature string, version *KibanaVersion) APIError {
+	return NewAPIError(600, "%s is not supported by Kibana %s", feature, version)
+}
+
+// NewUnsupportedServerlessError create new API error when the feature is not available on Kibana serverless
+func NewUnsupportedServerlessError(feature string) APIError {
+	return NewAPIError(600, "%s is not available on Kibana serverless", feature)
+}
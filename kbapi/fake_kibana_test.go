@@ -0,0 +1,86 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// fakeKibanaRequest is the request received by fake Kibana
+type fakeKibanaRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// fakeKibana is the Kibana server used by tests that don't need live Kibana.
+// It record the requests received, except the status requests used to read the version.
+type fakeKibana struct {
+	API      *API
+	Version  string
+	Requests []fakeKibanaRequest
+	server   *httptest.Server
+}
+
+// newFakeKibana start fake Kibana, that answer with the status code and the JSON body returned by handler.
+// The status API return Version, or 503 when Version is empty. The server is closed at the end of test.
+func newFakeKibana(t *testing.T, handler func(r *fakeKibanaRequest) (int, string)) *fakeKibana {
+	o := &fakeKibana{}
+	o.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/status" {
+			if o.Version == "" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"name": "kibana", "version": {"number": "%s"}}`, o.Version)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		request := fakeKibanaRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header,
+			Body:   body,
+		}
+		o.Requests = append(o.Requests, request)
+		statusCode, response := handler(&request)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(o.server.Close)
+	o.API = New(resty.New().SetBaseURL(o.server.URL))
+
+	return o
+}
+
+// reset drop the requests received and the version cached by API
+func (o *fakeKibana) reset() {
+	o.Requests = nil
+	o.API = New(resty.New().SetBaseURL(o.server.URL))
+}
+
+// lastRequest return the last request received
+func (o *fakeKibana) lastRequest(t *testing.T) *fakeKibanaRequest {
+	if len(o.Requests) == 0 {
+		t.Fatal("Fake Kibana has not received request")
+	}
+	return &o.Requests[len(o.Requests)-1]
+}
+
+// decodeBody read the JSON body of request
+func (o *fakeKibanaRequest) decodeBody(t *testing.T, data interface{}) {
+	if err := json.Unmarshal(o.Body, data); err != nil {
+		t.Fatalf("Request body is not JSON: %s", err)
+	}
+}
@@ -0,0 +1,363 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/disaster37/go-kibana-rest/v8/rison"
+)
+
+// Locators that can be used with shorten URL and deep links
+const (
+	LocatorDashboard = "DASHBOARD_APP_LOCATOR"
+	LocatorDiscover  = "DISCOVER_APP_LOCATOR"
+	LocatorLens      = "LENS_APP_LOCATOR"
+	LocatorVisualize = "VISUALIZE_APP_LOCATOR"
+)
+
+// View modes of dashboard
+const (
+	DashboardViewModeView = "view"
+	DashboardViewModeEdit = "edit"
+)
+
+// Stores of filter. Filters of global state are pinned and kept when user navigate between applications.
+const (
+	FilterStoreAppState    = "appState"
+	FilterStoreGlobalState = "globalState"
+)
+
+// LocatorParams is the params of Kibana application locator
+type LocatorParams interface {
+	// LocatorID return the ID of locator
+	LocatorID() string
+
+	// appPath return the path of application, with it hash route
+	appPath() (string, error)
+
+	// appState return the application state, encoded on _a query param of deep link
+	appState() map[string]interface{}
+
+	// globalState return the global state, encoded on _g query param of deep link
+	globalState() map[string]interface{}
+}
+
+// LocatorTimeRange is the time range. From and to can be absolute date or date math like now-15m.
+type LocatorTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Mode string `json:"mode,omitempty"`
+}
+
+// LocatorRefreshInterval is the refresh interval, in milliseconds
+type LocatorRefreshInterval struct {
+	Pause bool  `json:"pause"`
+	Value int64 `json:"value"`
+}
+
+// LocatorQuery is the query bar content. Language is kuery or lucene.
+type LocatorQuery struct {
+	Query    interface{} `json:"query"`
+	Language string      `json:"language"`
+}
+
+// LocatorFilter is the filter added on filter bar
+type LocatorFilter struct {
+	Meta  LocatorFilterMeta      `json:"meta"`
+	Query map[string]interface{} `json:"query,omitempty"`
+	State *LocatorFilterState    `json:"$state,omitempty"`
+}
+
+// LocatorFilterMeta is the filter metadata used by filter bar
+type LocatorFilterMeta struct {
+	Alias    string      `json:"alias,omitempty"`
+	Disabled bool        `json:"disabled"`
+	Negate   bool        `json:"negate"`
+	Index    string      `json:"index,omitempty"`
+	Key      string      `json:"key,omitempty"`
+	Type     string      `json:"type,omitempty"`
+	Params   interface{} `json:"params,omitempty"`
+}
+
+// LocatorFilterState is the store of filter
+type LocatorFilterState struct {
+	Store string `json:"store"`
+}
+
+// LocatorCommonParams is the params shared by all application locators
+type LocatorCommonParams struct {
+	TimeRange       *LocatorTimeRange       `json:"timeRange,omitempty"`
+	RefreshInterval *LocatorRefreshInterval `json:"refreshInterval,omitempty"`
+	Query           *LocatorQuery           `json:"query,omitempty"`
+	Filters         []LocatorFilter         `json:"filters,omitempty"`
+}
+
+// DashboardLocatorParams is the params of dashboard locator. Without dashboard ID, it open new dashboard.
+type DashboardLocatorParams struct {
+	DashboardID string `json:"dashboardId,omitempty"`
+	LocatorCommonParams
+	ViewMode             string `json:"viewMode,omitempty"`
+	PreserveSavedFilters *bool  `json:"preserveSavedFilters,omitempty"`
+}
+
+// DiscoverLocatorParams is the params of discover locator
+type DiscoverLocatorParams struct {
+	SavedSearchID string `json:"savedSearchId,omitempty"`
+	DataViewID    string `json:"dataViewId,omitempty"`
+	LocatorCommonParams
+	Columns  []string `json:"columns,omitempty"`
+	ViewMode string   `json:"viewMode,omitempty"`
+}
+
+// LensLocatorParams is the params of lens locator. Without saved object ID, it open new lens visualization.
+type LensLocatorParams struct {
+	SavedObjectID string `json:"savedObjectId,omitempty"`
+	LocatorCommonParams
+}
+
+// VisualizeLocatorParams is the params of visualize locator. Without visualization ID, the type is needed to create new visualization.
+type VisualizeLocatorParams struct {
+	VisID string `json:"visId,omitempty"`
+	Type  string `json:"type,omitempty"`
+	LocatorCommonParams
+}
+
+// NewPhraseFilter return filter that match documents where field contain the value
+func NewPhraseFilter(field string, value interface{}) LocatorFilter {
+	return LocatorFilter{
+		Meta: LocatorFilterMeta{
+			Key:  field,
+			Type: "phrase",
+			Params: map[string]interface{}{
+				"query": value,
+			},
+		},
+		Query: map[string]interface{}{
+			"match_phrase": map[string]interface{}{
+				field: value,
+			},
+		},
+		State: &LocatorFilterState{
+			Store: FilterStoreAppState,
+		},
+	}
+}
+
+// NewShortenURL return the shorten URL that redirect on application locator
+func NewShortenURL(params LocatorParams) (*ShortenURL, error) {
+	if params == nil {
+		return nil, NewAPIError(600, "You must provide locator params")
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	shortenURLParams := map[string]any{}
+	if err = json.Unmarshal(data, &shortenURLParams); err != nil {
+		return nil, err
+	}
+
+	return &ShortenURL{
+		LocatorId: params.LocatorID(),
+		Params:    shortenURLParams,
+	}, nil
+}
+
+// BuildKibanaURL return the full URL to open application locator on Kibana address and space
+func BuildKibanaURL(address string, kibanaSpace string, params LocatorParams) (string, error) {
+	if address == "" {
+		return "", NewAPIError(600, "You must provide Kibana address")
+	}
+	if params == nil {
+		return "", NewAPIError(600, "You must provide locator params")
+	}
+	path, err := params.appPath()
+	if err != nil {
+		return "", err
+	}
+
+	query := make([]string, 0, 2)
+	for _, state := range []struct {
+		key   string
+		value map[string]interface{}
+	}{
+		{key: "_g", value: params.globalState()},
+		{key: "_a", value: params.appState()},
+	} {
+		if len(state.value) == 0 {
+			continue
+		}
+		encodedState, err := rison.Marshal(state.value)
+		if err != nil {
+			return "", err
+		}
+		query = append(query, fmt.Sprintf("%s=%s", state.key, escapeRison(string(encodedState))))
+	}
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path = path + separator + strings.Join(query, "&")
+	}
+
+	return strings.TrimSuffix(address, "/") + spacePath(kibanaSpace, path), nil
+}
+
+// LocatorID return the ID of dashboard locator
+func (o *DashboardLocatorParams) LocatorID() string {
+	return LocatorDashboard
+}
+
+func (o *DashboardLocatorParams) appPath() (string, error) {
+	if o.DashboardID == "" {
+		return "/app/dashboards#/create", nil
+	}
+	return fmt.Sprintf("/app/dashboards#/view/%s", url.PathEscape(o.DashboardID)), nil
+}
+
+func (o *DashboardLocatorParams) appState() map[string]interface{} {
+	state := o.LocatorCommonParams.appState()
+	if o.ViewMode != "" {
+		state["viewMode"] = o.ViewMode
+	}
+	return state
+}
+
+func (o *DashboardLocatorParams) globalState() map[string]interface{} {
+	return o.LocatorCommonParams.globalState()
+}
+
+// LocatorID return the ID of discover locator
+func (o *DiscoverLocatorParams) LocatorID() string {
+	return LocatorDiscover
+}
+
+func (o *DiscoverLocatorParams) appPath() (string, error) {
+	if o.SavedSearchID == "" {
+		return "/app/discover#/", nil
+	}
+	return fmt.Sprintf("/app/discover#/view/%s", url.PathEscape(o.SavedSearchID)), nil
+}
+
+func (o *DiscoverLocatorParams) appState() map[string]interface{} {
+	state := o.LocatorCommonParams.appState()
+	if o.DataViewID != "" {
+		state["index"] = o.DataViewID
+	}
+	if len(o.Columns) > 0 {
+		state["columns"] = o.Columns
+	}
+	if o.ViewMode != "" {
+		state["viewMode"] = o.ViewMode
+	}
+	return state
+}
+
+func (o *DiscoverLocatorParams) globalState() map[string]interface{} {
+	return o.LocatorCommonParams.globalState()
+}
+
+// LocatorID return the ID of lens locator
+func (o *LensLocatorParams) LocatorID() string {
+	return LocatorLens
+}
+
+func (o *LensLocatorParams) appPath() (string, error) {
+	if o.SavedObjectID == "" {
+		return "/app/lens#/", nil
+	}
+	return fmt.Sprintf("/app/lens#/edit/%s", url.PathEscape(o.SavedObjectID)), nil
+}
+
+func (o *LensLocatorParams) appState() map[string]interface{} {
+	return o.LocatorCommonParams.appState()
+}
+
+func (o *LensLocatorParams) globalState() map[string]interface{} {
+	return o.LocatorCommonParams.globalState()
+}
+
+// LocatorID return the ID of visualize locator
+func (o *VisualizeLocatorParams) LocatorID() string {
+	return LocatorVisualize
+}
+
+func (o *VisualizeLocatorParams) appPath() (string, error) {
+	if o.VisID != "" {
+		return fmt.Sprintf("/app/visualize#/edit/%s", url.PathEscape(o.VisID)), nil
+	}
+	if o.Type == "" {
+		return "", NewAPIError(600, "You must provide visualization ID or type")
+	}
+	return fmt.Sprintf("/app/visualize#/create?type=%s", url.QueryEscape(o.Type)), nil
+}
+
+func (o *VisualizeLocatorParams) appState() map[string]interface{} {
+	return o.LocatorCommonParams.appState()
+}
+
+func (o *VisualizeLocatorParams) globalState() map[string]interface{} {
+	return o.LocatorCommonParams.globalState()
+}
+
+// appState return the query and the filters of application
+func (o *LocatorCommonParams) appState() map[string]interface{} {
+	state := map[string]interface{}{}
+	if o.Query != nil {
+		state["query"] = o.Query
+	}
+	if filters := o.filters(FilterStoreAppState); len(filters) > 0 {
+		state["filters"] = filters
+	}
+	return state
+}
+
+// globalState return the time, the refresh interval and the pinned filters
+func (o *LocatorCommonParams) globalState() map[string]interface{} {
+	state := map[string]interface{}{}
+	if o.TimeRange != nil {
+		state["time"] = o.TimeRange
+	}
+	if o.RefreshInterval != nil {
+		state["refreshInterval"] = o.RefreshInterval
+	}
+	if filters := o.filters(FilterStoreGlobalState); len(filters) > 0 {
+		state["filters"] = filters
+	}
+	return state
+}
+
+// filters return the filters of store. Filters without store belong to application.
+func (o *LocatorCommonParams) filters(store string) []LocatorFilter {
+	filters := make([]LocatorFilter, 0, len(o.Filters))
+	for _, filter := range o.Filters {
+		filterStore := FilterStoreAppState
+		if filter.State != nil && filter.State.Store != "" {
+			filterStore = filter.State.Store
+		}
+		if filterStore == store {
+			filters = append(filters, filter)
+		}
+	}
+	return filters
+}
+
+// escapeRison permit to escape rison for URL query, without escaping the characters used by rison syntax
+func escapeRison(value string) string {
+	return strings.NewReplacer(
+		"%21", "!",
+		"%27", "'",
+		"%28", "(",
+		"%29", ")",
+		"%2A", "*",
+		"%2C", ",",
+		"%3A", ":",
+		"%40", "@",
+		"%24", "$",
+		"%2F", "/",
+		"+", "%20",
+	).Replace(url.QueryEscape(value))
+}
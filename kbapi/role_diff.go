@@ -0,0 +1,280 @@
+package kbapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// KibanaRoleChange is one difference between two roles
+type KibanaRoleChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// KibanaRoleDiff is the list of differences between two roles
+type KibanaRoleDiff []KibanaRoleChange
+
+// HasChanges return true if there are differences
+func (d KibanaRoleDiff) HasChanges() bool {
+	return len(d) > 0
+}
+
+// String permit to return KibanaRoleDiff object as human readable string
+func (d KibanaRoleDiff) String() string {
+	lines := make([]string, 0, len(d))
+	for _, change := range d {
+		oldValue, _ := json.Marshal(change.Old)
+		newValue, _ := json.Marshal(change.New)
+		lines = append(lines, fmt.Sprintf("%s: %s => %s", change.Path, oldValue, newValue))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CompareKibanaRole return the meaningful differences between the current role and the desired role.
+// The order of privileges, indices, spaces and features is ignored, like the fields computed by Kibana (transient metadata and metadata prefixed by underscore).
+// Indices are identified by their names and Kibana privileges by their spaces. When many entries have the same names or spaces, they are identified by their position.
+func CompareKibanaRole(current *KibanaRole, desired *KibanaRole) KibanaRoleDiff {
+	current = current.Normalize()
+	desired = desired.Normalize()
+	diff := KibanaRoleDiff{}
+
+	compare := func(path string, oldValue interface{}, newValue interface{}) {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff = append(diff, KibanaRoleChange{
+				Path: path,
+				Old:  oldValue,
+				New:  newValue,
+			})
+		}
+	}
+
+	compare("description", current.Description, desired.Description)
+	compare("metadata", current.Metadata, desired.Metadata)
+
+	currentElasticsearch := current.Elasticsearch
+	if currentElasticsearch == nil {
+		currentElasticsearch = &KibanaRoleElasticsearch{}
+	}
+	desiredElasticsearch := desired.Elasticsearch
+	if desiredElasticsearch == nil {
+		desiredElasticsearch = &KibanaRoleElasticsearch{}
+	}
+	compare("elasticsearch.cluster", currentElasticsearch.Cluster, desiredElasticsearch.Cluster)
+	compare("elasticsearch.run_as", currentElasticsearch.RunAs, desiredElasticsearch.RunAs)
+	compare("elasticsearch.remote_cluster", currentElasticsearch.RemoteCluster, desiredElasticsearch.RemoteCluster)
+	compare("elasticsearch.remote_indices", currentElasticsearch.RemoteIndices, desiredElasticsearch.RemoteIndices)
+
+	indiceKey := func(indice KibanaRoleElasticsearchIndice) string {
+		return strings.Join(indice.Names, ",")
+	}
+	compareEntries(compare, "elasticsearch.indices",
+		groupEntries(currentElasticsearch.Indices, indiceKey),
+		groupEntries(desiredElasticsearch.Indices, indiceKey),
+	)
+
+	kibanaKey := func(kibana KibanaRoleKibana) string {
+		return strings.Join(kibana.Spaces, ",")
+	}
+	compareEntries(compare, "kibana",
+		groupEntries(current.Kibana, kibanaKey),
+		groupEntries(desired.Kibana, kibanaKey),
+	)
+
+	return diff
+}
+
+// Normalize return a copy of the role with sorted privileges, indices, spaces and features and without the fields computed by Kibana.
+// Empty lists are set to nil, so roles can be compared with reflect.DeepEqual.
+func (k *KibanaRole) Normalize() *KibanaRole {
+	if k == nil {
+		return &KibanaRole{}
+	}
+
+	kibanaRole := &KibanaRole{
+		Name:        k.Name,
+		Description: k.Description,
+	}
+
+	// Metadata values are converted as JSON values, so numbers are compared as float64 like when they are read from Kibana
+	for key, value := range k.Metadata {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if kibanaRole.Metadata == nil {
+			kibanaRole.Metadata = make(map[string]interface{})
+		}
+		var jsonValue interface{}
+		if data, err := json.Marshal(value); err == nil && json.Unmarshal(data, &jsonValue) == nil {
+			value = jsonValue
+		}
+		kibanaRole.Metadata[key] = value
+	}
+
+	if k.Elasticsearch != nil {
+		elasticsearch := &KibanaRoleElasticsearch{
+			Cluster: sortedStrings(k.Elasticsearch.Cluster),
+			RunAs:   sortedStrings(k.Elasticsearch.RunAs),
+		}
+		for _, indice := range k.Elasticsearch.Indices {
+			elasticsearch.Indices = append(elasticsearch.Indices, KibanaRoleElasticsearchIndice{
+				Names:                  sortedStrings(indice.Names),
+				Privileges:             sortedStrings(indice.Privileges),
+				FieldSecurity:          normalizeFieldSecurity(indice.FieldSecurity),
+				Query:                  indice.Query,
+				AllowRestrictedIndices: indice.AllowRestrictedIndices,
+			})
+		}
+		sort.Slice(elasticsearch.Indices, func(i, j int) bool {
+			return lessEntry(
+				strings.Join(elasticsearch.Indices[i].Names, ","), elasticsearch.Indices[i],
+				strings.Join(elasticsearch.Indices[j].Names, ","), elasticsearch.Indices[j],
+			)
+		})
+		for _, indice := range k.Elasticsearch.RemoteIndices {
+			elasticsearch.RemoteIndices = append(elasticsearch.RemoteIndices, KibanaRoleElasticsearchRemoteIndice{
+				Clusters:               sortedStrings(indice.Clusters),
+				Names:                  sortedStrings(indice.Names),
+				Privileges:             sortedStrings(indice.Privileges),
+				FieldSecurity:          normalizeFieldSecurity(indice.FieldSecurity),
+				Query:                  indice.Query,
+				AllowRestrictedIndices: indice.AllowRestrictedIndices,
+			})
+		}
+		sort.Slice(elasticsearch.RemoteIndices, func(i, j int) bool {
+			keyI := strings.Join(elasticsearch.RemoteIndices[i].Clusters, ",") + ":" + strings.Join(elasticsearch.RemoteIndices[i].Names, ",")
+			keyJ := strings.Join(elasticsearch.RemoteIndices[j].Clusters, ",") + ":" + strings.Join(elasticsearch.RemoteIndices[j].Names, ",")
+			return lessEntry(keyI, elasticsearch.RemoteIndices[i], keyJ, elasticsearch.RemoteIndices[j])
+		})
+		for _, remoteCluster := range k.Elasticsearch.RemoteCluster {
+			elasticsearch.RemoteCluster = append(elasticsearch.RemoteCluster, KibanaRoleElasticsearchRemoteCluster{
+				Clusters:   sortedStrings(remoteCluster.Clusters),
+				Privileges: sortedStrings(remoteCluster.Privileges),
+			})
+		}
+		sort.Slice(elasticsearch.RemoteCluster, func(i, j int) bool {
+			return lessEntry(
+				strings.Join(elasticsearch.RemoteCluster[i].Clusters, ","), elasticsearch.RemoteCluster[i],
+				strings.Join(elasticsearch.RemoteCluster[j].Clusters, ","), elasticsearch.RemoteCluster[j],
+			)
+		})
+		if !reflect.DeepEqual(elasticsearch, &KibanaRoleElasticsearch{}) {
+			kibanaRole.Elasticsearch = elasticsearch
+		}
+	}
+
+	for _, kibana := range k.Kibana {
+		normalizedKibana := KibanaRoleKibana{
+			Base:   sortedStrings(kibana.Base),
+			Spaces: sortedStrings(kibana.Spaces),
+		}
+		for feature, privileges := range kibana.Feature {
+			if normalizedKibana.Feature == nil {
+				normalizedKibana.Feature = make(map[string][]string)
+			}
+			normalizedKibana.Feature[feature] = sortedStrings(privileges)
+		}
+		kibanaRole.Kibana = append(kibanaRole.Kibana, normalizedKibana)
+	}
+	sort.Slice(kibanaRole.Kibana, func(i, j int) bool {
+		return lessEntry(
+			strings.Join(kibanaRole.Kibana[i].Spaces, ","), kibanaRole.Kibana[i],
+			strings.Join(kibanaRole.Kibana[j].Spaces, ","), kibanaRole.Kibana[j],
+		)
+	})
+
+	return kibanaRole
+}
+
+// normalizeFieldSecurity return a copy of field security with sorted fields
+func normalizeFieldSecurity(fieldSecurity *KibanaRoleFieldSecurity) *KibanaRoleFieldSecurity {
+	if fieldSecurity == nil {
+		return nil
+	}
+
+	return &KibanaRoleFieldSecurity{
+		Grant:  sortedStrings(fieldSecurity.Grant),
+		Except: sortedStrings(fieldSecurity.Except),
+	}
+}
+
+// sortedStrings return a sorted copy of list, or nil if list is empty
+func sortedStrings(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	sortedList := make([]string, len(list))
+	copy(sortedList, list)
+	sort.Strings(sortedList)
+
+	return sortedList
+}
+
+// lessEntry return true if entry i is before entry j. Entries with the same key, like the same index pattern with different privileges,
+// are ordered by their whole content, so the order is always the same.
+func lessEntry(keyI string, entryI interface{}, keyJ string, entryJ interface{}) bool {
+	if keyI != keyJ {
+		return keyI < keyJ
+	}
+	dataI, _ := json.Marshal(entryI)
+	dataJ, _ := json.Marshal(entryJ)
+
+	return string(dataI) < string(dataJ)
+}
+
+// groupEntries return the entries by key, in the same order
+func groupEntries[T any](entries []T, key func(T) string) map[string][]T {
+	groups := make(map[string][]T, len(entries))
+	for _, entry := range entries {
+		groups[key(entry)] = append(groups[key(entry)], entry)
+	}
+
+	return groups
+}
+
+// compareEntries compare the normalized entries by key. When many entries have the same key, they are compared by their position.
+func compareEntries[T any](compare func(path string, oldValue interface{}, newValue interface{}), path string, current map[string][]T, desired map[string][]T) {
+	for _, key := range mergedKeys(current, desired) {
+		currentEntries := current[key]
+		desiredEntries := desired[key]
+		if len(currentEntries) <= 1 && len(desiredEntries) <= 1 {
+			compare(fmt.Sprintf("%s[%s]", path, key), entryAt(currentEntries, 0), entryAt(desiredEntries, 0))
+			continue
+		}
+		if reflect.DeepEqual(currentEntries, desiredEntries) {
+			continue
+		}
+		for i := 0; i < len(currentEntries) || i < len(desiredEntries); i++ {
+			compare(fmt.Sprintf("%s[%s][%d]", path, key, i), entryAt(currentEntries, i), entryAt(desiredEntries, i))
+		}
+	}
+}
+
+// entryAt return pointer on entry at index, or nil if there are not entry
+func entryAt[T any](entries []T, index int) *T {
+	if index >= len(entries) {
+		return nil
+	}
+
+	return &entries[index]
+}
+
+// mergedKeys return the sorted keys of both maps
+func mergedKeys[T any](a map[string]T, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,62 @@
+package kbapi
+
+import (
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderElasticAPIVersion = "elastic-api-version" // Header to select the version of versioned API
+	ElasticAPIVersionPublic = "2023-10-31"          // Version of public API
+)
+
+// serverlessEndpoint is the endpoint not available on Kibana serverless
+type serverlessEndpoint struct {
+	path    string
+	feature string
+}
+
+// serverlessUnavailableEndpoints is the endpoints not available on Kibana serverless, by path prefix
+var serverlessUnavailableEndpoints = []serverlessEndpoint{
+	{path: basePathKibanaRoleManagement, feature: "Role management API"},
+	{path: basePathKibanaRoleManagementBulk, feature: "Role management API"},
+	{path: basePathKibanaLogstashPipeline, feature: "Logstash pipeline API"},
+	{path: basePathKibanaLogstashPipeline + "s", feature: "Logstash pipeline API"},
+	{path: basePathKibanaDashboard, feature: "Dashboard import and export API"},
+	{path: basePathKibanaLegacyShortenURL, feature: "Legacy shorten URL API"},
+	{path: basePathKibanaSecurity + "/session", feature: "Session management API"},
+	{path: "/internal", feature: "Internal API"}, // Like maintenance windows finish action
+}
+
+// NewServerlessMiddleware return the request middleware needed to call Kibana serverless.
+// It set the elastic-api-version header when not already set, and refuse the endpoints not available on serverless.
+func NewServerlessMiddleware() resty.RequestMiddleware {
+	return func(c *resty.Client, r *resty.Request) error {
+		path := trimSpacePath(r.URL)
+		for _, endpoint := range serverlessUnavailableEndpoints {
+			if path == endpoint.path || strings.HasPrefix(path, endpoint.path+"/") {
+				return NewUnsupportedServerlessError(endpoint.feature)
+			}
+		}
+
+		if r.Header.Get(HeaderElasticAPIVersion) == "" {
+			r.SetHeader(HeaderElasticAPIVersion, ElasticAPIVersionPublic)
+		}
+		log.Debugf("Elastic API version for %s: %s", path, r.Header.Get(HeaderElasticAPIVersion))
+
+		return nil
+	}
+}
+
+// trimSpacePath remove the space prefix /s/{space} from path
+func trimSpacePath(path string) string {
+	if !strings.HasPrefix(path, "/s/") {
+		return path
+	}
+	if index := strings.Index(path[len("/s/"):], "/"); index >= 0 {
+		return path[len("/s/")+index:]
+	}
+	return "/"
+}
@@ -0,0 +1,163 @@
+package kbapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// KibanaVersion is the semantic version of Kibana
+type KibanaVersion struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+}
+
+const (
+	kibanaVersionRetryInterval = 1 * time.Minute // Duration before read again the version when status can't be read
+)
+
+// KibanaStatusGetVersion permit to get the Kibana version. It is read once from status, then cached.
+// When status can't be read, the error is returned during one minute before try again.
+type KibanaStatusGetVersion func() (*KibanaVersion, error)
+
+// kibanaVersionCache permit to read Kibana version only one time
+type kibanaVersionCache struct {
+	getDetails KibanaStatusGetDetails
+	mutex      sync.Mutex
+	version    *KibanaVersion
+	err        error
+	retryAt    time.Time
+}
+
+// ParseKibanaVersion permit to read version like 8.5.0 or 8.12.0-SNAPSHOT
+func ParseKibanaVersion(version string) (*KibanaVersion, error) {
+	number, preRelease, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(version), "v"), "-")
+	parts := strings.Split(number, ".")
+	if len(parts) != 3 {
+		return nil, NewAPIError(600, "Version '%s' is not valid semantic version", version)
+	}
+	numbers := make([]int, 0, 3)
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, NewAPIError(600, "Version '%s' is not valid semantic version", version)
+		}
+		numbers = append(numbers, n)
+	}
+
+	return &KibanaVersion{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		PreRelease: preRelease,
+	}, nil
+}
+
+// String return the version as string
+func (v *KibanaVersion) String() string {
+	if v.PreRelease != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.PreRelease)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare return -1, 0 or 1 when version is lower, equal or greater than other version.
+// Pre-release version is lower than the release version.
+func (v *KibanaVersion) Compare(other *KibanaVersion) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	default:
+		return strings.Compare(v.PreRelease, other.PreRelease)
+	}
+}
+
+// AtLeast return true when version is greater or equal than major.minor.patch. Pre-release of this version are accepted.
+func (v *KibanaVersion) AtLeast(major int, minor int, patch int) bool {
+	return v.Compare(&KibanaVersion{Major: major, Minor: minor, Patch: patch, PreRelease: v.PreRelease}) >= 0
+}
+
+// LessThan return true when version is lower than major.minor.patch
+func (v *KibanaVersion) LessThan(major int, minor int, patch int) bool {
+	return !v.AtLeast(major, minor, patch)
+}
+
+// newKibanaVersionCache init the cache of Kibana version
+func newKibanaVersionCache(c *resty.Client) *kibanaVersionCache {
+	return &kibanaVersionCache{
+		getDetails: newKibanaStatusGetDetailsFunc(c),
+	}
+}
+
+// get return the Kibana version. When status can't be read, the error is cached until retry interval.
+// The lock is not held while reading status, so concurrent calls are not serialized.
+func (o *kibanaVersionCache) get() (*KibanaVersion, error) {
+	o.mutex.Lock()
+	version, err, retryAt := o.version, o.err, o.retryAt
+	o.mutex.Unlock()
+
+	if version != nil {
+		return version, nil
+	}
+	if err != nil && time.Now().Before(retryAt) {
+		return nil, err
+	}
+
+	version, err = o.read()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if err != nil {
+		log.Warnf("Can't read Kibana version, try again in %s: %s", kibanaVersionRetryInterval, err)
+		o.err = err
+		o.retryAt = time.Now().Add(kibanaVersionRetryInterval)
+		return nil, err
+	}
+	if o.version == nil {
+		o.version = version
+		o.err = nil
+	}
+
+	return o.version, nil
+}
+
+// read permit to read the Kibana version from status
+func (o *kibanaVersionCache) read() (*KibanaVersion, error) {
+	status, err := o.getDetails(nil)
+	if err != nil {
+		return nil, err
+	}
+	version, err := ParseKibanaVersion(status.Version.Number)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("KibanaVersion: ", version)
+
+	return version, nil
+}
+
+// newKibanaStatusGetVersionFunc permit to get the Kibana version
+func newKibanaStatusGetVersionFunc(versionCache *kibanaVersionCache) KibanaStatusGetVersion {
+	return func() (*KibanaVersion, error) {
+		return versionCache.get()
+	}
+}
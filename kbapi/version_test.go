@@ -0,0 +1,76 @@
+package kbapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKibanaVersion(t *testing.T) {
+
+	// Parse version
+	version, err := ParseKibanaVersion("8.12.1")
+	assert.NoError(t, err)
+	assert.Equal(t, &KibanaVersion{Major: 8, Minor: 12, Patch: 1}, version)
+	assert.Equal(t, "8.12.1", version.String())
+	assert.True(t, version.AtLeast(8, 12, 0))
+	assert.True(t, version.AtLeast(8, 12, 1))
+	assert.True(t, version.LessThan(9, 0, 0))
+	assert.False(t, version.LessThan(7, 16, 0))
+
+	// Parse pre-release version
+	version, err = ParseKibanaVersion("9.0.0-SNAPSHOT")
+	assert.NoError(t, err)
+	assert.Equal(t, "SNAPSHOT", version.PreRelease)
+	assert.Equal(t, "9.0.0-SNAPSHOT", version.String())
+	assert.True(t, version.AtLeast(9, 0, 0))
+	assert.Equal(t, -1, version.Compare(&KibanaVersion{Major: 9}))
+
+	// Bad version
+	_, err = ParseKibanaVersion("8.12")
+	assert.Error(t, err)
+	_, err = ParseKibanaVersion("8.x.0")
+	assert.Error(t, err)
+
+	// Get Kibana version
+	kibana := newFakeKibana(t, func(r *fakeKibanaRequest) (int, string) {
+		return http.StatusNotFound, `{}`
+	})
+	kibana.Version = "8.12.0"
+	version, err = kibana.API.KibanaStatus.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "8.12.0", version.String())
+
+	// Version is cached
+	kibana.Version = "8.13.0"
+	cachedVersion, err := kibana.API.KibanaStatus.GetVersion()
+	assert.NoError(t, err)
+	assert.Same(t, version, cachedVersion)
+
+	// Error is cached, so status is not read on each call
+	calls := 0
+	versionCache := &kibanaVersionCache{
+		getDetails: func(options *KibanaStatusOptions) (*KibanaStatusDetails, error) {
+			calls++
+			return nil, NewAPIError(403, "Forbidden")
+		},
+	}
+	_, err = versionCache.get()
+	assert.Error(t, err)
+	_, err = versionCache.get()
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Status is read again after retry interval
+	versionCache.retryAt = versionCache.retryAt.Add(-kibanaVersionRetryInterval)
+	versionCache.getDetails = func(options *KibanaStatusOptions) (*KibanaStatusDetails, error) {
+		calls++
+		return &KibanaStatusDetails{Version: KibanaStatusVersion{Number: "8.12.0"}}, nil
+	}
+	version, err = versionCache.get()
+	assert.NoError(t, err)
+	assert.Equal(t, "8.12.0", version.String())
+	_, _ = versionCache.get()
+	assert.Equal(t, 2, calls)
+}
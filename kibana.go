@@ -1,6 +1,7 @@
 package kibana
 
 import (
+	"context"
 	"crypto/tls"
 
 	"github.com/disaster37/go-kibana-rest/v8/kbapi"
@@ -14,6 +15,10 @@ type Config struct {
 	Password         string
 	DisableVerifySSL bool
 	CAs              []string
+	// APIKey is the encoded API key used instead of username and password, like project API key on serverless
+	APIKey string
+	// Serverless set the headers needed by Kibana serverless and refuse the API not available on it
+	Serverless bool
 }
 
 // Client contain the REST client and the API specification
@@ -35,10 +40,19 @@ func NewClient(cfg Config) (*Client, error) {
 
 	restyClient := resty.New().
 		SetBaseURL(cfg.Address).
-		SetBasicAuth(cfg.Username, cfg.Password).
 		SetHeader("kbn-xsrf", "true").
 		SetHeader("Content-Type", "application/json")
 
+	if cfg.APIKey != "" {
+		restyClient.SetAuthScheme("ApiKey").SetAuthToken(cfg.APIKey)
+	} else {
+		restyClient.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	if cfg.Serverless {
+		restyClient.OnBeforeRequest(kbapi.NewServerlessMiddleware())
+	}
+
 	for _, path := range cfg.CAs {
 		restyClient.SetRootCertificate(path)
 	}
@@ -55,3 +69,19 @@ func NewClient(cfg Config) (*Client, error) {
 	return client, nil
 
 }
+
+// WaitUntilReady wait that Kibana overall status is available, or reach the minimum level provided on options.
+// It return the last status observed, and KibanaStatusNotReadyError when context or timeout expire before.
+func (c *Client) WaitUntilReady(ctx context.Context, opts *kbapi.KibanaStatusWaitOptions) (*kbapi.KibanaStatusDetails, error) {
+	return c.API.KibanaStatus.WaitUntilReady(ctx, opts)
+}
+
+// Version return the Kibana version. It is read from status on first call, then cached.
+func (c *Client) Version() (*kbapi.KibanaVersion, error) {
+	return c.API.KibanaStatus.GetVersion()
+}
+
+// DeepLink return the full URL to open Kibana application with locator params, on space
+func (c *Client) DeepLink(params kbapi.LocatorParams, kibanaSpace string) (string, error) {
+	return kbapi.BuildKibanaURL(c.Client.BaseURL, kibanaSpace, params)
+}
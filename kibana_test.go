@@ -1,8 +1,14 @@
 package kibana
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -55,3 +61,157 @@ func (s *KBTestSuite) TestNewDefaultClient() {
 	assert.NotNil(s.T(), client)
 
 }
+
+func (s *KBTestSuite) TestWaitUntilReady() {
+
+	client, err := NewClient(Config{
+		Address: "http://127.0.0.1:1",
+	})
+	assert.NoError(s.T(), err)
+
+	// Kibana is not reachable, so wait until timeout
+	attempts := 0
+	_, err = client.WaitUntilReady(context.Background(), &kbapi.KibanaStatusWaitOptions{
+		Timeout:         200 * time.Millisecond,
+		InitialInterval: 10 * time.Millisecond,
+		OnProgress: func(attempt int, status *kbapi.KibanaStatusDetails, err error) {
+			attempts = attempt
+			assert.Error(s.T(), err)
+		},
+	})
+	assert.Error(s.T(), err)
+	assert.True(s.T(), errors.Is(err, context.DeadlineExceeded))
+	notReadyError := &kbapi.KibanaStatusNotReadyError{}
+	assert.True(s.T(), errors.As(err, &notReadyError))
+	assert.Equal(s.T(), attempts, notReadyError.Attempts)
+	assert.Greater(s.T(), notReadyError.Attempts, 1)
+	assert.Error(s.T(), notReadyError.LastError)
+
+	// Kibana accept connection but not answer, the call in progress is stopped by timeout
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	hangingClient, err := NewClient(Config{
+		Address: server.URL,
+	})
+	assert.NoError(s.T(), err)
+	start := time.Now()
+	_, err = hangingClient.WaitUntilReady(context.Background(), &kbapi.KibanaStatusWaitOptions{
+		Timeout: 200 * time.Millisecond,
+	})
+	assert.True(s.T(), errors.Is(err, context.DeadlineExceeded))
+	assert.Less(s.T(), time.Since(start), 5*time.Second)
+
+	// Minimum level must be valid
+	_, err = client.WaitUntilReady(context.Background(), &kbapi.KibanaStatusWaitOptions{
+		MinimumLevel: "green",
+	})
+	assert.Error(s.T(), err)
+}
+
+func (s *KBTestSuite) TestServerless() {
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "test", "name": "test"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Address:    server.URL,
+		APIKey:     "my-api-key",
+		Serverless: true,
+	})
+	assert.NoError(s.T(), err)
+
+	// Versioned API header and API key are sent
+	space, err := client.API.KibanaSpaces.Get("test")
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), space)
+	assert.Equal(s.T(), kbapi.ElasticAPIVersionPublic, headers.Get(kbapi.HeaderElasticAPIVersion))
+	assert.Equal(s.T(), "ApiKey my-api-key", headers.Get("Authorization"))
+
+	// API not available on serverless are refused without calling Kibana
+	headers = nil
+	_, err = client.API.KibanaLogstashPipeline.Get("test")
+	assert.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "not available on Kibana serverless")
+	_, err = client.API.KibanaRoleManagement.Get("test")
+	assert.Error(s.T(), err)
+	assert.Nil(s.T(), headers)
+
+	// Without serverless, there are no versioned API header
+	client, err = NewClient(Config{
+		Address: server.URL,
+	})
+	assert.NoError(s.T(), err)
+	_, err = client.API.KibanaSpaces.Get("test")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), headers.Get(kbapi.HeaderElasticAPIVersion))
+}
+
+func (s *KBTestSuite) TestDeepLink() {
+
+	client, err := NewClient(Config{
+		Address: "http://127.0.0.1:5601/",
+	})
+	assert.NoError(s.T(), err)
+
+	// Dashboard with time range, query and filters
+	dashboardURL, err := client.DeepLink(&kbapi.DashboardLocatorParams{
+		DashboardID: "edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b",
+		LocatorCommonParams: kbapi.LocatorCommonParams{
+			TimeRange: &kbapi.LocatorTimeRange{
+				From: "now-15m",
+				To:   "now",
+			},
+			RefreshInterval: &kbapi.LocatorRefreshInterval{
+				Pause: true,
+			},
+			Query: &kbapi.LocatorQuery{
+				Query:    "host.name: \"my host\"",
+				Language: "kuery",
+			},
+			Filters: []kbapi.LocatorFilter{
+				kbapi.NewPhraseFilter("service.name", "api"),
+			},
+		},
+		ViewMode: kbapi.DashboardViewModeView,
+	}, "test")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "http://127.0.0.1:5601/s/test/app/dashboards#/view/edf84fe0-e1a0-11e7-b6d5-4dc382ef7f5b"+
+		"?_g=(refreshInterval:(pause:!t,value:0),time:(from:now-15m,to:now))"+
+		"&_a=(filters:!(('$state':(store:appState),meta:(disabled:!f,key:service.name,negate:!f,params:(query:api),type:phrase),query:(match_phrase:(service.name:api)))),"+
+		"query:(language:kuery,query:'host.name:%20%22my%20host%22'),viewMode:view)", dashboardURL)
+
+	// Discover on default space
+	discoverURL, err := client.DeepLink(&kbapi.DiscoverLocatorParams{
+		DataViewID: "logs-*",
+		Columns:    []string{"message"},
+	}, "default")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "http://127.0.0.1:5601/app/discover#/?_a=(columns:!(message),index:'logs-*')", discoverURL)
+
+	// Visualize need ID or type
+	_, err = client.DeepLink(&kbapi.VisualizeLocatorParams{}, "")
+	assert.Error(s.T(), err)
+	visualizeURL, err := client.DeepLink(&kbapi.VisualizeLocatorParams{Type: "markdown"}, "")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "http://127.0.0.1:5601/app/visualize#/create?type=markdown", visualizeURL)
+
+	// Locator params as shorten URL
+	shortenURL, err := kbapi.NewShortenURL(&kbapi.LensLocatorParams{
+		SavedObjectID: "my-lens",
+	})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), kbapi.LocatorLens, shortenURL.LocatorId)
+	assert.Equal(s.T(), "my-lens", shortenURL.Params["savedObjectId"])
+}
@@ -0,0 +1,399 @@
+// Package rison permit to encode and decode Rison, the compact JSON format used by Kibana to store application state on URL.
+// See https://github.com/Nanonid/rison for the format.
+//
+// Values are converted to and from JSON, so the struct tags and the json.Marshaler of types are used.
+// O-Rison is the object without the surrounding parenthesis, and A-Rison is the array without the surrounding !( and ).
+package rison
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	notIDChar  = " '!:(),*@$"  // Characters that can't be used on identifier
+	notIDStart = "-0123456789" // Characters that can't start identifier
+)
+
+// SyntaxError is the error returned when data is not valid Rison
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+// Error return error message
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("rison: %s at offset %d", e.Message, e.Offset)
+}
+
+// Marshal return the Rison encoding of value
+func Marshal(value interface{}) ([]byte, error) {
+	normalizedValue, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	encode(buf, normalizedValue)
+	return buf.Bytes(), nil
+}
+
+// MarshalObject return the O-Rison encoding of value. Value must be encoded as JSON object.
+func MarshalObject(value interface{}) ([]byte, error) {
+	normalizedValue, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+	if _, isObject := normalizedValue.(map[string]interface{}); !isObject {
+		return nil, fmt.Errorf("rison: O-Rison need object, got %T", value)
+	}
+
+	buf := &bytes.Buffer{}
+	encode(buf, normalizedValue)
+	return buf.Bytes()[1 : buf.Len()-1], nil
+}
+
+// MarshalArray return the A-Rison encoding of value. Value must be encoded as JSON array.
+func MarshalArray(value interface{}) ([]byte, error) {
+	normalizedValue, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+	if _, isArray := normalizedValue.([]interface{}); !isArray {
+		return nil, fmt.Errorf("rison: A-Rison need array, got %T", value)
+	}
+
+	buf := &bytes.Buffer{}
+	encode(buf, normalizedValue)
+	return buf.Bytes()[2 : buf.Len()-1], nil
+}
+
+// Unmarshal decode Rison data and store the result on value pointed
+func Unmarshal(data []byte, value interface{}) error {
+	p := &parser{data: data}
+	decodedValue, err := p.parse()
+	if err != nil {
+		return err
+	}
+
+	return denormalize(decodedValue, value)
+}
+
+// UnmarshalObject decode O-Rison data and store the result on value pointed
+func UnmarshalObject(data []byte, value interface{}) error {
+	p := &parser{data: data, offset: -1}
+	decodedValue, err := p.parseWrapped("(", ")")
+	if err != nil {
+		return err
+	}
+
+	return denormalize(decodedValue, value)
+}
+
+// UnmarshalArray decode A-Rison data and store the result on value pointed
+func UnmarshalArray(data []byte, value interface{}) error {
+	p := &parser{data: data, offset: -2}
+	decodedValue, err := p.parseWrapped("!(", ")")
+	if err != nil {
+		return err
+	}
+
+	return denormalize(decodedValue, value)
+}
+
+// normalize return the value as decoded from JSON, with numbers kept as they are
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var normalizedValue interface{}
+	if err = decoder.Decode(&normalizedValue); err != nil {
+		return nil, err
+	}
+
+	return normalizedValue, nil
+}
+
+// denormalize store the value decoded from Rison on value pointed, with JSON
+func denormalize(decodedValue interface{}, value interface{}) error {
+	data, err := json.Marshal(decodedValue)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+// encode write the value decoded from JSON as Rison
+func encode(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("!n")
+	case bool:
+		if v {
+			buf.WriteString("!t")
+		} else {
+			buf.WriteString("!f")
+		}
+	case json.Number:
+		buf.WriteString(strings.Replace(strings.ToLower(v.String()), "e+", "e", 1))
+	case string:
+		encodeString(buf, v)
+	case []interface{}:
+		buf.WriteString("!(")
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encode(buf, item)
+		}
+		buf.WriteByte(')')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('(')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encodeString(buf, key)
+			buf.WriteByte(':')
+			encode(buf, v[key])
+		}
+		buf.WriteByte(')')
+	}
+}
+
+// encodeString write string as identifier when possible, else as quoted string
+func encodeString(buf *bytes.Buffer, value string) {
+	if isID(value) {
+		buf.WriteString(value)
+		return
+	}
+	buf.WriteByte('\'')
+	buf.WriteString(strings.NewReplacer("!", "!!", "'", "!'").Replace(value))
+	buf.WriteByte('\'')
+}
+
+// isID return true when string can be written without quote
+func isID(value string) bool {
+	return value != "" && !strings.ContainsAny(value, notIDChar) && !strings.ContainsAny(value[:1], notIDStart)
+}
+
+// parser read Rison data
+type parser struct {
+	data []byte
+	// offset is the position on data, used on error. It start before data when the wrapper of O-Rison or A-Rison is added.
+	offset int
+	pos    int
+}
+
+// parseWrapped parse data after adding prefix and suffix
+func (p *parser) parseWrapped(prefix string, suffix string) (interface{}, error) {
+	p.data = append(append([]byte(prefix), p.data...), suffix...)
+	return p.parse()
+}
+
+// parse read the only value of data
+func (p *parser) parse() (interface{}, error) {
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.data) {
+		return nil, p.error("unexpected character '%c'", p.data[p.pos])
+	}
+
+	return value, nil
+}
+
+// error return syntax error at current position
+func (p *parser) error(message string, params ...interface{}) error {
+	return &SyntaxError{
+		Offset:  p.pos + p.offset,
+		Message: fmt.Sprintf(message, params...),
+	}
+}
+
+// parseValue read value at current position
+func (p *parser) parseValue() (interface{}, error) {
+	if p.pos >= len(p.data) {
+		return nil, p.error("unexpected end of data")
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '(':
+		return p.parseObject()
+	case c == '!':
+		p.pos++
+		if p.pos >= len(p.data) {
+			return nil, p.error("unexpected end of data after '!'")
+		}
+		c = p.data[p.pos]
+		p.pos++
+		switch c {
+		case 't':
+			return true, nil
+		case 'f':
+			return false, nil
+		case 'n':
+			return nil, nil
+		case '(':
+			return p.parseArray()
+		}
+		p.pos--
+		return nil, p.error("unknown literal '!%c'", c)
+	case c == '\'':
+		return p.parseString()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	default:
+		return p.parseID()
+	}
+}
+
+// parseObject read object like (a:1,b:2)
+func (p *parser) parseObject() (interface{}, error) {
+	p.pos++
+	object := map[string]interface{}{}
+	if p.pos < len(p.data) && p.data[p.pos] == ')' {
+		p.pos++
+		return object, nil
+	}
+	for {
+		var key interface{}
+		var err error
+		if p.pos < len(p.data) && p.data[p.pos] == '\'' {
+			key, err = p.parseString()
+		} else {
+			key, err = p.parseID()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.data) || p.data[p.pos] != ':' {
+			return nil, p.error("missing ':' after key")
+		}
+		p.pos++
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		object[key.(string)] = value
+
+		if p.pos >= len(p.data) {
+			return nil, p.error("unterminated object")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return object, nil
+		default:
+			return nil, p.error("missing ',' or ')' on object")
+		}
+	}
+}
+
+// parseArray read array like !(1,2), after the !( prefix
+func (p *parser) parseArray() (interface{}, error) {
+	array := []interface{}{}
+	if p.pos < len(p.data) && p.data[p.pos] == ')' {
+		p.pos++
+		return array, nil
+	}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+
+		if p.pos >= len(p.data) {
+			return nil, p.error("unterminated array")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return array, nil
+		default:
+			return nil, p.error("missing ',' or ')' on array")
+		}
+	}
+}
+
+// parseString read quoted string like 'it!'s'
+func (p *parser) parseString() (interface{}, error) {
+	p.pos++
+	sb := &strings.Builder{}
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '\'':
+			return sb.String(), nil
+		case '!':
+			if p.pos >= len(p.data) {
+				return nil, p.error("unterminated string")
+			}
+			escaped := p.data[p.pos]
+			if escaped != '!' && escaped != '\'' {
+				return nil, p.error("invalid string escape '!%c'", escaped)
+			}
+			sb.WriteByte(escaped)
+			p.pos++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return nil, p.error("unterminated string")
+}
+
+// parseNumber read number like -1.5e3
+func (p *parser) parseNumber() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.data) && strings.IndexByte("-+.0123456789eE", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+	number := string(p.data[start:p.pos])
+	if !json.Valid([]byte(number)) {
+		p.pos = start
+		return nil, p.error("invalid number '%s'", number)
+	}
+
+	return json.Number(number), nil
+}
+
+// parseID read identifier, that is string without quote
+func (p *parser) parseID() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.data) && strings.IndexByte(notIDChar, p.data[p.pos]) < 0 {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.pos >= len(p.data) {
+			return nil, p.error("unexpected end of data")
+		}
+		return nil, p.error("unexpected character '%c'", p.data[p.pos])
+	}
+	id := string(p.data[start:p.pos])
+	if strings.IndexByte(notIDStart, id[0]) >= 0 {
+		p.pos = start
+		return nil, p.error("invalid identifier '%s'", id)
+	}
+
+	return id, nil
+}
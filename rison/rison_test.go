@@ -0,0 +1,190 @@
+package rison
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RisonTestSuite struct {
+	suite.Suite
+}
+
+type testTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type testState struct {
+	Time            testTimeRange          `json:"time"`
+	RefreshInterval map[string]interface{} `json:"refreshInterval"`
+	Filters         []interface{}          `json:"filters"`
+	Title           string                 `json:"title,omitempty"`
+	Columns         []string               `json:"columns,omitempty"`
+}
+
+func TestRisonTestSuite(t *testing.T) {
+	suite.Run(t, new(RisonTestSuite))
+}
+
+func (s *RisonTestSuite) TestMarshal() {
+
+	testCases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: nil, expected: "!n"},
+		{value: true, expected: "!t"},
+		{value: false, expected: "!f"},
+		{value: 0, expected: "0"},
+		{value: -1.5, expected: "-1.5"},
+		{value: 1e21, expected: "1e21"},
+		{value: "", expected: "''"},
+		{value: "abc", expected: "abc"},
+		{value: "now-15m", expected: "now-15m"},
+		{value: "-abc", expected: "'-abc'"},
+		{value: "1abc", expected: "'1abc'"},
+		{value: "it's ok!", expected: "'it!'s ok!!'"},
+		{value: "$state", expected: "'$state'"},
+		{value: []string{}, expected: "!()"},
+		{value: []interface{}{1, "a", true, nil}, expected: "!(1,a,!t,!n)"},
+		{value: map[string]interface{}{}, expected: "()"},
+		{value: map[string]interface{}{"b": 1, "a": "x y"}, expected: "(a:'x y',b:1)"},
+	}
+	for _, testCase := range testCases {
+		data, err := Marshal(testCase.value)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), testCase.expected, string(data))
+	}
+
+	// Struct use JSON tags
+	data, err := Marshal(&testState{
+		Time:            testTimeRange{From: "now-15m", To: "now"},
+		RefreshInterval: map[string]interface{}{"pause": true, "value": 0},
+		Filters:         []interface{}{},
+	})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "(filters:!(),refreshInterval:(pause:!t,value:0),time:(from:now-15m,to:now))", string(data))
+
+	// Value that can't be encoded as JSON
+	_, err = Marshal(make(chan int))
+	assert.Error(s.T(), err)
+}
+
+func (s *RisonTestSuite) TestUnmarshal() {
+
+	var value interface{}
+	testCases := []struct {
+		data     string
+		expected interface{}
+	}{
+		{data: "!n", expected: nil},
+		{data: "!t", expected: true},
+		{data: "!f", expected: false},
+		{data: "-1.5e3", expected: -1500.0},
+		{data: "''", expected: ""},
+		{data: "abc", expected: "abc"},
+		{data: "'it!'s ok!!'", expected: "it's ok!"},
+		{data: "!()", expected: []interface{}{}},
+		{data: "!(1,a,!t,!n,!(b))", expected: []interface{}{1.0, "a", true, nil, []interface{}{"b"}}},
+		{data: "()", expected: map[string]interface{}{}},
+		{data: "(a:'x y','$state':(store:appState))", expected: map[string]interface{}{"a": "x y", "$state": map[string]interface{}{"store": "appState"}}},
+	}
+	for _, testCase := range testCases {
+		err := Unmarshal([]byte(testCase.data), &value)
+		assert.NoError(s.T(), err, testCase.data)
+		assert.Equal(s.T(), testCase.expected, value, testCase.data)
+	}
+
+	// Bad rison
+	for _, data := range []string{"", "(", "(a)", "(a:1", "(a:1;b:2)", "!(1", "!x", "'abc", "'a!b'", "1-", "-abc", "a b", "(a:1))"} {
+		err := Unmarshal([]byte(data), &value)
+		assert.Error(s.T(), err, data)
+		syntaxError := &SyntaxError{}
+		assert.True(s.T(), errors.As(err, &syntaxError), data)
+	}
+
+	// Type mismatch is reported by JSON
+	var number int
+	err := Unmarshal([]byte("abc"), &number)
+	assert.Error(s.T(), err)
+	typeError := &json.UnmarshalTypeError{}
+	assert.True(s.T(), errors.As(err, &typeError))
+}
+
+func (s *RisonTestSuite) TestRoundTrip() {
+
+	expected := &testState{
+		Time:            testTimeRange{From: "2023-01-01T00:00:00.000Z", To: "now"},
+		RefreshInterval: map[string]interface{}{"pause": false, "value": 10000.0},
+		Filters: []interface{}{
+			map[string]interface{}{
+				"meta":  map[string]interface{}{"key": "service.name", "negate": false},
+				"query": map[string]interface{}{"match_phrase": map[string]interface{}{"service.name": "it's (api)"}},
+			},
+		},
+		Title:   "New Dashboard",
+		Columns: []string{"message", "@timestamp"},
+	}
+
+	data, err := Marshal(expected)
+	assert.NoError(s.T(), err)
+	state := &testState{}
+	err = Unmarshal(data, state)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, state)
+
+	// O-Rison
+	data, err = MarshalObject(expected)
+	assert.NoError(s.T(), err)
+	assert.NotEqual(s.T(), byte('('), data[0])
+	state = &testState{}
+	err = UnmarshalObject(data, state)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, state)
+
+	// A-Rison
+	data, err = MarshalArray(expected.Columns)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "message,'@timestamp'", string(data))
+	var columns []string
+	err = UnmarshalArray(data, &columns)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), expected.Columns, columns)
+}
+
+func (s *RisonTestSuite) TestObjectAndArray() {
+
+	// O-Rison need object and A-Rison need array
+	_, err := MarshalObject([]string{"a"})
+	assert.Error(s.T(), err)
+	_, err = MarshalArray(map[string]string{"a": "b"})
+	assert.Error(s.T(), err)
+
+	// Empty O-Rison and A-Rison
+	data, err := MarshalObject(map[string]string{})
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), data)
+	data, err = MarshalArray([]string{})
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), data)
+	object := map[string]interface{}{}
+	err = UnmarshalObject([]byte(""), &object)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), object)
+
+	// Offset of syntax error is on data provided
+	err = UnmarshalObject([]byte("a:1,b"), &object)
+	syntaxError := &SyntaxError{}
+	if assert.True(s.T(), errors.As(err, &syntaxError)) {
+		assert.Equal(s.T(), 5, syntaxError.Offset)
+	}
+	var array []interface{}
+	err = UnmarshalArray([]byte("1,!x"), &array)
+	if assert.True(s.T(), errors.As(err, &syntaxError)) {
+		assert.Equal(s.T(), 3, syntaxError.Offset)
+	}
+}